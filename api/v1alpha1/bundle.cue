@@ -19,4 +19,19 @@ _#ObjectMeta: {
 		components: [string]: {...}
 		...
 	}
+	transforms?: [Name=string]: #Transform
+}
+
+// #Transform post-processes every resource the bundle renders. odin fills in
+// with each resource in turn and renders out in its place, e.g.
+//
+//	transforms: managedBy: {
+//		in:  _
+//		out: in & {metadata: labels: "app.kubernetes.io/managed-by": "odin"}
+//	}
+//
+// Transforms are applied in the order they're declared.
+#Transform: {
+	in:  {...}
+	out: {...}
 }
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/cmd/api"
+)
+
+type apiCmd struct {
+	logger     *slog.Logger
+	config     config.Manager
+	cacheDir   string
+	bundlePath string
+	definition string
+	format     string
+	expand     bool
+}
+
+func (c *apiCmd) Args(cmd *cobra.Command, args []string) error {
+	if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		c.definition = args[0]
+	}
+	return nil
+}
+
+func (c *apiCmd) PreRunE(cmd *cobra.Command, args []string) error {
+	sharedOpts := sharedOptsFromCommand(cmd)
+	c.cacheDir = sharedOpts.CacheDir
+	c.logger = loggerFromCommand(cmd)
+	c.config = configFromCommand(cmd)
+
+	if err := ensureCacheDir(c.cacheDir); err != nil {
+		return err
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
+	return nil
+}
+
+func (c *apiCmd) RunE(cmd *cobra.Command, args []string) error {
+	opts := api.Options{
+		BundlePath: c.bundlePath,
+		Definition: c.definition,
+		Format:     c.format,
+		Expand:     c.expand,
+		CacheDir:   c.cacheDir,
+		Offline:    sharedOptsFromCommand(cmd).Offline,
+		Logger:     c.logger.With("component", "api"),
+		Timings:    sharedOptsFromCommand(cmd).Timings,
+	}
+	globalRegistries, err := c.config.ModuleRegistries()
+	if err != nil {
+		return err
+	}
+	opts.Registries = globalRegistries
+	return opts.Run(cmd.Context())
+}
+
+func newAPICmd() *cobra.Command {
+	c := &apiCmd{
+		bundlePath: ".",
+		format:     "text",
+	}
+	cmd := &cobra.Command{
+		Use:   "api [definition]",
+		Short: "show the schema of the odin API",
+		Long: `Show the schema of a definition in the odin API
+(go-valkyrie.com/odin/api/v1alpha1), using the version of the API the bundle
+depends on.
+
+Without an argument the schema of #ComponentBase, which component templates
+must conform to, is shown. Name another definition, such as #Component or
+#Bundle, to show it instead; the leading # may be left out.
+
+Output formats (-f/--format): text, markdown/md, json.`,
+		Args:    c.Args,
+		PreRunE: c.PreRunE,
+		RunE:    c.RunE,
+	}
+
+	cmd.Flags().StringVarP(&c.bundlePath, "bundle", "b", ".", "bundle location")
+	cmd.Flags().StringVarP(&c.format, "format", "f", "text", "output format (text, markdown/md, json)")
+	cmd.Flags().BoolVar(&c.expand, "expand", false, "recursively expand referenced definitions inline")
+
+	return cmd
+}
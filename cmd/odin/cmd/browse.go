@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/cmd/browse"
+)
+
+type browseCmd struct {
+	logger     *slog.Logger
+	config     config.Manager
+	cacheDir   string
+	bundlePath string
+	expand     bool
+}
+
+func (c *browseCmd) Args(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	if len(args) > 0 {
+		c.bundlePath = args[0]
+	} else {
+		c.bundlePath = "."
+	}
+	return nil
+}
+
+func (c *browseCmd) PreRunE(cmd *cobra.Command, args []string) error {
+	sharedOpts := sharedOptsFromCommand(cmd)
+	c.cacheDir = sharedOpts.CacheDir
+	c.logger = loggerFromCommand(cmd)
+	c.config = configFromCommand(cmd)
+
+	if err := ensureCacheDir(c.cacheDir); err != nil {
+		return err
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
+	return nil
+}
+
+func (c *browseCmd) RunE(cmd *cobra.Command, args []string) error {
+	opts := browse.Options{
+		BundlePath: c.bundlePath,
+		Expand:     c.expand,
+		CacheDir:   c.cacheDir,
+		Offline:    sharedOptsFromCommand(cmd).Offline,
+		Logger:     c.logger.With("component", "browse"),
+		Input:      cmd.InOrStdin(),
+		Output:     cmd.OutOrStdout(),
+	}
+	globalRegistries, err := c.config.ModuleRegistries()
+	if err != nil {
+		return err
+	}
+	opts.Registries = globalRegistries
+	return opts.Run(cmd.Context())
+}
+
+func newBrowseCmd() *cobra.Command {
+	c := &browseCmd{}
+	cmd := &cobra.Command{
+		Use:   "browse [location]",
+		Short: "interactively browse component templates",
+		Long: `Browse the component templates available to a bundle in a terminal UI.
+
+The left pane lists discovered templates and the right pane shows the selected
+template's config schema as a tree. Use the arrow keys (or j/k) to move, tab to
+switch panes, enter or right to expand a field, left to collapse it, and q to
+quit. Requires an interactive terminal.`,
+		Args:    c.Args,
+		PreRunE: c.PreRunE,
+		RunE:    c.RunE,
+	}
+
+	cmd.Flags().BoolVar(&c.expand, "expand", false, "recursively expand referenced definitions inline")
+
+	return cmd
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/pkg/model"
 	"io/fs"
 	"log/slog"
 )
@@ -13,16 +14,51 @@ import (
 type cacheCleanCmd struct {
 	logger     *slog.Logger
 	sharedOpts *sharedOptions
+	prune      bool
+	dryRun     bool
+	bundlePath string
+}
+
+func (c *cacheCleanCmd) Args(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && !c.prune {
+		return fmt.Errorf("a bundle location can only be given with --prune")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	if len(args) > 0 {
+		c.bundlePath = args[0]
+	} else {
+		c.bundlePath = "."
+	}
+	return nil
 }
 
 func (c *cacheCleanCmd) PreRunE(cmd *cobra.Command, args []string) error {
 	c.logger = loggerFromCommand(cmd)
 	c.sharedOpts = sharedOptsFromCommand(cmd)
 
+	if c.dryRun && !c.prune {
+		return fmt.Errorf("--dry-run can only be used with --prune")
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.prune && c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
 	return nil
 }
 
 func (c *cacheCleanCmd) RunE(cmd *cobra.Command, args []string) error {
+	if c.prune {
+		return c.runPrune(cmd)
+	}
+
 	cacheDir := c.sharedOpts.CacheDir
 	verbose := c.sharedOpts.Verbose
 	fmt.Printf("cleaning cache directory %s\n", cacheDir)
@@ -53,14 +89,62 @@ func (c *cacheCleanCmd) RunE(cmd *cobra.Command, args []string) error {
 	return dirFS.RemoveAll(".")
 }
 
+// runPrune removes the cached modules that the bundle doesn't depend on.
+func (c *cacheCleanCmd) runPrune(cmd *cobra.Command) error {
+	registries, err := configFromCommand(cmd).ModuleRegistries()
+	if err != nil {
+		return err
+	}
+
+	b, err := model.LoadBundle(c.bundlePath,
+		model.WithLogger(c.logger.With("component", "cache")),
+		model.WithRegistries(registries),
+		model.WithCacheDir(c.sharedOpts.CacheDir),
+		model.WithOffline(c.sharedOpts.Offline),
+	)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	pruned, err := b.PruneCache(c.sharedOpts.CacheDir, c.dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "pruned"
+	if c.dryRun {
+		verb = "would prune"
+	}
+	out := cmd.OutOrStdout()
+	for _, module := range pruned {
+		fmt.Fprintf(out, "%s %s\n", verb, module)
+	}
+	if len(pruned) == 0 {
+		fmt.Fprintln(out, "nothing to prune")
+	}
+	return nil
+}
+
 func newCacheCleanCmd() *cobra.Command {
 	c := &cacheCleanCmd{}
 
 	cmd := &cobra.Command{
-		Use:     "clean",
+		Use:   "clean [location]",
+		Short: "remove cached modules",
+		Long: `Remove cached modules.
+
+By default the whole cache is removed. With --prune, only the modules that
+the bundle at location (by default, the one containing the current directory)
+doesn't depend on are removed, keeping those it does so they needn't be
+fetched again. --dry-run lists what --prune would remove without removing it.`,
+		Args:    c.Args,
 		PreRunE: c.PreRunE,
 		RunE:    c.RunE,
 	}
 
+	cmd.Flags().BoolVar(&c.prune, "prune", false, "only remove modules the bundle doesn't depend on")
+	cmd.Flags().BoolVar(&c.dryRun, "dry-run", false, "with --prune, list the modules that would be removed without removing them")
+
 	return cmd
 }
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/pkg/cmd/docs"
+)
+
+// completeComponentReferences completes a component template reference for
+// the bundle selected by the command's --bundle flag, with the config named
+// by --config. Any failure yields no completions rather than an error, since
+// completion must never break the shell.
+func completeComponentReferences(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	bundlePath, err := cmd.Flags().GetString("bundle")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if bundlePath == "." {
+		if bundlePath, err = findBundleRoot("."); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+
+	sharedOpts := sharedOptsFromCommand(cmd)
+	if sharedOpts == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	// The root command's setup ran before the flags were parsed, so the
+	// config is loaded here, now that --config is known.
+	logger := loggerFromCommand(cmd)
+	cfg, err := loadConfig(logger, sharedOpts.ConfigPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	opts := docs.Options{
+		BundlePath: bundlePath,
+		CacheDir:   sharedOpts.CacheDir,
+		Offline:    sharedOpts.Offline,
+		Logger:     logger,
+	}
+	if registries, err := cfg.ModuleRegistries(); err == nil {
+		opts.Registries = registries
+	}
+
+	references, err := opts.References(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(references))
+	for _, ref := range references {
+		if strings.HasPrefix(strings.ToLower(ref), strings.ToLower(toComplete)) {
+			completions = append(completions, ref)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/mod/modregistrytest"
+)
+
+// writeFiles writes files, keyed by slash-separated relative path, under dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestCompleteComponentReferencesUsesConfigFlag(t *testing.T) {
+	apiPath, err := filepath.Abs(filepath.Join("..", "..", "..", "api"))
+	if err != nil {
+		t.Fatalf("failed to resolve api path: %v", err)
+	}
+	registryDir := t.TempDir()
+	if err := os.CopyFS(filepath.Join(registryDir, "go-valkyrie.com_odin_api_v0.0.0-test"), os.DirFS(apiPath)); err != nil {
+		t.Fatalf("failed to copy odin API module: %v", err)
+	}
+	writeFiles(t, filepath.Join(registryDir, "example.com_platform_v0.0.0-test"), map[string]string{
+		"cue.mod/module.cue": `module: "example.com/platform@v0"
+language: version: "v0.14.0"
+deps: "go-valkyrie.com/odin/api@v0": v: "v0.0.0-test"
+`,
+		"workload/workload.cue": `package workload
+
+import odin "go-valkyrie.com/odin/api/v1alpha1"
+
+#WebApp: odin.#Component & {
+	config: image: string
+}
+`,
+	})
+	registry, err := modregistrytest.New(os.DirFS(registryDir), "")
+	if err != nil {
+		t.Fatalf("failed to start registry: %v", err)
+	}
+	t.Cleanup(registry.Close)
+
+	bundleDir := t.TempDir()
+	writeFiles(t, bundleDir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/bundle@v0"
+language: version: "v0.14.0"
+deps: {
+	"example.com/platform@v0": v: "v0.0.0-test"
+	"go-valkyrie.com/odin/api@v0": v: "v0.0.0-test"
+}
+`,
+		"bundle.cue": `package bundle
+
+import odin "go-valkyrie.com/odin/api/v1alpha1"
+
+odin.#Bundle & {
+	metadata: name: "webapp"
+}
+`,
+	})
+
+	// Only the config named by --config knows the registry; the default
+	// config is broken, so completing with it yields nothing.
+	configDir := t.TempDir()
+	writeFiles(t, configDir, map[string]string{
+		"odin/config.cue": "cue: registries: {\n",
+		"flag.cue": `cue: registries: {
+	"go-valkyrie.com": "` + registry.Host() + `+insecure"
+	"example.com":     "` + registry.Host() + `+insecure"
+}
+`,
+	})
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("ODIN_REGISTRY", "")
+	t.Chdir(t.TempDir())
+
+	complete := func(args ...string) []string {
+		t.Helper()
+		var out bytes.Buffer
+		root := newRootCmd(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		root.SetArgs(append([]string{"__complete", "docs", "--bundle", bundleDir}, args...))
+		root.SetOut(&out)
+		root.SetErr(io.Discard)
+		if err := root.Execute(); err != nil {
+			t.Fatalf("completion error = %v", err)
+		}
+		var completions []string
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			if !strings.HasPrefix(line, ":") {
+				completions = append(completions, line)
+			}
+		}
+		return completions
+	}
+
+	if got := complete(""); len(got) != 0 {
+		t.Errorf("completions with the broken default config = %v, want none", got)
+	}
+	got := complete("--config", filepath.Join(configDir, "flag.cue"), "work")
+	if !strings.Contains(strings.Join(got, "\n"), "workload.WebApp") {
+		t.Errorf("completions with --config = %v, want workload.WebApp", got)
+	}
+}
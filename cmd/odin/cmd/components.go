@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"go-valkyrie.com/odin/internal/config"
 	"go-valkyrie.com/odin/pkg/cmd/components"
+	"go-valkyrie.com/odin/pkg/model"
 )
 
 type componentsCmd struct {
@@ -17,6 +18,12 @@ type componentsCmd struct {
 	cacheDir   string
 	bundlePath string
 	format     string
+	scope      string
+	showBase   bool
+	wide       bool
+	excludeDep []string
+	strict     bool
+	bases      []string
 }
 
 func (c *componentsCmd) Args(cmd *cobra.Command, args []string) error {
@@ -55,10 +62,18 @@ func (c *componentsCmd) PreRunE(cmd *cobra.Command, args []string) error {
 
 func (c *componentsCmd) RunE(cmd *cobra.Command, args []string) error {
 	opts := components.Options{
-		BundlePath: c.bundlePath,
-		Format:     c.format,
-		CacheDir:   c.cacheDir,
-		Logger:     c.logger.With("component", "components"),
+		BundlePath:          c.bundlePath,
+		Format:              c.format,
+		Scope:               c.scope,
+		ShowBase:            c.showBase,
+		Wide:                c.wide,
+		ExcludeDependencies: c.excludeDep,
+		Strict:              c.strict,
+		ComponentBases:      c.bases,
+		CacheDir:            c.cacheDir,
+		Offline:             sharedOptsFromCommand(cmd).Offline,
+		Logger:              c.logger.With("component", "components"),
+		Timings:             sharedOptsFromCommand(cmd).Timings,
 	}
 	globalRegistries, err := c.config.ModuleRegistries()
 	if err != nil {
@@ -71,6 +86,7 @@ func (c *componentsCmd) RunE(cmd *cobra.Command, args []string) error {
 func newComponentsCmd() *cobra.Command {
 	c := &componentsCmd{
 		format: "table",
+		scope:  "all",
 	}
 	cmd := &cobra.Command{
 		Use:     "components [location]",
@@ -80,7 +96,13 @@ func newComponentsCmd() *cobra.Command {
 		RunE:    c.RunE,
 	}
 
-	cmd.Flags().StringVarP(&c.format, "format", "f", "table", "output format (table, json)")
+	cmd.Flags().StringVarP(&c.format, "format", "f", "table", "output format (table, wide, json, ndjson: one JSON object per line, written as templates are found)")
+	cmd.Flags().BoolVar(&c.wide, "wide", false, "don't truncate long package paths to fit the terminal (same as --format wide)")
+	cmd.Flags().StringVar(&c.scope, "scope", "all", "which templates to list (all, dependencies, local)")
+	cmd.Flags().BoolVar(&c.showBase, "show-base", false, "show the component base definition each template matched (table format)")
+	cmd.Flags().StringArrayVar(&c.excludeDep, "exclude-dep", nil, "skip dependencies whose module path matches this glob or prefix (repeatable)")
+	cmd.Flags().BoolVar(&c.strict, "strict", false, "fail if a package can't be built, or marks a template @odin(component) that doesn't unify with a component base, instead of skipping it with a warning")
+	cmd.Flags().StringArrayVar(&c.bases, "base", nil, "component base definition templates are discovered by, as <import path>:#<Definition> (repeatable, default "+model.DefaultComponentBase+")")
 
 	return cmd
 }
@@ -11,6 +11,8 @@ func newConfigCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newConfigEvalCmd())
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigValidateCmd())
 
 	return cmd
 }
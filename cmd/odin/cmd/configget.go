@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+)
+
+type configGetCmd struct {
+	json bool
+}
+
+func (c *configGetCmd) RunE(cmd *cobra.Command, args []string) error {
+	value, err := configFromCommand(cmd).Get(args[0])
+	if err != nil {
+		return err
+	}
+	data, err := config.FormatValue(value, c.json)
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(data)
+	return err
+}
+
+func newConfigGetCmd() *cobra.Command {
+	c := &configGetCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "get <path>",
+		Short: "print a single config value",
+		Long: `Print the config value at a CUE path, e.g. cue.registries or
+defaults.prompt. Strings, numbers and booleans are printed as they are, for
+use in scripts; structs and lists are printed as CUE, or as JSON with --json.`,
+		Args: cobra.ExactArgs(1),
+		RunE: c.RunE,
+	}
+
+	cmd.Flags().BoolVar(&c.json, "json", false, "print the value as JSON")
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+)
+
+type configValidateCmd struct {
+}
+
+func (c *configValidateCmd) RunE(cmd *cobra.Command, args []string) error {
+	// A config that doesn't validate isn't a usage error.
+	cmd.SilenceUsage = true
+	path, err := config.Validate(sharedOptsFromCommand(cmd).ConfigPath)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		fmt.Fprintln(cmd.OutOrStdout(), "no config file found, the defaults are valid")
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s is valid\n", path)
+	}
+	return nil
+}
+
+func newConfigValidateCmd() *cobra.Command {
+	c := &configValidateCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "check the config file against the config schema",
+		Long: `Check the config file against the config schema, reporting each problem
+with its position. The file checked is the one given with --config, or the
+first one found in the usual locations.`,
+		Args: cobra.NoArgs,
+		RunE: c.RunE,
+		// The config is loaded here, where its errors are the output, rather
+		// than failing before the command runs.
+		Annotations: map[string]string{skipConfigAnnotation: "true"},
+	}
+
+	return cmd
+}
@@ -5,6 +5,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/model"
 	"log/slog"
 )
 
@@ -14,12 +15,18 @@ var (
 	configManagerCtxKey contextKey = "configManager"
 	sharedOptsCtxKey    contextKey = "sharedOpts"
 	loggerCtxKey        contextKey = "logger"
+	profilerCtxKey      contextKey = "profiler"
 )
 
 type sharedOptions struct {
 	ConfigPath string
 	CacheDir   string
 	Verbose    bool
+	// Offline restricts bundle loading to modules already in the cache.
+	Offline bool
+	// Timings collects phase timings of bundle loading when --timings is
+	// set, and is nil otherwise.
+	Timings *model.Timings
 }
 
 func configFromCommand(cmd *cobra.Command) config.Manager {
@@ -31,7 +31,12 @@ func cuePreRunE(cmd *cobra.Command, args []string) error {
 
 	logger.Debug("merged registries", "registries", registries)
 
-	env := utils.CreateCueEnvironment(sharedOpts.CacheDir, registries)
+	var env []string
+	if sharedOpts.Offline {
+		env = utils.CreateOfflineCueEnvironment(sharedOpts.CacheDir, registries)
+	} else {
+		env = utils.CreateCueEnvironment(sharedOpts.CacheDir, registries)
+	}
 	logger.Debug("using CUE environment", "env", env)
 
 	for _, e := range env {
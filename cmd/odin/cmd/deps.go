@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/cmd/deps"
+)
+
+type depsCmd struct {
+	logger     *slog.Logger
+	config     config.Manager
+	cacheDir   string
+	bundlePath string
+	format     string
+}
+
+func (c *depsCmd) Args(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	if len(args) > 0 {
+		c.bundlePath = args[0]
+	} else {
+		c.bundlePath = "."
+	}
+	return nil
+}
+
+func (c *depsCmd) PreRunE(cmd *cobra.Command, args []string) error {
+	sharedOpts := sharedOptsFromCommand(cmd)
+	c.cacheDir = sharedOpts.CacheDir
+	c.logger = loggerFromCommand(cmd)
+	c.config = configFromCommand(cmd)
+
+	if err := ensureCacheDir(c.cacheDir); err != nil {
+		return err
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
+	return nil
+}
+
+func (c *depsCmd) RunE(cmd *cobra.Command, args []string) error {
+	opts := deps.Options{
+		BundlePath: c.bundlePath,
+		Format:     c.format,
+		CacheDir:   c.cacheDir,
+		Offline:    sharedOptsFromCommand(cmd).Offline,
+		Logger:     c.logger.With("component", "deps"),
+	}
+	globalRegistries, err := c.config.ModuleRegistries()
+	if err != nil {
+		return err
+	}
+	opts.Registries = globalRegistries
+	return opts.Run(cmd.Context())
+}
+
+func newDepsCmd() *cobra.Command {
+	c := &depsCmd{
+		format: "table",
+	}
+	cmd := &cobra.Command{
+		Use:   "deps [location]",
+		Short: "check bundle dependencies for newer versions",
+		Long: `Check bundle dependencies for newer versions.
+
+Each module required by the bundle's cue.mod/module.cue is looked up in its
+registry, and the version in use is reported alongside the latest version
+available. Modules that can't be queried are reported individually without
+stopping the rest of the check.`,
+		Args:    c.Args,
+		PreRunE: c.PreRunE,
+		RunE:    c.RunE,
+	}
+
+	cmd.Flags().StringVarP(&c.format, "format", "f", "table", "output format (table, json)")
+
+	return cmd
+}
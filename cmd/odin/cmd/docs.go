@@ -9,18 +9,27 @@ import (
 	"github.com/spf13/cobra"
 	"go-valkyrie.com/odin/internal/config"
 	"go-valkyrie.com/odin/pkg/cmd/docs"
+	"go-valkyrie.com/odin/pkg/model"
 )
 
 type docsCmd struct {
-	logger     *slog.Logger
-	config     config.Manager
-	cacheDir   string
-	bundlePath string
-	reference  string
-	expand     bool
-	format     string
-	outputPath string
-	noSummary  bool
+	logger        *slog.Logger
+	config        config.Manager
+	cacheDir      string
+	bundlePath    string
+	reference     string
+	expand        bool
+	depth         int
+	format        string
+	outputPath    string
+	noSummary     bool
+	explain       string
+	path          string
+	excludeDep    []string
+	strict        bool
+	caseSensitive bool
+	bases         []string
+	example       string
 }
 
 func (c *docsCmd) Args(cmd *cobra.Command, args []string) error {
@@ -50,12 +59,28 @@ func (c *docsCmd) PreRunE(cmd *cobra.Command, args []string) error {
 		c.bundlePath = root
 	}
 
+	// Apply bundle defaults from odin.toml for flags not set explicitly.
+	bundleCfg, err := model.LoadConfig(c.bundlePath)
+	if err != nil {
+		return err
+	}
+	if !cmd.Flags().Changed("format") && bundleCfg.Bundle.Format != "" {
+		c.format = bundleCfg.Bundle.Format
+	}
+
 	return nil
 }
 
 func (c *docsCmd) RunE(cmd *cobra.Command, args []string) error {
+	if c.explain != "" && c.expand {
+		return fmt.Errorf("--explain cannot be combined with --expand")
+	}
+	if c.explain != "" && c.path != "" {
+		return fmt.Errorf("--explain cannot be combined with --path")
+	}
+
 	// Validate format-specific requirements
-	if (c.format == "markdown-multi" || c.format == "mdm" || c.format == "mdbook" || c.format == "mdb") && c.outputPath == "" {
+	if c.explain == "" && (c.format == "markdown-multi" || c.format == "mdm" || c.format == "mdbook" || c.format == "mdb") && c.outputPath == "" {
 		return fmt.Errorf("format %q requires -o/--output to specify a directory path", c.format)
 	}
 	if c.noSummary && c.format != "mdbook" && c.format != "mdb" {
@@ -63,14 +88,24 @@ func (c *docsCmd) RunE(cmd *cobra.Command, args []string) error {
 	}
 
 	opts := docs.Options{
-		BundlePath: c.bundlePath,
-		Reference:  c.reference,
-		Expand:     c.expand,
-		Format:     c.format,
-		OutputPath: c.outputPath,
-		NoSummary:  c.noSummary,
-		CacheDir:   c.cacheDir,
-		Logger:     c.logger.With("component", "docs"),
+		BundlePath:          c.bundlePath,
+		Reference:           c.reference,
+		Expand:              c.expand,
+		Depth:               c.depth,
+		Format:              c.format,
+		OutputPath:          c.outputPath,
+		NoSummary:           c.noSummary,
+		Explain:             c.explain,
+		Path:                c.path,
+		ExcludeDependencies: c.excludeDep,
+		Strict:              c.strict,
+		CaseSensitive:       c.caseSensitive,
+		ComponentBases:      c.bases,
+		Example:             c.example,
+		CacheDir:            c.cacheDir,
+		Offline:             sharedOptsFromCommand(cmd).Offline,
+		Logger:              c.logger.With("component", "docs"),
+		Timings:             sharedOptsFromCommand(cmd).Timings,
 	}
 	globalRegistries, err := c.config.ModuleRegistries()
 	if err != nil {
@@ -84,6 +119,7 @@ func newDocsCmd() *cobra.Command {
 	c := &docsCmd{
 		bundlePath: ".",
 		format:     "text",
+		depth:      -1,
 	}
 	cmd := &cobra.Command{
 		Use:   "docs <reference>",
@@ -98,17 +134,40 @@ Output formats (-f/--format):
   - text (default): colored terminal output
   - markdown/md: single markdown document (concatenated if multiple templates)
   - markdown-multi/mdm: one markdown file per template (requires -o directory)
-  - mdbook/mdb: same as mdm plus SUMMARY.md (requires -o directory)`,
-		Args:    c.Args,
-		PreRunE: c.PreRunE,
-		RunE:    c.RunE,
+  - mdbook/mdb: same as mdm plus SUMMARY.md (requires -o directory)
+
+Use --explain <field.path> to show where a single field's constraints come
+from: each conjunct unified into the field is printed with the position it
+was declared at. The path is resolved within the template's config first,
+so "replicas" and "config.replicas" are equivalent. --explain requires a
+single template reference and always prints text.
+
+Use --path <field.path> to document only one config field and its subtree,
+e.g. --path database.pool. The path is relative to the template's config.
+
+Use --with-example to add an example config, with defaults filled in and
+placeholders for required fields, after the config schema in markdown
+output. It's CUE by default; --with-example=yaml writes YAML instead.`,
+		Args:              c.Args,
+		PreRunE:           c.PreRunE,
+		RunE:              c.RunE,
+		ValidArgsFunction: completeComponentReferences,
 	}
 
 	cmd.Flags().StringVarP(&c.bundlePath, "bundle", "b", ".", "bundle location")
 	cmd.Flags().BoolVar(&c.expand, "expand", false, "recursively expand referenced definitions inline")
+	cmd.Flags().IntVar(&c.depth, "depth", -1, "maximum nesting depth of fields to show, 0 for top-level only (default unlimited)")
 	cmd.Flags().StringVarP(&c.format, "format", "f", "text", "output format (text, markdown/md, markdown-multi/mdm, mdbook/mdb)")
 	cmd.Flags().StringVarP(&c.outputPath, "output", "o", "", "output file or directory path (required for mdm/mdb formats)")
 	cmd.Flags().BoolVar(&c.noSummary, "no-summary", false, "disable SUMMARY.md generation in mdbook format")
+	cmd.Flags().StringVar(&c.explain, "explain", "", "show the constraints contributing to a single field path")
+	cmd.Flags().StringVar(&c.path, "path", "", "only document the config field at this path and its subtree")
+	cmd.Flags().StringArrayVar(&c.excludeDep, "exclude-dep", nil, "skip dependencies whose module path matches this glob or prefix (repeatable)")
+	cmd.Flags().BoolVar(&c.caseSensitive, "case-sensitive", false, "match definition and package names in the reference in the same case only")
+	cmd.Flags().BoolVar(&c.strict, "strict", false, "fail if a package can't be built, or marks a template @odin(component) that doesn't unify with a component base, instead of skipping it with a warning")
+	cmd.Flags().StringVar(&c.example, "with-example", "", "add an example config block to markdown output (cue, yaml)")
+	cmd.Flags().Lookup("with-example").NoOptDefVal = "cue"
+	cmd.Flags().StringArrayVar(&c.bases, "base", nil, "component base definition templates are discovered by, as <import path>:#<Definition> (repeatable, default "+model.DefaultComponentBase+")")
 
 	return cmd
 }
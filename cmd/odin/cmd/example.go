@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/cmd/example"
+)
+
+type exampleCmd struct {
+	logger        *slog.Logger
+	config        config.Manager
+	cacheDir      string
+	bundlePath    string
+	reference     string
+	format        string
+	outputPath    string
+	values        bool
+	onlyRequired  bool
+	caseSensitive bool
+}
+
+func (c *exampleCmd) Args(cmd *cobra.Command, args []string) error {
+	if c.values {
+		if len(args) != 0 {
+			return fmt.Errorf("--values does not take a component template reference")
+		}
+		return nil
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("exactly one argument required: the component template reference")
+	}
+	c.reference = args[0]
+	return nil
+}
+
+func (c *exampleCmd) PreRunE(cmd *cobra.Command, args []string) error {
+	sharedOpts := sharedOptsFromCommand(cmd)
+	c.cacheDir = sharedOpts.CacheDir
+	c.logger = loggerFromCommand(cmd)
+	c.config = configFromCommand(cmd)
+
+	if err := ensureCacheDir(c.cacheDir); err != nil {
+		return err
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
+	return nil
+}
+
+func (c *exampleCmd) RunE(cmd *cobra.Command, args []string) error {
+	opts := example.Options{
+		BundlePath:    c.bundlePath,
+		Reference:     c.reference,
+		Values:        c.values,
+		OnlyRequired:  c.onlyRequired,
+		CaseSensitive: c.caseSensitive,
+		Format:        c.format,
+		OutputPath:    c.outputPath,
+		CacheDir:      c.cacheDir,
+		Offline:       sharedOptsFromCommand(cmd).Offline,
+		Logger:        c.logger.With("component", "example"),
+	}
+	globalRegistries, err := c.config.ModuleRegistries()
+	if err != nil {
+		return err
+	}
+	opts.Registries = globalRegistries
+	return opts.Run(cmd.Context())
+}
+
+func newExampleCmd() *cobra.Command {
+	c := &exampleCmd{
+		bundlePath: ".",
+		format:     "cue",
+	}
+	cmd := &cobra.Command{
+		Use:   "example [reference]",
+		Short: "generate an example config for a component template",
+		Long: `Generate a skeleton config for a component template.
+
+Fields with defaults are filled in with their default value, required fields
+get a placeholder for their type ("TODO" for strings, 0 for numbers, and so
+on), and disjunctions use their default or first option. Optional fields
+without a default are left out. Doc comments are kept as comments.
+
+The reference accepts the same formats as odin docs. Use --values instead of
+a reference to generate an example of the bundle's values, and
+--only-required to limit the example to the fields that must be provided.`,
+		Args:              c.Args,
+		PreRunE:           c.PreRunE,
+		RunE:              c.RunE,
+		ValidArgsFunction: completeComponentReferences,
+	}
+
+	cmd.Flags().StringVarP(&c.bundlePath, "bundle", "b", ".", "bundle location")
+	cmd.Flags().StringVarP(&c.format, "format", "f", "cue", "output format (cue, yaml)")
+	cmd.Flags().StringVarP(&c.outputPath, "output", "o", "", "output file path (default: stdout)")
+	cmd.Flags().BoolVar(&c.values, "values", false, "generate an example of the bundle's values instead of a component config")
+	cmd.Flags().BoolVar(&c.onlyRequired, "only-required", false, "only include fields without a default that must be provided")
+	cmd.Flags().BoolVar(&c.caseSensitive, "case-sensitive", false, "match definition and package names in the reference in the same case only")
+
+	return cmd
+}
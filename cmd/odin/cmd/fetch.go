@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/cmd/fetch"
+)
+
+type fetchCmd struct {
+	logger     *slog.Logger
+	config     config.Manager
+	cacheDir   string
+	bundlePath string
+}
+
+func (c *fetchCmd) Args(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	if len(args) > 0 {
+		c.bundlePath = args[0]
+	} else {
+		c.bundlePath = "."
+	}
+	return nil
+}
+
+func (c *fetchCmd) PreRunE(cmd *cobra.Command, args []string) error {
+	sharedOpts := sharedOptsFromCommand(cmd)
+	if sharedOpts.Offline {
+		return fmt.Errorf("fetch can't be used with --offline")
+	}
+	c.cacheDir = sharedOpts.CacheDir
+	c.logger = loggerFromCommand(cmd)
+	c.config = configFromCommand(cmd)
+
+	if err := ensureCacheDir(c.cacheDir); err != nil {
+		return err
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
+	return nil
+}
+
+func (c *fetchCmd) RunE(cmd *cobra.Command, args []string) error {
+	opts := fetch.Options{
+		BundlePath: c.bundlePath,
+		Output:     cmd.OutOrStdout(),
+		CacheDir:   c.cacheDir,
+		Logger:     c.logger.With("component", "fetch"),
+	}
+	globalRegistries, err := c.config.ModuleRegistries()
+	if err != nil {
+		return err
+	}
+	opts.Registries = globalRegistries
+	return opts.Run(cmd.Context())
+}
+
+func newFetchCmd() *cobra.Command {
+	c := &fetchCmd{}
+	cmd := &cobra.Command{
+		Use:     "fetch [location]",
+		Aliases: []string{"warm-cache"},
+		Short:   "fetch bundle dependencies into the module cache",
+		Long: `Fetch bundle dependencies into the module cache.
+
+Each module required by the bundle's cue.mod/module.cue is fetched from its
+registry into the cache, unless it's there already, so the bundle can then be
+used with --offline. Each module is reported as fetched, cached or failed, and
+the command fails if any module couldn't be fetched.`,
+		Args:    c.Args,
+		PreRunE: c.PreRunE,
+		RunE:    c.RunE,
+	}
+
+	return cmd
+}
@@ -3,10 +3,11 @@
 package cmd
 
 import (
-	cueerrors "cuelang.org/go/cue/errors"
 	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/pkg/cueerr"
+	"go-valkyrie.com/odin/pkg/model"
 	"io"
 	"log/slog"
 	"os"
@@ -30,25 +31,29 @@ func (c *Command) Execute(args []string) error {
 
 	cmd.SetErr(c.Err)
 
-	if err := cmd.Execute(); err == nil {
+	executed, err := cmd.ExecuteC()
+	if p := profilerFromCommand(executed); p != nil {
+		if stopErr := p.stop(); stopErr != nil {
+			cmd.PrintErrln("Error:", stopErr)
+		}
+	}
+	if executed != nil && executed.Context() != nil {
+		if opts := sharedOptsFromCommand(executed); opts != nil && opts.Timings != nil {
+			opts.Timings.Format(cmd.ErrOrStderr())
+		}
+	}
+
+	if err == nil {
 		return nil
-	} else if e := cueerrors.Error(nil); errors.As(err, &e) {
+	} else if cueerr.Is(err) {
 		cmd.PrintErrln("Error processing CUE files:")
-		errs := cueerrors.Errors(e)
-		for _, err := range errs {
-			cmd.PrintErrln(err.Error())
-			if pos := err.InputPositions(); len(pos) > 0 {
-				cmd.PrintErrln(" Positions:")
-				for _, pos := range pos {
-					cmd.PrintErrln("  ", pos.String())
-				}
-			} else {
-				cmd.PrintErrln(" Position:", err.Position().String())
-			}
-		}
+		cueerr.Format(cmd.ErrOrStderr(), err)
 		return err
 	} else {
 		cmd.PrintErr(fmt.Sprintf("Error: %v\n", err))
+		if errors.Is(err, model.ErrNotCached) {
+			cmd.PrintErrln("Run without --offline, or run odin fetch first, to fetch it.")
+		}
 		return err
 	}
 }
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/spf13/cobra"
+)
+
+// profiler records the Go execution trace and CPU profile requested by
+// --trace and --cpuprofile for the duration of a command. With neither flag
+// set it does nothing.
+type profiler struct {
+	tracePath      string
+	cpuProfilePath string
+
+	traceFile      *os.File
+	cpuProfileFile *os.File
+}
+
+// start begins profiling. If either profile can't be started, anything
+// already started is stopped again.
+func (p *profiler) start() error {
+	if p.cpuProfilePath != "" {
+		f, err := os.Create(p.cpuProfilePath)
+		if err != nil {
+			return fmt.Errorf("creating CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return fmt.Errorf("starting CPU profile: %w", err)
+		}
+		p.cpuProfileFile = f
+	}
+
+	if p.tracePath != "" {
+		f, err := os.Create(p.tracePath)
+		if err != nil {
+			return errors.Join(fmt.Errorf("creating trace: %w", err), p.stop())
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return errors.Join(fmt.Errorf("starting trace: %w", err), p.stop())
+		}
+		p.traceFile = f
+	}
+
+	return nil
+}
+
+// stop flushes and closes any profiles that were started. It is safe to call
+// more than once.
+func (p *profiler) stop() error {
+	var errs []error
+	if p.traceFile != nil {
+		trace.Stop()
+		if err := p.traceFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("writing trace: %w", err))
+		}
+		p.traceFile = nil
+	}
+	if p.cpuProfileFile != nil {
+		pprof.StopCPUProfile()
+		if err := p.cpuProfileFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("writing CPU profile: %w", err))
+		}
+		p.cpuProfileFile = nil
+	}
+	return errors.Join(errs...)
+}
+
+func profilerFromCommand(cmd *cobra.Command) *profiler {
+	if cmd == nil || cmd.Context() == nil {
+		return nil
+	}
+	if p, ok := cmd.Context().Value(profilerCtxKey).(*profiler); ok {
+		return p
+	}
+	return nil
+}
@@ -5,11 +5,15 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"go-valkyrie.com/odin/pkg/cmd/pull"
+	"go-valkyrie.com/odin/pkg/oci"
 )
 
 type pullCmd struct {
-	reference string
-	outputDir string
+	reference   string
+	outputDir   string
+	strict      bool
+	concurrency int
+	quiet       bool
 }
 
 func newPullCmd() *cobra.Command {
@@ -21,9 +25,14 @@ func newPullCmd() *cobra.Command {
 		Long: `Pull a bundle from an OCI registry and extract it to a local directory.
 
 The reference should be in the format: registry/repository:tag or oci://registry/repository:tag
+A reference without a registry, such as repository:tag, uses oci.defaultRegistry
+from the config. Registries with a mirror in oci.mirrors are pulled through it.
 
 If no output directory is specified, defaults to {bundle-name}-{tag} in the current directory.
 
+On success the reference pinned to the pulled digest is printed, followed by the
+output directory.
+
 Examples:
   odin pull ghcr.io/org/app:v1
   odin pull ghcr.io/org/app:v1 -o ./my-bundle
@@ -35,11 +44,29 @@ Examples:
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := loggerFromCommand(cmd)
+			if p.quiet {
+				logger = quietLogger(logger)
+			}
+
+			config := configFromCommand(cmd)
+			defaultRegistry, err := config.DefaultOCIRegistry()
+			if err != nil {
+				return err
+			}
+			mirrors, err := config.OCIMirrors()
+			if err != nil {
+				return err
+			}
 
 			opts := pull.Options{
-				Reference: p.reference,
-				OutputDir: p.outputDir,
-				Logger:    logger,
+				Reference:       p.reference,
+				DefaultRegistry: defaultRegistry,
+				Mirrors:         mirrors,
+				OutputDir:       p.outputDir,
+				Strict:          p.strict,
+				Concurrency:     p.concurrency,
+				Output:          cmd.OutOrStdout(),
+				Logger:          logger,
 			}
 
 			return pull.Run(cmd.Context(), opts)
@@ -47,6 +74,9 @@ Examples:
 	}
 
 	cmd.Flags().StringVarP(&p.outputDir, "output", "o", "", "output directory (default: {bundle-name}-{tag})")
+	cmd.Flags().BoolVar(&p.strict, "strict", false, "fail if the artifact is not an odin bundle")
+	cmd.Flags().BoolVarP(&p.quiet, "quiet", "q", false, "only log warnings and errors; the pulled reference and output directory are still printed")
+	cmd.Flags().IntVar(&p.concurrency, "concurrency", oci.DefaultConcurrency, "number of blobs to download at once; more can speed up large bundles on slow links but uses more connections and memory")
 
 	return cmd
 }
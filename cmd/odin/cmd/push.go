@@ -7,12 +7,18 @@ import (
 
 	"github.com/spf13/cobra"
 	"go-valkyrie.com/odin/pkg/cmd/push"
+	"go-valkyrie.com/odin/pkg/oci"
 )
 
 type pushCmd struct {
 	reference   string
 	bundlePath  string
 	annotations map[string]string
+	rendered    bool
+	valuesFiles []string
+	namespace   string
+	concurrency int
+	quiet       bool
 }
 
 func newPushCmd() *cobra.Command {
@@ -24,11 +30,22 @@ func newPushCmd() *cobra.Command {
 		Long: `Push a bundle to an OCI registry as a gzip-compressed tarball.
 
 The reference should be in the format: registry/repository:tag or oci://registry/repository:tag
+A reference without a registry, such as repository:tag, uses oci.defaultRegistry
+from the config.
+
+By default the bundle source is pushed (artifact type application/vnd.odin.bundle.v1),
+which can be pulled and rendered later. With --rendered the bundle is rendered first
+and the resulting manifests, one YAML file per resource, are pushed instead
+(artifact type application/vnd.odin.manifests.v1) for consumption by GitOps tools.
+
+On success the reference pinned to the pushed digest is printed, followed by the
+tag; pin the digest reference wherever the exact content matters.
 
 Examples:
   odin push ghcr.io/org/app:v1
   odin push ghcr.io/org/app:v1 ./my-bundle
-  odin push oci://registry.example.com/project/bundle:latest`,
+  odin push oci://registry.example.com/project/bundle:latest
+  odin push --rendered -f values.yaml ghcr.io/org/app-manifests:v1`,
 		Args: cobra.RangeArgs(1, 2),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			p.reference = args[0]
@@ -49,12 +66,42 @@ Examples:
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := loggerFromCommand(cmd)
+			if p.quiet {
+				logger = quietLogger(logger)
+			}
+
+			defaultRegistry, err := configFromCommand(cmd).DefaultOCIRegistry()
+			if err != nil {
+				return err
+			}
 
 			opts := push.Options{
-				Reference:   p.reference,
-				BundlePath:  p.bundlePath,
-				Annotations: p.annotations,
-				Logger:      logger,
+				Reference:       p.reference,
+				DefaultRegistry: defaultRegistry,
+				BundlePath:      p.bundlePath,
+				Annotations:     p.annotations,
+				Concurrency:     p.concurrency,
+				Output:          cmd.OutOrStdout(),
+				Logger:          logger,
+			}
+
+			if p.rendered {
+				cacheDir := sharedOptsFromCommand(cmd).CacheDir
+				if err := ensureCacheDir(cacheDir); err != nil {
+					return err
+				}
+				registries, err := configFromCommand(cmd).ModuleRegistries()
+				if err != nil {
+					return err
+				}
+				opts.Rendered = true
+				opts.CacheDir = cacheDir
+				opts.Offline = sharedOptsFromCommand(cmd).Offline
+				opts.Registries = registries
+				opts.ValuesLocations = p.valuesFiles
+				opts.Namespace = p.namespace
+			} else if len(p.valuesFiles) > 0 || p.namespace != "" {
+				return fmt.Errorf("--values and --namespace are only valid with --rendered")
 			}
 
 			return push.Run(cmd.Context(), opts)
@@ -62,6 +109,11 @@ Examples:
 	}
 
 	cmd.Flags().StringToStringVarP(&p.annotations, "annotation", "a", nil, "OCI manifest annotations in key=value format (can be specified multiple times)")
+	cmd.Flags().BoolVar(&p.rendered, "rendered", false, "push the rendered manifests instead of the bundle source")
+	cmd.Flags().StringArrayVarP(&p.valuesFiles, "values", "f", []string{}, "values files used when rendering (requires --rendered)")
+	cmd.Flags().StringVar(&p.namespace, "namespace", "", "namespace used when rendering (requires --rendered)")
+	cmd.Flags().BoolVarP(&p.quiet, "quiet", "q", false, "only log warnings and errors; the pushed references are still printed")
+	cmd.Flags().IntVar(&p.concurrency, "concurrency", oci.DefaultConcurrency, "number of blobs to upload at once; more can speed up large bundles on slow links but uses more connections and memory")
 
 	return cmd
 }
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/cmd/render"
+)
+
+type renderCmd struct {
+	logger        *slog.Logger
+	config        config.Manager
+	cacheDir      string
+	bundlePath    string
+	reference     string
+	name          string
+	valuesFiles   []string
+	caseSensitive bool
+}
+
+func (c *renderCmd) Args(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("exactly one argument required: the component template reference")
+	}
+	c.reference = args[0]
+	return nil
+}
+
+func (c *renderCmd) PreRunE(cmd *cobra.Command, args []string) error {
+	sharedOpts := sharedOptsFromCommand(cmd)
+	c.cacheDir = sharedOpts.CacheDir
+	c.logger = loggerFromCommand(cmd)
+	c.config = configFromCommand(cmd)
+
+	if err := ensureCacheDir(c.cacheDir); err != nil {
+		return err
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
+	return nil
+}
+
+func (c *renderCmd) RunE(cmd *cobra.Command, args []string) error {
+	opts := render.Options{
+		BundlePath:      c.bundlePath,
+		Reference:       c.reference,
+		Name:            c.name,
+		ValuesLocations: c.valuesFiles,
+		CaseSensitive:   c.caseSensitive,
+		Output:          cmd.OutOrStdout(),
+		CacheDir:        c.cacheDir,
+		Offline:         sharedOptsFromCommand(cmd).Offline,
+		Logger:          c.logger.With("component", "render"),
+	}
+	globalRegistries, err := c.config.ModuleRegistries()
+	if err != nil {
+		return err
+	}
+	opts.Registries = globalRegistries
+	return opts.Run(cmd.Context())
+}
+
+func newRenderCmd() *cobra.Command {
+	c := &renderCmd{
+		bundlePath: ".",
+	}
+	cmd := &cobra.Command{
+		Use:   "render [reference]",
+		Short: "render a single component template with the given config",
+		Long: `Render a single component template with the given config.
+
+The template is instantiated on its own, outside of the bundle's components,
+and its resources are written as YAML. Its config is read from the values
+files given with --values, under a config field as odin example writes it:
+
+  odin example workload.WebApp -f yaml -o webapp.yaml
+  odin render workload.WebApp -f webapp.yaml
+
+Config fields that conflict with the template or are left unset are listed
+with their positions. The reference accepts the same formats as odin docs.`,
+		Args:              c.Args,
+		PreRunE:           c.PreRunE,
+		RunE:              c.RunE,
+		ValidArgsFunction: completeComponentReferences,
+	}
+
+	cmd.Flags().StringVarP(&c.bundlePath, "bundle", "b", ".", "bundle location")
+	cmd.Flags().StringArrayVarP(&c.valuesFiles, "values", "f", []string{}, "Values files setting the config, optionally prefixed with a format (cue, json, toml, yaml, k8s), e.g. \"yaml: values.txt\"")
+	cmd.Flags().StringVar(&c.name, "name", "", "name to instantiate the template with (default: the template's name in lower case)")
+	cmd.Flags().BoolVar(&c.caseSensitive, "case-sensitive", false, "match definition and package names in the reference in the same case only")
+
+	return cmd
+}
@@ -4,26 +4,44 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"github.com/dpotapov/slogpfx"
 	"github.com/lmittmann/tint"
 	"github.com/mattn/go-colorable"
 	"github.com/spf13/cobra"
 	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/model"
 	"log/slog"
 	"os"
 	"path/filepath"
 )
 
+// skipConfigAnnotation marks commands that load the config themselves, so
+// the root command doesn't fail on an invalid config before they run.
+const skipConfigAnnotation = "odin.skip-config"
+
 type rootCmd struct {
 	opts       *sharedOptions
 	configPath string
 	logger     *slog.Logger
 	debug      bool
+	logFormat  string
+	logLevel   string
+	profiler   profiler
+	timings    bool
 }
 
 func (c *rootCmd) PersistentPreRunE(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
+	// Start profiling first so it covers as much of the command as possible.
+	// Execute stops it once the command returns, whether or not it failed.
+	if err := c.profiler.start(); err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, profilerCtxKey, &c.profiler)
+	cmd.SetContext(ctx)
+
 	if c.debug {
 		cmd.SilenceUsage = true
 	}
@@ -37,36 +55,45 @@ func (c *rootCmd) PersistentPreRunE(cmd *cobra.Command, args []string) error {
 		c.opts.CacheDir = filepath.Join(dir, "odin")
 	}
 
+	if c.timings {
+		c.opts.Timings = &model.Timings{}
+	}
+
 	ctx = context.WithValue(ctx, sharedOptsCtxKey, c.opts)
 
 	var logger *slog.Logger
 	if c.logger != nil {
 		logger = c.logger
 	} else {
-		opts := &tint.Options{}
-
+		level, err := parseLogLevel(c.logLevel)
+		if err != nil {
+			return err
+		}
+		// --debug is shorthand for --log-level debug and takes precedence.
 		if c.debug {
-			opts.Level = slog.LevelDebug
-		} else {
-			opts.Level = slog.LevelInfo
+			level = slog.LevelDebug
 		}
 
-		handler := tint.NewHandler(colorable.NewColorableStderr(), opts)
-		handler = slogpfx.NewHandler(handler, &slogpfx.HandlerOptions{
-			PrefixKeys: []string{"component"},
-		})
+		handler, err := newLogHandler(c.logFormat, level)
+		if err != nil {
+			return err
+		}
 
 		logger = slog.New(handler)
 	}
 
 	ctx = context.WithValue(ctx, loggerCtxKey, logger)
 
-	configManager, err := config.NewManager(logger, c.opts.ConfigPath)
-	if err != nil {
-		return err
+	// Shell completion parses the flags of the command being completed only
+	// after this hook has run, so --config isn't known yet; completion
+	// functions load the config themselves with loadConfig.
+	if cmd.Annotations[skipConfigAnnotation] == "true" || cmd.Name() == cobra.ShellCompRequestCmd {
+		cmd.SetContext(ctx)
+		return nil
 	}
 
-	if err := configManager.Load(); err != nil {
+	configManager, err := loadConfig(logger, c.opts.ConfigPath)
+	if err != nil {
 		return err
 	}
 
@@ -77,6 +104,52 @@ func (c *rootCmd) PersistentPreRunE(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// loadConfig loads the config file at configPath, or the default one if it
+// is empty.
+func loadConfig(logger *slog.Logger, configPath string) (config.Manager, error) {
+	configManager, err := config.NewManager(logger, configPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := configManager.Load(); err != nil {
+		return nil, err
+	}
+	return configManager, nil
+}
+
+// parseLogLevel maps a --log-level value to its slog level.
+func parseLogLevel(level string) (slog.Level, error) {
+	switch level {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unsupported log level: %q (supported: debug, info, warn, error)", level)
+	}
+}
+
+// newLogHandler builds the handler for the given log format. The text format
+// prefixes messages with the component name; the JSON format keeps component
+// as a regular attribute.
+func newLogHandler(format string, level slog.Level) (slog.Handler, error) {
+	switch format {
+	case "text":
+		handler := tint.NewHandler(colorable.NewColorableStderr(), &tint.Options{Level: level})
+		return slogpfx.NewHandler(handler, &slogpfx.HandlerOptions{
+			PrefixKeys: []string{"component"},
+		}), nil
+	case "json":
+		return slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level}), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format: %q (supported: text, json)", format)
+	}
+}
+
 func newRootCmd(logger *slog.Logger) *cobra.Command {
 	root := &rootCmd{
 		opts:   &sharedOptions{},
@@ -84,18 +157,57 @@ func newRootCmd(logger *slog.Logger) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:               "odin",
-		Short:             "Odin CLI",
-		Long:              `odin is a CLI for generating kubernetes manifests from CUE configurations`,
+		Use:   "odin",
+		Short: "Odin CLI",
+		Long: `odin is a CLI for generating kubernetes manifests from CUE configurations
+
+Module registries are taken from the config file, then the ODIN_REGISTRY
+environment variable (comma-separated prefix=registry pairs), then the
+bundle's odin.toml, each overriding the one before for the same module prefix.`,
 		PersistentPreRunE: root.PersistentPreRunE,
 		SilenceErrors:     true,
 	}
 
+	cmd.PersistentFlags().StringVar(&root.opts.ConfigPath,
+		"config",
+		"",
+		"path to the odin config file (default: the first of $XDG_CONFIG_HOME/odin/config.cue, ~/.config/odin/config.cue and .odin/config.cue in this or a parent directory up to the repository root)")
+
 	cmd.PersistentFlags().BoolVarP(&root.debug,
 		"debug",
 		"",
 		false,
-		"enable debug logging")
+		"enable debug logging (shorthand for --log-level debug, overrides --log-level)")
+
+	cmd.PersistentFlags().StringVar(&root.logLevel,
+		"log-level",
+		"info",
+		"minimum log level (debug, info, warn, error)")
+
+	cmd.PersistentFlags().StringVar(&root.logFormat,
+		"log-format",
+		"text",
+		"log output format (text, json)")
+
+	cmd.PersistentFlags().StringVar(&root.profiler.tracePath,
+		"trace",
+		"",
+		"write a Go execution trace of the command to this file")
+
+	cmd.PersistentFlags().StringVar(&root.profiler.cpuProfilePath,
+		"cpuprofile",
+		"",
+		"write a pprof CPU profile of the command to this file")
+
+	cmd.PersistentFlags().BoolVar(&root.timings,
+		"timings",
+		false,
+		"print how long each phase of loading the bundle took to stderr")
+
+	cmd.PersistentFlags().BoolVar(&root.opts.Offline,
+		"offline",
+		false,
+		"only use CUE modules already in the cache, failing instead of fetching any that are missing")
 
 	cmd.PersistentFlags().BoolVarP(&root.opts.Verbose,
 		"verbose",
@@ -103,17 +215,51 @@ func newRootCmd(logger *slog.Logger) *cobra.Command {
 		false,
 		"enable verbose output")
 
+	cmd.AddCommand(newAPICmd())
+	cmd.AddCommand(newBrowseCmd())
 	cmd.AddCommand(newCueCmd())
 	cmd.AddCommand(newCacheCmd())
 	cmd.AddCommand(newComponentsCmd())
 	cmd.AddCommand(newConfigCmd())
+	cmd.AddCommand(newDepsCmd())
 	cmd.AddCommand(newDocsCmd())
+	cmd.AddCommand(newExampleCmd())
+	cmd.AddCommand(newFetchCmd())
 	cmd.AddCommand(newInitCmd())
 	cmd.AddCommand(newPullCmd())
 	cmd.AddCommand(newPushCmd())
+	cmd.AddCommand(newRenderCmd())
+	cmd.AddCommand(newServeCmd())
 	cmd.AddCommand(newShowCmd())
 	cmd.AddCommand(newTemplateCmd())
 	cmd.AddCommand(newTestCmd())
+	cmd.AddCommand(newValidateCmd())
+	cmd.AddCommand(newVersionCmd())
 
 	return cmd
 }
+
+// minLevelHandler drops records below a minimum level on top of whatever
+// level the handler it wraps already filters at.
+type minLevelHandler struct {
+	slog.Handler
+	level slog.Level
+}
+
+func (h minLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && h.Handler.Enabled(ctx, level)
+}
+
+func (h minLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return minLevelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h minLevelHandler) WithGroup(name string) slog.Handler {
+	return minLevelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// quietLogger returns logger without its info and debug records, for
+// --quiet. Warnings and errors are still logged if --log-level allows them.
+func quietLogger(logger *slog.Logger) *slog.Logger {
+	return slog.New(minLevelHandler{Handler: logger.Handler(), level: slog.LevelWarn})
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestQuietLogger(t *testing.T) {
+	tests := []struct {
+		name  string
+		level slog.Level
+		want  []string
+		drop  []string
+	}{
+		{
+			name:  "drops info and debug",
+			level: slog.LevelDebug,
+			want:  []string{"warned", "failed"},
+			drop:  []string{"debugged", "informed"},
+		},
+		{
+			name:  "keeps the wrapped handler's level",
+			level: slog.LevelError,
+			want:  []string{"failed"},
+			drop:  []string{"debugged", "informed", "warned"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := quietLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: tt.level})))
+			// Derived loggers stay quiet.
+			logger = logger.With("component", "pull").WithGroup("g")
+			logger.Debug("debugged")
+			logger.Info("informed")
+			logger.Warn("warned")
+			logger.Error("failed")
+
+			out := buf.String()
+			for _, msg := range tt.want {
+				if !strings.Contains(out, "msg="+msg) {
+					t.Errorf("%s was not logged:\n%s", msg, out)
+				}
+			}
+			for _, msg := range tt.drop {
+				if strings.Contains(out, "msg="+msg) {
+					t.Errorf("%s was logged:\n%s", msg, out)
+				}
+			}
+			if !strings.Contains(out, "component=pull") {
+				t.Errorf("attributes were lost:\n%s", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/cmd/serve"
+)
+
+type serveCmd struct {
+	logger     *slog.Logger
+	config     config.Manager
+	cacheDir   string
+	bundlePath string
+	address    string
+	expand     bool
+}
+
+func (c *serveCmd) Args(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	if len(args) > 0 {
+		c.bundlePath = args[0]
+	} else {
+		c.bundlePath = "."
+	}
+	return nil
+}
+
+func (c *serveCmd) PreRunE(cmd *cobra.Command, args []string) error {
+	sharedOpts := sharedOptsFromCommand(cmd)
+	c.cacheDir = sharedOpts.CacheDir
+	c.logger = loggerFromCommand(cmd)
+	c.config = configFromCommand(cmd)
+
+	if err := ensureCacheDir(c.cacheDir); err != nil {
+		return err
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
+	return nil
+}
+
+func (c *serveCmd) RunE(cmd *cobra.Command, args []string) error {
+	opts := serve.Options{
+		BundlePath: c.bundlePath,
+		Address:    c.address,
+		Expand:     c.expand,
+		CacheDir:   c.cacheDir,
+		Offline:    sharedOptsFromCommand(cmd).Offline,
+		Logger:     c.logger.With("component", "serve"),
+	}
+	globalRegistries, err := c.config.ModuleRegistries()
+	if err != nil {
+		return err
+	}
+	opts.Registries = globalRegistries
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return opts.Run(ctx)
+}
+
+func newServeCmd() *cobra.Command {
+	c := &serveCmd{
+		address: "localhost:8080",
+	}
+	cmd := &cobra.Command{
+		Use:   "serve [location]",
+		Short: "serve component template docs over HTTP",
+		Long: `Serve component template documentation as an HTTP API.
+
+Templates are discovered once at startup. The server exposes:
+
+  GET /components              JSON inventory, as printed by odin components -f json
+  GET /components/{ref}/schema config schema of a template as JSON
+  GET /components/{ref}/docs   markdown documentation of a template
+
+{ref} accepts the same reference formats as odin docs; references containing
+'/' must be path-escaped (e.g. example.com%2Fworkload:%23WebApp). The server
+shuts down gracefully on interrupt.`,
+		Args:    c.Args,
+		PreRunE: c.PreRunE,
+		RunE:    c.RunE,
+	}
+
+	cmd.Flags().StringVar(&c.address, "address", "localhost:8080", "address to listen on")
+	cmd.Flags().BoolVar(&c.expand, "expand", false, "recursively expand referenced definitions inline")
+
+	return cmd
+}
@@ -10,9 +10,10 @@ func newShowCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "show",
 		Short: "Show information about bundles",
-		Long:  "Show information about bundles, such as their values schema.",
+		Long:  "Show information about bundles, such as their metadata and values schema.",
 	}
 
+	cmd.AddCommand(newShowBundleCmd())
 	cmd.AddCommand(newShowValuesCmd())
 
 	return cmd
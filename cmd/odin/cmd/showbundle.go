@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/cmd/showbundle"
+)
+
+type showBundleCmd struct {
+	logger     *slog.Logger
+	config     config.Manager
+	cacheDir   string
+	bundlePath string
+	format     string
+}
+
+func (c *showBundleCmd) Args(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	if len(args) > 0 {
+		c.bundlePath = args[0]
+	} else {
+		c.bundlePath = "."
+	}
+	return nil
+}
+
+func (c *showBundleCmd) PreRunE(cmd *cobra.Command, args []string) error {
+	sharedOpts := sharedOptsFromCommand(cmd)
+	c.cacheDir = sharedOpts.CacheDir
+	c.logger = loggerFromCommand(cmd)
+	c.config = configFromCommand(cmd)
+
+	if err := ensureCacheDir(c.cacheDir); err != nil {
+		return err
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
+	return nil
+}
+
+func (c *showBundleCmd) RunE(cmd *cobra.Command, args []string) error {
+	opts := showbundle.Options{
+		BundlePath: c.bundlePath,
+		Format:     c.format,
+		CacheDir:   c.cacheDir,
+		Offline:    sharedOptsFromCommand(cmd).Offline,
+		Logger:     c.logger.With("component", "show-bundle"),
+	}
+	globalRegistries, err := c.config.ModuleRegistries()
+	if err != nil {
+		return err
+	}
+	opts.Registries = globalRegistries
+	return opts.Run(cmd.Context())
+}
+
+func newShowBundleCmd() *cobra.Command {
+	c := &showBundleCmd{
+		format: "text",
+	}
+	cmd := &cobra.Command{
+		Use:   "bundle [location]",
+		Short: "Show metadata for a bundle",
+		Long: `Show metadata for a bundle.
+
+Prints the bundle's name, CUE module path, language version, the module
+dependencies it requires along with their versions, and the number of
+components it defines.
+
+Examples:
+  # Show metadata for current bundle
+  odin show bundle
+
+  # Show metadata for bundle at path
+  odin show bundle ./path/to/bundle
+
+  # Output as JSON
+  odin show bundle -f json`,
+		Args:    c.Args,
+		PreRunE: c.PreRunE,
+		RunE:    c.RunE,
+	}
+
+	cmd.Flags().StringVarP(&c.format, "format", "f", "text", "Output format (text, json)")
+
+	return cmd
+}
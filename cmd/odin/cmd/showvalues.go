@@ -18,6 +18,8 @@ type showValuesCmd struct {
 	bundlePath string
 	format     string
 	outputPath string
+	values     []string
+	depth      int
 }
 
 func (c *showValuesCmd) Args(cmd *cobra.Command, args []string) error {
@@ -56,11 +58,14 @@ func (c *showValuesCmd) PreRunE(cmd *cobra.Command, args []string) error {
 
 func (c *showValuesCmd) RunE(cmd *cobra.Command, args []string) error {
 	opts := showvalues.Options{
-		BundlePath: c.bundlePath,
-		Format:     c.format,
-		OutputPath: c.outputPath,
-		CacheDir:   c.cacheDir,
-		Logger:     c.logger.With("component", "show-values"),
+		BundlePath:      c.bundlePath,
+		Format:          c.format,
+		Depth:           c.depth,
+		OutputPath:      c.outputPath,
+		CacheDir:        c.cacheDir,
+		Offline:         sharedOptsFromCommand(cmd).Offline,
+		Logger:          c.logger.With("component", "show-values"),
+		ValuesLocations: c.values,
 	}
 	globalRegistries, err := c.config.ModuleRegistries()
 	if err != nil {
@@ -73,6 +78,7 @@ func (c *showValuesCmd) RunE(cmd *cobra.Command, args []string) error {
 func newShowValuesCmd() *cobra.Command {
 	c := &showValuesCmd{
 		format: "text",
+		depth:  -1,
 	}
 	cmd := &cobra.Command{
 		Use:   "values [location]",
@@ -94,7 +100,10 @@ Examples:
   odin show values -f cue
 
   # Output as markdown
-  odin show values -f markdown -o values.md`,
+  odin show values -f markdown -o values.md
+
+  # Mark which fields a values file sets or overrides
+  odin show values --values prod.yaml`,
 		Args:    c.Args,
 		PreRunE: c.PreRunE,
 		RunE:    c.RunE,
@@ -102,6 +111,8 @@ Examples:
 
 	cmd.Flags().StringVarP(&c.format, "format", "f", "text", "Output format (text, cue, markdown/md)")
 	cmd.Flags().StringVarP(&c.outputPath, "output", "o", "", "Output file path (default: stdout)")
+	cmd.Flags().IntVar(&c.depth, "depth", -1, "Maximum nesting depth of fields to show, 0 for top-level only (text and markdown; default unlimited)")
+	cmd.Flags().StringArrayVar(&c.values, "values", []string{}, "Values files to apply, marking each field as default, set or overridden")
 
 	return cmd
 }
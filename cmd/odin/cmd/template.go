@@ -5,10 +5,13 @@ package cmd
 import (
 	"fmt"
 	"log/slog"
+	"os"
+	"os/signal"
 
 	"github.com/spf13/cobra"
 	"go-valkyrie.com/odin/internal/config"
 	"go-valkyrie.com/odin/pkg/cmd/template"
+	"go-valkyrie.com/odin/pkg/model"
 )
 
 type templateCmd struct {
@@ -17,7 +20,17 @@ type templateCmd struct {
 	cacheDir    string
 	bundlePath  string
 	valuesFiles []string
+	setFiles    []string
 	namespace   string
+	stamp       bool
+	concrete    bool
+	watch       bool
+	outputPath  string
+	order       string
+	kindOrder   []string
+	selector    []string
+	format      string
+	yamlStart   bool
 }
 
 func (c *templateCmd) Args(cmd *cobra.Command, args []string) error {
@@ -51,16 +64,44 @@ func (c *templateCmd) PreRunE(cmd *cobra.Command, args []string) error {
 		c.bundlePath = root
 	}
 
+	if c.bundlePath == model.StdinLocation && c.watch {
+		return fmt.Errorf("--watch cannot be used with a bundle read from stdin")
+	}
+
+	// Apply bundle defaults from odin.toml for flags not set explicitly.
+	bundleCfg, err := model.LoadConfig(c.bundlePath)
+	if err != nil {
+		return err
+	}
+	if !cmd.Flags().Changed("namespace") && bundleCfg.Bundle.Namespace != "" {
+		c.namespace = bundleCfg.Bundle.Namespace
+	}
+	if !cmd.Flags().Changed("values") && len(bundleCfg.Bundle.Values) > 0 {
+		c.valuesFiles = bundleCfg.Bundle.Values
+	}
+
 	return nil
 }
 
 func (c *templateCmd) RunE(cmd *cobra.Command, args []string) error {
 	opts := template.Options{
-		BundlePath:      c.bundlePath,
-		CacheDir:        c.cacheDir,
-		Logger:          c.logger.With("component", "template"),
-		ValuesLocations: c.valuesFiles,
-		Namespace:       c.namespace,
+		BundlePath:        c.bundlePath,
+		Input:             cmd.InOrStdin(),
+		CacheDir:          c.cacheDir,
+		Offline:           sharedOptsFromCommand(cmd).Offline,
+		Logger:            c.logger.With("component", "template"),
+		ValuesLocations:   c.valuesFiles,
+		SetFiles:          c.setFiles,
+		Namespace:         c.namespace,
+		StampRevision:     c.stamp,
+		ConcreteConfig:    c.concrete,
+		Order:             c.order,
+		KindOrder:         c.kindOrder,
+		Selector:          c.selector,
+		Format:            c.format,
+		OutputPath:        c.outputPath,
+		YAMLExplicitStart: c.yamlStart,
+		Timings:           sharedOptsFromCommand(cmd).Timings,
 	}
 	// Load global registries first
 	globalRegistries, err := c.config.ModuleRegistries()
@@ -69,20 +110,50 @@ func (c *templateCmd) RunE(cmd *cobra.Command, args []string) error {
 	}
 	// Pass global registries; bundle-local registries will be merged inside the model loader.
 	opts.Registries = globalRegistries
+	if c.watch {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		return opts.Watch(ctx)
+	}
 	return opts.Run(cmd.Context())
 }
 
 func newTemplateCmd() *cobra.Command {
 	c := &templateCmd{}
 	cmd := &cobra.Command{
-		Use:     "template [location]",
-		Short:   "render templates from a bundle",
+		Use:   "template [location]",
+		Short: "render templates from a bundle",
+		Long: `Render the resources of a bundle.
+
+The location defaults to the bundle containing the current directory. Pass -
+to read the bundle from stdin instead, either as a single CUE file or as a
+txtar archive of the bundle's files:
+
+  -- cue.mod/module.cue --
+  module: "example.com/bundle@v0"
+  ...
+  -- bundle.cue --
+  package bundle
+  ...
+
+A bundle without a cue.mod/module.cue is given one without dependencies, so
+use a txtar archive that includes it for bundles that import other modules.`,
 		Args:    c.Args,
 		PreRunE: c.PreRunE,
 		RunE:    c.RunE,
 	}
-	cmd.Flags().StringArrayVarP(&c.valuesFiles, "values", "f", []string{}, "Values files")
+	cmd.Flags().StringArrayVarP(&c.valuesFiles, "values", "f", []string{}, "Values files, optionally prefixed with a format (cue, json, toml, yaml, k8s), e.g. \"yaml: values.txt\"")
+	cmd.Flags().StringArrayVar(&c.setFiles, "set-file", nil, "Set a value to the contents of a text file, as key=path, e.g. components.app.tls.cert=cert.pem (repeatable)")
 	cmd.Flags().StringVar(&c.namespace, "namespace", "", "Namespace to use for @tag(namespace) in CUE")
+	cmd.Flags().BoolVar(&c.watch, "watch", false, "Re-render whenever a .cue file in the bundle or a values file changes; with --output, each render replaces the file")
+	cmd.Flags().StringVarP(&c.outputPath, "output", "o", "", "Output file path (default: stdout)")
+	cmd.Flags().StringVar(&c.format, "format", "yaml", "Output format (yaml, cue)")
+	cmd.Flags().BoolVar(&c.yamlStart, "yaml-explicit-start", false, "Start every YAML document with ---, including the first")
+	cmd.Flags().StringVar(&c.order, "order", template.OrderName, "Order to emit resources in: name (by component and resource name) or apply (namespaces and CRDs first)")
+	cmd.Flags().StringSliceVar(&c.kindOrder, "kind-order", nil, "Kind priority for --order apply, overriding the built-in install order")
+	cmd.Flags().StringSliceVarP(&c.selector, "selector", "l", nil, "Only render resources whose labels match all of the given key=value pairs, e.g. tier=frontend")
+	cmd.Flags().BoolVar(&c.concrete, "require-concrete", false, "Fail before rendering if a component's config has fields left unset, listing them")
+	cmd.Flags().BoolVar(&c.stamp, "stamp-revision", false, "Annotate resources with the bundle's git commit ("+template.RevisionAnnotation+")")
 
 	return cmd
 }
@@ -12,13 +12,18 @@ import (
 )
 
 type testCmd struct {
-	logger      *slog.Logger
-	config      config.Manager
-	cacheDir    string
-	modulePaths []string
-	update      bool
-	testPaths   []string
-	verbose     bool
+	logger        *slog.Logger
+	config        config.Manager
+	cacheDir      string
+	modulePaths   []string
+	update        bool
+	run           string
+	failFast      bool
+	env           []string
+	keepWork      bool
+	testPaths     []string
+	verbose       bool
+	summaryFormat string
 }
 
 func (c *testCmd) Args(cmd *cobra.Command, args []string) error {
@@ -55,13 +60,18 @@ func (c *testCmd) RunE(cmd *cobra.Command, args []string) error {
 	}
 
 	opts := test.Options{
-		ModulePaths: c.modulePaths,
-		TestPaths:   c.testPaths,
-		Update:      c.update,
-		Verbose:     c.verbose,
-		CacheDir:    c.cacheDir,
-		Logger:      c.logger,
-		Registries:  registries,
+		ModulePaths:   c.modulePaths,
+		TestPaths:     c.testPaths,
+		Update:        c.update,
+		RunPattern:    c.run,
+		FailFast:      c.failFast,
+		Env:           c.env,
+		KeepWork:      c.keepWork,
+		Verbose:       c.verbose,
+		SummaryFormat: c.summaryFormat,
+		CacheDir:      c.cacheDir,
+		Logger:        c.logger,
+		Registries:    registries,
 	}
 
 	return opts.Run(cmd.Context())
@@ -81,6 +91,11 @@ func newTestCmd() *cobra.Command {
 
 	cmd.Flags().StringSliceVarP(&c.modulePaths, "module", "m", nil, "path to local CUE module to serve (required, repeatable)")
 	cmd.Flags().BoolVarP(&c.update, "update", "u", false, "update golden files in txtar scripts")
+	cmd.Flags().StringVar(&c.run, "run", "", "only run tests whose name matches the regular expression")
+	cmd.Flags().StringArrayVar(&c.env, "env", nil, "set an environment variable in test scripts as KEY=VALUE (repeatable)")
+	cmd.Flags().BoolVar(&c.keepWork, "keep-work", false, "keep the work directories of failed tests and log their paths")
+	cmd.Flags().StringVar(&c.summaryFormat, "summary-format", "", "also write the summary to stdout as a single line: plain (RESULT total=N passed=N failed=N skipped=N) or json")
+	cmd.Flags().BoolVar(&c.failFast, "fail-fast", false, "stop after the first failing test (remaining tests are reported as skipped)")
 
 	return cmd
 }
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate bundle inputs",
+		Long:  "Validate bundle inputs, such as values files, without rendering the bundle.",
+	}
+
+	cmd.AddCommand(newValidateValuesCmd())
+
+	return cmd
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"go-valkyrie.com/odin/internal/config"
+	"go-valkyrie.com/odin/pkg/cmd/validatevalues"
+)
+
+type validateValuesCmd struct {
+	logger     *slog.Logger
+	config     config.Manager
+	cacheDir   string
+	bundlePath string
+	format     string
+	values     []string
+}
+
+func (c *validateValuesCmd) Args(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	if len(args) > 0 {
+		c.bundlePath = args[0]
+	} else {
+		c.bundlePath = "."
+	}
+	return nil
+}
+
+func (c *validateValuesCmd) PreRunE(cmd *cobra.Command, args []string) error {
+	sharedOpts := sharedOptsFromCommand(cmd)
+	c.cacheDir = sharedOpts.CacheDir
+	c.logger = loggerFromCommand(cmd)
+	c.config = configFromCommand(cmd)
+
+	if err := ensureCacheDir(c.cacheDir); err != nil {
+		return err
+	}
+
+	// Auto-discover bundle root if using default path
+	if c.bundlePath == "." {
+		root, err := findBundleRoot(".")
+		if err != nil {
+			return err
+		}
+		c.bundlePath = root
+	}
+
+	return nil
+}
+
+func (c *validateValuesCmd) RunE(cmd *cobra.Command, args []string) error {
+	opts := validatevalues.Options{
+		BundlePath:      c.bundlePath,
+		ValuesLocations: c.values,
+		Format:          c.format,
+		Output:          cmd.OutOrStdout(),
+		CacheDir:        c.cacheDir,
+		Offline:         sharedOptsFromCommand(cmd).Offline,
+		Logger:          c.logger.With("component", "validate-values"),
+	}
+	globalRegistries, err := c.config.ModuleRegistries()
+	if err != nil {
+		return err
+	}
+	opts.Registries = globalRegistries
+	return opts.Run(cmd.Context())
+}
+
+func newValidateValuesCmd() *cobra.Command {
+	c := &validateValuesCmd{
+		format: validatevalues.FormatText,
+	}
+	cmd := &cobra.Command{
+		Use:   "values [location]",
+		Short: "Check values files against a bundle's schema",
+		Long: `Check values files against a bundle's values schema and the config schema
+of each of its components, without rendering any resources.
+
+Each invalid field is reported on its own line as "<path>: <message>", where
+the path is relative to the values, e.g. "components.app.replicas". Values the
+schema requires but the files don't set are reported too. The command fails if
+any value is invalid.
+
+Examples:
+  # Validate a values file against the current bundle
+  odin validate values -f prod.yaml
+
+  # Validate several files, unified in order, as JSON
+  odin validate values -f base.yaml -f prod.yaml --format json`,
+		Args:    c.Args,
+		PreRunE: c.PreRunE,
+		RunE:    c.RunE,
+	}
+
+	cmd.Flags().StringArrayVarP(&c.values, "values", "f", []string{}, "Values files to validate, optionally prefixed with a format (cue, json, toml, yaml, k8s)")
+	cmd.Flags().StringVar(&c.format, "format", validatevalues.FormatText, "Output format (text, json)")
+	cmd.MarkFlagRequired("values")
+
+	return cmd
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"cuelang.org/go/cue"
+	"github.com/spf13/cobra"
+)
+
+type versionInfo struct {
+	Odin string `json:"odin"`
+	CUE  string `json:"cue"`
+	Go   string `json:"go"`
+}
+
+type versionCmd struct {
+	json bool
+}
+
+func (c *versionCmd) RunE(cmd *cobra.Command, args []string) error {
+	info := versionInfo{
+		Odin: odinVersion(),
+		CUE:  cue.LanguageVersion(),
+		Go:   runtime.Version(),
+	}
+
+	out := cmd.OutOrStdout()
+	if c.json {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	fmt.Fprintf(out, "odin: %s\n", info.Odin)
+	fmt.Fprintf(out, "cue:  %s\n", info.CUE)
+	fmt.Fprintf(out, "go:   %s\n", info.Go)
+	return nil
+}
+
+// odinVersion returns the main module version recorded in the binary's build
+// info, falling back to the VCS revision for development builds.
+func odinVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		return v
+	}
+
+	var revision, modified string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value
+		}
+	}
+	if revision == "" {
+		return "(devel)"
+	}
+	if modified == "true" {
+		revision += "-dirty"
+	}
+	return "(devel) " + revision
+}
+
+func newVersionCmd() *cobra.Command {
+	c := &versionCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "print odin, CUE and Go versions",
+		Args:  cobra.NoArgs,
+		RunE:  c.RunE,
+	}
+
+	cmd.Flags().BoolVar(&c.json, "json", false, "print version information as JSON")
+
+	return cmd
+}
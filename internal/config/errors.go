@@ -2,6 +2,8 @@
 
 package config
 
+// ErrConfigValidation is returned when the config doesn't match the config
+// schema. It wraps the CUE errors describing why.
 type ErrConfigValidation struct {
 	wrapped error
 }
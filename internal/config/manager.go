@@ -3,11 +3,16 @@
 package config
 
 import (
+	"bytes"
 	"cuelang.org/go/cue"
 	cuefmt "cuelang.org/go/cue/format"
+	"encoding/json"
 	"fmt"
 	"go-valkyrie.com/cueconfig"
+	"go-valkyrie.com/odin/internal/utils"
 	"log/slog"
+	"maps"
+	"os"
 	"sync"
 )
 
@@ -17,6 +22,9 @@ type Manager interface {
 	Load() error
 	ModuleRegistries() (map[string]string, error)
 	Raw() *cue.Value
+	Get(path string) (cue.Value, error)
+	DefaultOCIRegistry() (string, error)
+	OCIMirrors() (map[string]string, error)
 }
 
 // manager is a thin wrapper around cueconfig.Config
@@ -36,14 +44,19 @@ func NewManager(logger *slog.Logger, configPath string) (Manager, error) {
 	}
 
 	// Create config with schema and source
-	configSource, err := loadSource(configPath)
+	configSource, path, err := loadSource(configPath)
 	if err != nil {
 		return nil, err
 	}
+	if path != "" {
+		logger.Debug("loading config", "path", path)
+	} else {
+		logger.Debug("no config file found, using defaults")
+	}
 
 	config, err := cueconfig.New(configSchema, cueconfig.WithSources(configSource))
 	if err != nil {
-		return nil, err
+		return nil, &ErrConfigValidation{wrapped: err}
 	}
 
 	return &manager{
@@ -53,6 +66,21 @@ func NewManager(logger *slog.Logger, configPath string) (Manager, error) {
 	}, nil
 }
 
+// Validate checks the config that NewManager would load for configPath
+// against the config schema, returning the file it checked, or "" if none
+// was found and the defaults were used. A config that doesn't match the
+// schema is reported as an *ErrConfigValidation wrapping the CUE errors.
+func Validate(configPath string) (string, error) {
+	configSource, path, err := loadSource(configPath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := cueconfig.New(configSchema, cueconfig.WithSources(configSource)); err != nil {
+		return path, &ErrConfigValidation{wrapped: err}
+	}
+	return path, nil
+}
+
 // Load reloads the configuration
 func (m *manager) Load() error {
 	m.configMu.Lock()
@@ -73,16 +101,114 @@ func (m *manager) Evaluated() ([]byte, error) {
 	return cuefmt.Node(syntax, cuefmt.Simplify())
 }
 
-// ModuleRegistries returns the module registries from the configuration
+// RegistryEnv is the environment variable that overrides module registries,
+// as comma-separated prefix=registry pairs, e.g.
+// "go-valkyrie.com=registry.internal/cue,example.com=localhost:5000+insecure".
+const RegistryEnv = "ODIN_REGISTRY"
+
+// ModuleRegistries returns the module registries from the configuration,
+// with those set in RegistryEnv taking precedence over the config file for
+// the same prefix. A bundle's odin.toml is applied over both when it's
+// loaded.
 func (m *manager) ModuleRegistries() (map[string]string, error) {
 	registries := make(map[string]string)
 	if err := m.config.ValueAt("cue.registries").Decode(&registries); err != nil {
 		return nil, err
 	}
+	if env := os.Getenv(RegistryEnv); env != "" {
+		overrides, err := utils.ParseRegistryConfig(env)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", RegistryEnv, err)
+		}
+		m.logger.Debug("overriding module registries from the environment", "registries", env)
+		maps.Copy(registries, overrides)
+	}
 	return registries, nil
 }
 
+// DefaultOCIRegistry returns the registry used for OCI references that don't
+// name one, or "" if none is configured.
+func (m *manager) DefaultOCIRegistry() (string, error) {
+	v := m.config.ValueAt("oci.defaultRegistry")
+	if !v.Exists() {
+		return "", nil
+	}
+	return v.String()
+}
+
+// OCIMirrors returns the mirrors to pull from, keyed by the registry host
+// they stand in for.
+func (m *manager) OCIMirrors() (map[string]string, error) {
+	mirrors := make(map[string]string)
+	if v := m.config.ValueAt("oci.mirrors"); v.Exists() {
+		if err := v.Decode(&mirrors); err != nil {
+			return nil, err
+		}
+	}
+	return mirrors, nil
+}
+
 // Raw returns the raw CUE value
 func (m *manager) Raw() *cue.Value {
 	return m.config.Raw()
 }
+
+// Get returns the config value at path, e.g. "cue.registries", failing if
+// there's no such value.
+func (m *manager) Get(path string) (cue.Value, error) {
+	m.configMu.Lock()
+	defer m.configMu.Unlock()
+
+	return lookup(*m.config.Raw(), path)
+}
+
+func lookup(config cue.Value, path string) (cue.Value, error) {
+	p := cue.ParsePath(path)
+	if err := p.Err(); err != nil {
+		return cue.Value{}, fmt.Errorf("invalid config path %q: %w", path, err)
+	}
+	v := config.LookupPath(p)
+	if !v.Exists() {
+		return cue.Value{}, fmt.Errorf("config has no value at %s", path)
+	}
+	return v, nil
+}
+
+// FormatValue formats a config value for printing: strings, numbers and
+// booleans as they are, anything else as CUE, or everything as indented
+// JSON if asJSON is set. The result ends in a newline.
+func FormatValue(v cue.Value, asJSON bool) ([]byte, error) {
+	if asJSON {
+		data, err := v.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+		return buf.Bytes(), nil
+	}
+
+	switch v.Kind() {
+	case cue.StringKind:
+		s, err := v.String()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(s + "\n"), nil
+	case cue.BoolKind, cue.IntKind, cue.FloatKind, cue.NumberKind:
+		data, err := v.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+
+	data, err := cuefmt.Node(v.Syntax(cue.Final(), cue.Docs(true)), cuefmt.Simplify())
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+)
+
+func TestValidateInvalidConfig(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"invalid-prompt.cue", "defaults.prompt"},
+		{"invalid-module.cue", "not a module"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join("testdata", tt.file)
+			got, err := Validate(path)
+			if got != path {
+				t.Errorf("Validate() path = %q, want %q", got, path)
+			}
+			var validationErr *ErrConfigValidation
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want *ErrConfigValidation", err)
+			}
+			if details := cueerrors.Details(err, nil); !strings.Contains(details, tt.want) {
+				t.Errorf("Validate() error = %s, want one about %s", details, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMissingConfig(t *testing.T) {
+	_, err := Validate(filepath.Join("testdata", "missing.cue"))
+	if err == nil || !strings.Contains(err.Error(), "reading config file") {
+		t.Errorf("Validate() error = %v, want a reading config file error", err)
+	}
+	var validationErr *ErrConfigValidation
+	if errors.As(err, &validationErr) {
+		t.Errorf("Validate() error = %v, want it not to be a validation error", err)
+	}
+}
+
+func TestLookupAndFormatValue(t *testing.T) {
+	config := cuecontext.New().CompileString(`
+cue: registries: {
+	"example.com": "registry.example.com/cue"
+}
+defaults: {
+	prompt: false
+	retries: 3
+}
+`)
+
+	tests := []struct {
+		path   string
+		asJSON bool
+		want   string
+	}{
+		{"defaults.prompt", false, "false\n"},
+		{"defaults.retries", false, "3\n"},
+		{`cue.registries."example.com"`, false, "registry.example.com/cue\n"},
+		{`cue.registries."example.com"`, true, "\"registry.example.com/cue\"\n"},
+		{"defaults", false, "{\n\tprompt:  false\n\tretries: 3\n}\n"},
+		{"cue.registries", true, "{\n  \"example.com\": \"registry.example.com/cue\"\n}\n"},
+	}
+	for _, tt := range tests {
+		v, err := lookup(config, tt.path)
+		if err != nil {
+			t.Fatalf("lookup(%s) error = %v", tt.path, err)
+		}
+		got, err := FormatValue(v, tt.asJSON)
+		if err != nil {
+			t.Fatalf("FormatValue(%s) error = %v", tt.path, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("FormatValue(%s, %v) = %q, want %q", tt.path, tt.asJSON, got, tt.want)
+		}
+	}
+
+	if _, err := lookup(config, "defaults.missing"); err == nil || !strings.Contains(err.Error(), "no value at defaults.missing") {
+		t.Errorf("lookup(defaults.missing) error = %v", err)
+	}
+}
@@ -54,6 +54,12 @@ import (
 	prompt: bool
 }
 
+#oci: {
+	defaultRegistry?: string
+	mirrors: [string]: string
+}
+
 cue: #cue
 defaults: #defaults
+oci: #oci
 
@@ -3,21 +3,123 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"go-valkyrie.com/cueconfig/source"
 )
 
-func loadSource(path string) (source.Source, error) {
-	if path == "" {
-		// Use UserConfigSource for global config
-		return source.UserConfig(&source.UserConfigOptions{
-			Vendor:   "Valkyrie",
-			App:      "odin",
-			Filename: "config.cue",
-			Template: map[string][]byte{
-				"config.cue": userConfigTemplate,
-			},
-		})
-	} else {
-		return source.Path(path)
+// configFile is the name of the config file in each directory it's looked
+// for in.
+const configFile = "config.cue"
+
+// localConfigDir is the directory a repo-local config file is kept in, in
+// the current directory or one of its parents up to the project root; see
+// projectRoot.
+const localConfigDir = ".odin"
+
+// loadSource returns the source of the config and the file it's read from,
+// or "" for the built-in defaults. An explicit path, from --config, must
+// exist; otherwise the first of these that exists is used:
+//
+//  1. $XDG_CONFIG_HOME/odin/config.cue
+//  2. ~/.config/odin/config.cue
+//  3. .odin/config.cue in the current directory or the nearest parent with
+//     one, up to the root of the repository or CUE module it's in
+//  4. the platform's user config directory, Valkyrie/odin/config.cue (e.g.
+//     ~/Library/Application Support/Valkyrie/odin/config.cue on macOS), where
+//     earlier versions kept it
+//
+// If none exist the built-in defaults in template.cue are used.
+func loadSource(path string) (source.Source, string, error) {
+	if path != "" {
+		// Unlike the searched locations, which fall back to the defaults
+		// when there's no file, a config file that was asked for must be
+		// there.
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading config file: %w", err)
+		}
+		defer f.Close()
+		if info, err := f.Stat(); err != nil {
+			return nil, "", fmt.Errorf("reading config file: %w", err)
+		} else if info.IsDir() {
+			return nil, "", fmt.Errorf("reading config file: %s is a directory", path)
+		}
+		src, err := source.Path(path)
+		return src, path, err
+	}
+
+	for _, candidate := range configSearchPaths() {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			src, err := source.Path(candidate)
+			return src, candidate, err
+		}
+	}
+
+	// Use UserConfigSource for the platform's user config directory, which
+	// falls back to the template when there's no config there either.
+	src, err := source.UserConfig(&source.UserConfigOptions{
+		Vendor:   "Valkyrie",
+		App:      "odin",
+		Filename: configFile,
+		Template: map[string][]byte{
+			configFile: userConfigTemplate,
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		legacy := filepath.Join(dir, "Valkyrie", "odin", configFile)
+		if _, err := os.Stat(legacy); err == nil {
+			return src, legacy, nil
+		}
+	}
+	return src, "", nil
+}
+
+// configSearchPaths returns the XDG and repo-local locations searched for a
+// config file, in order.
+func configSearchPaths() []string {
+	var paths []string
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		paths = append(paths, filepath.Join(dir, "odin", configFile))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "odin", configFile))
+	}
+	if dir, err := os.Getwd(); err == nil {
+		root := projectRoot(dir)
+		for {
+			paths = append(paths, filepath.Join(dir, localConfigDir, configFile))
+			parent := filepath.Dir(dir)
+			if dir == root || parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+	return paths
+}
+
+// projectRoot returns the farthest directory a repo-local config is looked
+// for in from dir: the nearest directory holding .git, or failing that the
+// nearest holding cue.mod. Outside of both only dir itself is searched, so
+// a config in a shared parent such as /tmp is never picked up.
+func projectRoot(dir string) string {
+	for _, marker := range []string{".git", "cue.mod"} {
+		for d := dir; ; {
+			if _, err := os.Stat(filepath.Join(d, marker)); err == nil {
+				return d
+			}
+			parent := filepath.Dir(d)
+			if parent == d {
+				break
+			}
+			d = parent
+		}
 	}
+	return dir
 }
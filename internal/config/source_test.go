@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestConfigSearchPathsStopsAtProjectRoot(t *testing.T) {
+	tests := []struct {
+		name    string
+		markers []string
+		cwd     string
+		// want are the directories searched for .odin/config.cue, relative
+		// to the temporary root.
+		want []string
+	}{
+		{
+			name:    "stops at the repository root",
+			markers: []string{"repo/.git", "repo/bundles/app/cue.mod"},
+			cwd:     "repo/bundles/app",
+			want:    []string{"repo/bundles/app", "repo/bundles", "repo"},
+		},
+		{
+			name:    "stops at the module root outside a repository",
+			markers: []string{"app/cue.mod"},
+			cwd:     "app/sub",
+			want:    []string{"app/sub", "app"},
+		},
+		{
+			name: "searches only the current directory outside a project",
+			cwd:  "scratch",
+			want: []string{"scratch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", "")
+			t.Setenv("HOME", t.TempDir())
+			root, err := filepath.EvalSymlinks(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, dir := range append(tt.markers, tt.cwd) {
+				if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Chdir(filepath.Join(root, tt.cwd))
+
+			var got []string
+			for _, path := range configSearchPaths() {
+				rel, err := filepath.Rel(root, path)
+				if err != nil || !filepath.IsLocal(rel) {
+					continue
+				}
+				got = append(got, filepath.ToSlash(filepath.Dir(filepath.Dir(rel))))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("configSearchPaths() searched %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
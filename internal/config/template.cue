@@ -25,3 +25,14 @@ cue: {
 defaults: {
 	prompt: false
 }
+oci: {
+	// The registry, optionally with a repository prefix, that odin push and odin pull use for references that don't
+	// name one, so that "mybundle:v1" means "<defaultRegistry>/mybundle:v1", e.g.
+	//   defaultRegistry: "ghcr.io/my-org"
+
+	// Mirrors that odin pull fetches from instead of the registry they stand in for, keyed by registry host. The mirror
+	// may include a repository prefix, so with the example below ghcr.io/org/app:v1 is pulled from
+	// mirror.internal/ghcr.io/org/app:v1. Pushes always go to the registry named in the reference.
+	//   mirrors: "ghcr.io": "mirror.internal/ghcr.io"
+	mirrors: {}
+}
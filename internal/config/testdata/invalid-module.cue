@@ -0,0 +1,3 @@
+cue: registries: {
+	"not a module": "registry.example.com/cue"
+}
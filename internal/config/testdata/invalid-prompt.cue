@@ -0,0 +1,4 @@
+cue: registries: {
+	"example.com": "registry.example.com/cue"
+}
+defaults: prompt: "yes"
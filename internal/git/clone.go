@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// CloneAt clones the repository at url into dir and checks out ref, which may
+// be a branch, tag or commit. An empty ref checks out the remote's default
+// branch. Only the commit to check out is fetched, except for a commit the
+// remote won't serve on its own or an abbreviated hash, which fall back to a
+// full clone. When sparseDirs is non-empty only those directories are
+// checked out.
+func CloneAt(url, ref, dir string, sparseDirs []string) (*Repository, error) {
+	repo, err := cloneRef(url, ref, dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to clone %s: %w", url, err)
+	}
+
+	hash, err := resolveRef(repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{
+		Hash:                      *hash,
+		Force:                     true,
+		SparseCheckoutDirectories: sparseDirs,
+	}); err != nil {
+		return nil, fmt.Errorf("unable to check out %s: %w", ref, err)
+	}
+	return repo, nil
+}
+
+// cloneRef fetches the commit ref names into dir, without checking it out.
+func cloneRef(url, ref, dir string) (*Repository, error) {
+	if ref == "" {
+		return git.PlainClone(dir, false, &git.CloneOptions{
+			URL:          url,
+			NoCheckout:   true,
+			Depth:        1,
+			SingleBranch: true,
+		})
+	}
+
+	if plumbing.IsHash(ref) {
+		repo, err := fetchCommit(url, ref, dir)
+		if !errors.Is(err, git.ErrExactSHA1NotSupported) {
+			return repo, err
+		}
+	} else {
+		for _, name := range []plumbing.ReferenceName{
+			plumbing.NewBranchReferenceName(ref),
+			plumbing.NewTagReferenceName(ref),
+		} {
+			repo, err := git.PlainClone(dir, false, &git.CloneOptions{
+				URL:           url,
+				NoCheckout:    true,
+				Depth:         1,
+				SingleBranch:  true,
+				ReferenceName: name,
+			})
+			if !errors.Is(err, git.NoMatchingRefSpecError{}) {
+				return repo, err
+			}
+		}
+	}
+
+	return git.PlainClone(dir, false, &git.CloneOptions{
+		URL:        url,
+		NoCheckout: true,
+		Tags:       git.AllTags,
+	})
+}
+
+// fetchCommit fetches only the commit with the given hash into a new
+// repository in dir. Servers that don't allow commits to be fetched by hash
+// fail with git.ErrExactSHA1NotSupported, leaving dir empty.
+func fetchCommit(url, hash, dir string) (*Repository, error) {
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{url},
+	}); err != nil {
+		return nil, err
+	}
+	if err := repo.Fetch(&git.FetchOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(hash + ":refs/heads/odin-checkout")},
+		Depth:      1,
+	}); err != nil {
+		os.RemoveAll(filepath.Join(dir, git.GitDirName))
+		return nil, err
+	}
+	return repo, nil
+}
+
+// resolveRef resolves ref to a commit. Branches only exist as remote-tracking
+// references after a clone, so they are also looked up under origin.
+func resolveRef(repo *Repository, ref string) (*plumbing.Hash, error) {
+	if ref == "" {
+		head, err := repo.Head()
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve HEAD: %w", err)
+		}
+		hash := head.Hash()
+		return &hash, nil
+	}
+	for _, rev := range []string{ref, "origin/" + ref} {
+		if hash, err := repo.ResolveRevision(plumbing.Revision(rev)); err == nil {
+			return hash, nil
+		}
+	}
+	return nil, &ErrCannotResolveRef{Ref: ref}
+}
@@ -24,3 +24,11 @@ type ErrCannotUseBareRepo struct {
 func (e *ErrCannotUseBareRepo) Error() string {
 	return fmt.Sprintf("repository at '%s' is a bare repository and cannot be used", e.Path)
 }
+
+type ErrCannotResolveRef struct {
+	Ref string
+}
+
+func (e *ErrCannotResolveRef) Error() string {
+	return fmt.Sprintf("unable to resolve '%s' to a branch, tag or commit", e.Ref)
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+
+package git
+
+import "fmt"
+
+// HeadRevision returns the commit SHA checked out in repo and whether its
+// worktree has uncommitted changes.
+func HeadRevision(repo *Repository) (string, bool, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return "", false, fmt.Errorf("unable to resolve HEAD: %w", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", false, err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return "", false, fmt.Errorf("unable to get worktree status: %w", err)
+	}
+	return head.Hash().String(), !status.IsClean(), nil
+}
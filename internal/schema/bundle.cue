@@ -18,4 +18,8 @@
 		}
 		...
 	}
+	transforms?: [string]: {
+		in:  {...}
+		out: {...}
+	}
 }
@@ -9,8 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
-
-	"cuelang.org/go/pkg/strings"
+	"strings"
 )
 
 func FormatRegistryConfig(registries map[string]string) string {
@@ -24,6 +23,26 @@ func FormatRegistryConfig(registries map[string]string) string {
 	return strings.Join(r, ",")
 }
 
+// ParseRegistryConfig parses registries in the format FormatRegistryConfig
+// writes, comma-separated prefix=registry pairs. Unlike CUE_REGISTRY, every
+// entry must name a module prefix.
+func ParseRegistryConfig(s string) (map[string]string, error) {
+	registries := make(map[string]string)
+	for entry := range strings.SplitSeq(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		prefix, registry, ok := strings.Cut(entry, "=")
+		prefix, registry = strings.TrimSpace(prefix), strings.TrimSpace(registry)
+		if !ok || prefix == "" || registry == "" {
+			return nil, fmt.Errorf("invalid registry %q, must be prefix=registry", entry)
+		}
+		registries[prefix] = registry
+	}
+	return registries, nil
+}
+
 func CreateCueEnvironment(cacheDir string, registries map[string]string) []string {
 	registryConfig := FormatRegistryConfig(registries)
 	env := make([]string, 0, 4)
@@ -47,3 +66,14 @@ func CreateCueEnvironment(cacheDir string, registries map[string]string) []strin
 
 	return env
 }
+
+// CreateOfflineCueEnvironment is CreateCueEnvironment for running without
+// network access: modules are only read from the cache, and any that aren't
+// cached fail to resolve instead of being fetched. The cache is the one
+// CreateCueEnvironment uses for the same registries.
+func CreateOfflineCueEnvironment(cacheDir string, registries map[string]string) []string {
+	env := slices.DeleteFunc(CreateCueEnvironment(cacheDir, registries), func(e string) bool {
+		return strings.HasPrefix(e, "CUE_REGISTRY=")
+	})
+	return append(env, "CUE_REGISTRY=none")
+}
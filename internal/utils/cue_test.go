@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -50,6 +51,34 @@ func TestFormatRegistryConfig(t *testing.T) {
 	}
 }
 
+func TestParseRegistryConfig(t *testing.T) {
+	got, err := ParseRegistryConfig(" a.com=registry.a.com , m.com=localhost:5000/cue+insecure,")
+	if err != nil {
+		t.Fatalf("ParseRegistryConfig() error = %v", err)
+	}
+	want := map[string]string{
+		"a.com": "registry.a.com",
+		"m.com": "localhost:5000/cue+insecure",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParseRegistryConfig() = %v, want %v", got, want)
+	}
+	for prefix, registry := range want {
+		if got[prefix] != registry {
+			t.Errorf("ParseRegistryConfig()[%q] = %q, want %q", prefix, got[prefix], registry)
+		}
+	}
+	if FormatRegistryConfig(got) != "a.com=registry.a.com,m.com=localhost:5000/cue+insecure" {
+		t.Errorf("FormatRegistryConfig(ParseRegistryConfig()) = %q", FormatRegistryConfig(got))
+	}
+
+	for _, s := range []string{"registry.a.com", "=registry.a.com", "a.com="} {
+		if _, err := ParseRegistryConfig(s); err == nil {
+			t.Errorf("ParseRegistryConfig(%q) succeeded, want an error", s)
+		}
+	}
+}
+
 func TestCreateCueEnvironment(t *testing.T) {
 	// Save and restore original env vars
 	origHome := os.Getenv("HOME")
@@ -189,3 +218,29 @@ func TestCreateCueEnvironment(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateOfflineCueEnvironment(t *testing.T) {
+	registries := map[string]string{"example.com": "registry.example.com"}
+	online := CreateCueEnvironment("/tmp/cache", registries)
+	offline := CreateOfflineCueEnvironment("/tmp/cache", registries)
+
+	var onlineCache string
+	for _, env := range online {
+		if strings.HasPrefix(env, "CUE_CACHE_DIR=") {
+			onlineCache = env
+		}
+	}
+	if !slices.Contains(offline, onlineCache) {
+		t.Errorf("CreateOfflineCueEnvironment() = %v, want the online cache %q", offline, onlineCache)
+	}
+
+	var registryVars []string
+	for _, env := range offline {
+		if strings.HasPrefix(env, "CUE_REGISTRY=") {
+			registryVars = append(registryVars, env)
+		}
+	}
+	if !slices.Equal(registryVars, []string{"CUE_REGISTRY=none"}) {
+		t.Errorf("CreateOfflineCueEnvironment() registries = %v, want [CUE_REGISTRY=none]", registryVars)
+	}
+}
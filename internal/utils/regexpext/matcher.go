@@ -4,25 +4,47 @@ package regexpext
 
 import "regexp"
 
+// Result is a match found by Matcher.Match. Its methods may be called on a
+// nil Result, which has no groups.
 type Result struct {
 	matches     []string
 	namedGroups map[string]int
 }
 
 func (r *Result) All() []string {
+	if r == nil {
+		return nil
+	}
 	return r.matches
 }
 
+// Get returns the group at pos, where 0 is the whole match, or an empty
+// string if there's no such group.
 func (r *Result) Get(pos int) string {
+	if r == nil || pos < 0 || pos >= len(r.matches) {
+		return ""
+	}
 	return r.matches[pos]
 }
 
 func (r *Result) Length() int {
+	if r == nil {
+		return 0
+	}
 	return len(r.matches)
 }
 
+// Named returns the group with the given name, or an empty string if the
+// expression has no such group.
 func (r *Result) Named(name string) string {
-	return r.matches[r.namedGroups[name]]
+	if r == nil {
+		return ""
+	}
+	pos, ok := r.namedGroups[name]
+	if !ok {
+		return ""
+	}
+	return r.Get(pos)
 }
 
 type Matcher struct {
@@ -45,11 +67,34 @@ func NewMatcher(expression string) (*Matcher, error) {
 	return &Matcher{expression: re, namedGroups: groups}, nil
 }
 
+// Match returns the first match of the expression in text, or nil if there
+// isn't one.
 func (m *Matcher) Match(text string) *Result {
 	match := m.expression.FindStringSubmatch(text)
+	if match == nil {
+		return nil
+	}
 
 	return &Result{
 		matches:     match,
 		namedGroups: m.namedGroups,
 	}
 }
+
+// Expand returns template with $name, ${name} and $1 style references
+// replaced by the groups of the first match in text, as regexp.Expand does.
+// If the expression doesn't match text the result is empty.
+func (m *Matcher) Expand(template, text string) string {
+	match := m.expression.FindStringSubmatchIndex(text)
+	if match == nil {
+		return ""
+	}
+	return string(m.expression.ExpandString(nil, template, text, match))
+}
+
+// ReplaceAllNamed returns text with every match of the expression replaced
+// by replacement, in which $name and ${name} refer to named groups of the
+// match.
+func (m *Matcher) ReplaceAllNamed(text, replacement string) string {
+	return m.expression.ReplaceAllString(text, replacement)
+}
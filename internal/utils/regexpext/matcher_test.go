@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+
+package regexpext
+
+import "testing"
+
+func TestMatcherExpand(t *testing.T) {
+	m, err := NewMatcher(`^(?P<format>\w+):\s*(?P<path>.+)$`)
+	if err != nil {
+		t.Fatalf("NewMatcher() error = %v", err)
+	}
+
+	tests := []struct {
+		template string
+		text     string
+		want     string
+	}{
+		{"$path ($format)", "yaml: values.txt", "values.txt (yaml)"},
+		{"${format}_file", "json: a.json", "json_file"},
+		{"$2", "toml: b.toml", "b.toml"},
+		{"$path", "values.yaml", ""},
+	}
+	for _, tt := range tests {
+		if got := m.Expand(tt.template, tt.text); got != tt.want {
+			t.Errorf("Expand(%q, %q) = %q, want %q", tt.template, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMatcherReplaceAllNamed(t *testing.T) {
+	m, err := NewMatcher(`(?P<key>\w+)=(?P<value>\w+)`)
+	if err != nil {
+		t.Fatalf("NewMatcher() error = %v", err)
+	}
+	if got, want := m.ReplaceAllNamed("a=1, b=2", "${value}=${key}"), "1=a, 2=b"; got != want {
+		t.Errorf("ReplaceAllNamed() = %q, want %q", got, want)
+	}
+	if got := m.ReplaceAllNamed("no pairs", "$value"); got != "no pairs" {
+		t.Errorf("ReplaceAllNamed() = %q, want the text unchanged", got)
+	}
+}
+
+func TestMatcherMatchNoMatch(t *testing.T) {
+	m, err := NewMatcher(`^(?P<key>\w+)=(?P<value>\w*)$`)
+	if err != nil {
+		t.Fatalf("NewMatcher() error = %v", err)
+	}
+
+	result := m.Match("not a pair")
+	if result != nil {
+		t.Fatalf("Match() = %v, want nil", result.All())
+	}
+	if got := result.Named("key"); got != "" {
+		t.Errorf("nil Result Named() = %q, want empty", got)
+	}
+	if got := result.Get(1); got != "" {
+		t.Errorf("nil Result Get() = %q, want empty", got)
+	}
+	if result.Length() != 0 || result.All() != nil {
+		t.Errorf("nil Result Length() = %d, All() = %v, want no groups", result.Length(), result.All())
+	}
+
+	result = m.Match("a=")
+	if result == nil {
+		t.Fatal("Match(a=) = nil, want a match")
+	}
+	if got := result.Named("key"); got != "a" {
+		t.Errorf("Named(key) = %q, want a", got)
+	}
+	if got := result.Named("missing"); got != "" {
+		t.Errorf("Named(missing) = %q, want empty", got)
+	}
+	if got := result.Get(5); got != "" {
+		t.Errorf("Get(5) = %q, want empty", got)
+	}
+}
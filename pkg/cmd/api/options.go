@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"io"
+	"log/slog"
+
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+type Options struct {
+	BundlePath string
+	// Definition is the API definition to show, e.g. "#Component"; empty
+	// shows #ComponentBase.
+	Definition string
+	Format     string
+	Expand     bool
+	CacheDir   string
+	Offline    bool
+	Logger     *slog.Logger
+	Registries map[string]string
+	// Timings, if set, records how long loading the bundle took.
+	Timings *model.Timings
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		Format:     "text",
+		Registries: make(map[string]string),
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+	}
+}
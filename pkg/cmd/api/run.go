@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/fatih/color"
+	"go-valkyrie.com/odin/pkg/model"
+	"go-valkyrie.com/odin/pkg/schema"
+)
+
+func (o *Options) Run(ctx context.Context) error {
+	return run(ctx, *o)
+}
+
+func run(ctx context.Context, opts Options) error {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	}
+
+	b, err := model.LoadBundle(
+		opts.BundlePath,
+		model.WithLogger(logger),
+		model.WithRegistries(opts.Registries),
+		model.WithCacheDir(opts.CacheDir),
+		model.WithOffline(opts.Offline),
+		model.WithTimings(opts.Timings),
+	)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	def, err := b.APIDefinition(opts.Definition, schema.WithExpand(opts.Expand))
+	if err != nil {
+		return err
+	}
+
+	w := os.Stdout
+	switch opts.Format {
+	case "text", "":
+		writeText(w, def)
+	case "markdown", "md":
+		writeMarkdown(w, def)
+	case "json":
+		data, err := schema.MarshalSchema(def.Fields)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	default:
+		return fmt.Errorf("unsupported output format: %q (supported: text, markdown, json)", opts.Format)
+	}
+	return nil
+}
+
+func writeText(w io.Writer, def *model.APIDefinition) {
+	header := color.New(color.Bold, color.FgCyan).SprintFunc()
+	italic := color.New(color.Italic).SprintFunc()
+
+	fmt.Fprintf(w, "%s %s\n", header(def.Package), header(def.Name))
+	fmt.Fprintln(w)
+	if def.Doc != "" {
+		fmt.Fprintln(w, italic(def.Doc))
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, header("Fields:"))
+	schema.FormatSchema(w, def.Fields, 2)
+}
+
+func writeMarkdown(w io.Writer, def *model.APIDefinition) {
+	fmt.Fprintf(w, "# %s\n", def.Name)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Package: `%s`\n", def.Package)
+	fmt.Fprintln(w)
+	if def.Doc != "" {
+		fmt.Fprintln(w, def.Doc)
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "## Fields")
+	fmt.Fprintln(w)
+	schema.FormatSchemaMarkdown(w, def.Fields, 0)
+}
@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: MIT
+
+package browse
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"go-valkyrie.com/odin/pkg/model"
+	"go-valkyrie.com/odin/pkg/schema"
+)
+
+type pane int
+
+const (
+	paneTemplates pane = iota
+	paneSchema
+)
+
+var (
+	activeBorder   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("6"))
+	inactiveBorder = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8"))
+	selectedStyle  = lipgloss.NewStyle().Reverse(true)
+	headerStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	typeStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	defaultStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	mutedStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	docStyle       = lipgloss.NewStyle().Italic(true)
+)
+
+const helpText = "↑/↓ move • tab switch pane • enter/→ expand • ← collapse • q quit"
+
+// schemaRow is a visible line of the schema pane.
+type schemaRow struct {
+	field *schema.SchemaField
+	path  string
+	depth int
+}
+
+// browser is the bubbletea model of odin browse: a list of templates on the
+// left and the selected template's config schema, as a collapsible tree, on
+// the right.
+type browser struct {
+	templates []*model.ComponentTemplate
+	expand    bool
+
+	// schemas caches the config schema of each template by index, as
+	// walking it evaluates CUE.
+	schemas map[int][]*schema.SchemaField
+	// expanded holds the schema paths expanded per template index.
+	expanded map[int]map[string]bool
+
+	focus        pane
+	selected     int
+	schemaCursor int
+	width        int
+	height       int
+}
+
+func newBrowser(templates []*model.ComponentTemplate, expand bool) *browser {
+	return &browser{
+		templates: templates,
+		expand:    expand,
+		schemas:   make(map[int][]*schema.SchemaField),
+		expanded:  make(map[int]map[string]bool),
+	}
+}
+
+func (b *browser) Init() tea.Cmd {
+	return nil
+}
+
+func (b *browser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.width, b.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return b, tea.Quit
+		case "tab", "shift+tab":
+			if b.focus == paneTemplates {
+				b.focus = paneSchema
+			} else {
+				b.focus = paneTemplates
+			}
+		case "up", "k":
+			b.move(-1)
+		case "down", "j":
+			b.move(1)
+		case "enter", " ", "right", "l":
+			if b.focus == paneTemplates {
+				b.focus = paneSchema
+			} else {
+				b.toggle(true)
+			}
+		case "left", "h":
+			if b.focus == paneSchema && !b.toggle(false) {
+				b.focus = paneTemplates
+			}
+		}
+	}
+	return b, nil
+}
+
+// move moves the cursor of the focused pane by delta, clamped to its rows.
+func (b *browser) move(delta int) {
+	if b.focus == paneTemplates {
+		b.selected = clamp(b.selected+delta, len(b.templates))
+		b.schemaCursor = 0
+		return
+	}
+	b.schemaCursor = clamp(b.schemaCursor+delta, len(b.rows()))
+}
+
+// toggle expands or collapses the schema row under the cursor, reporting
+// whether anything changed.
+func (b *browser) toggle(expand bool) bool {
+	rows := b.rows()
+	if b.schemaCursor >= len(rows) {
+		return false
+	}
+	row := rows[b.schemaCursor]
+	if len(row.field.Children) == 0 {
+		return false
+	}
+	expanded := b.expanded[b.selected]
+	if expanded == nil {
+		expanded = make(map[string]bool)
+		b.expanded[b.selected] = expanded
+	}
+	if expanded[row.path] == expand {
+		return false
+	}
+	expanded[row.path] = expand
+	return true
+}
+
+// schema returns the config schema of the selected template.
+func (b *browser) schema() []*schema.SchemaField {
+	fields, ok := b.schemas[b.selected]
+	if !ok {
+		fields = b.templates[b.selected].ConfigSchema(schema.WithExpand(b.expand))
+		b.schemas[b.selected] = fields
+	}
+	return fields
+}
+
+// rows flattens the selected template's schema into its visible rows.
+func (b *browser) rows() []schemaRow {
+	var rows []schemaRow
+	expanded := b.expanded[b.selected]
+	var walk func(fields []*schema.SchemaField, prefix string, depth int)
+	walk = func(fields []*schema.SchemaField, prefix string, depth int) {
+		for _, f := range fields {
+			path := prefix + f.Name
+			rows = append(rows, schemaRow{field: f, path: path, depth: depth})
+			if expanded[path] {
+				walk(f.Children, path+".", depth+1)
+			}
+		}
+	}
+	walk(b.schema(), "", 0)
+	return rows
+}
+
+func (b *browser) View() string {
+	if b.width == 0 || b.height == 0 {
+		return ""
+	}
+
+	// Each pane's border takes two columns and two rows; the help line
+	// takes one more row.
+	paneHeight := max(b.height-3, 1)
+	listWidth := max(b.width/3-2, 10)
+	schemaWidth := max(b.width-listWidth-4, 10)
+
+	listStyle, schemaStyle := inactiveBorder, inactiveBorder
+	if b.focus == paneTemplates {
+		listStyle = activeBorder
+	} else {
+		schemaStyle = activeBorder
+	}
+
+	list := listStyle.Width(listWidth).Height(paneHeight).
+		Render(b.renderTemplates(listWidth, paneHeight))
+	detail := schemaStyle.Width(schemaWidth).Height(paneHeight).
+		Render(b.renderSchema(schemaWidth, paneHeight))
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, list, detail),
+		mutedStyle.Render(helpText),
+	)
+}
+
+func (b *browser) renderTemplates(width, height int) string {
+	lines := make([]string, len(b.templates))
+	for i, tmpl := range b.templates {
+		line := truncate(fmt.Sprintf("%s %s", shortPackage(tmpl.Package), tmpl.Name), width)
+		if i == b.selected {
+			line = selectedStyle.Render(line)
+		}
+		lines[i] = line
+	}
+	return strings.Join(window(lines, b.selected, height), "\n")
+}
+
+func (b *browser) renderSchema(width, height int) string {
+	tmpl := b.templates[b.selected]
+	header := []string{
+		headerStyle.Render(truncate(tmpl.Package+" "+tmpl.Name, width)),
+		"",
+	}
+
+	rows := b.rows()
+	if len(rows) == 0 {
+		return strings.Join(append(header, mutedStyle.Render("no config fields")), "\n")
+	}
+
+	// Reserve space below the tree for the doc comment of the current row.
+	var doc []string
+	if b.schemaCursor < len(rows) {
+		if text := rows[b.schemaCursor].field.Doc; text != "" {
+			doc = append(doc, "")
+			for _, line := range strings.Split(text, "\n") {
+				doc = append(doc, docStyle.Render(truncate(line, width)))
+			}
+		}
+	}
+	treeHeight := max(height-len(header)-len(doc), 1)
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		line := truncate(formatRow(row, b.expanded[b.selected][row.path]), width)
+		if i == b.schemaCursor && b.focus == paneSchema {
+			line = selectedStyle.Render(line)
+		}
+		lines[i] = line
+	}
+
+	out := append(header, window(lines, b.schemaCursor, treeHeight)...)
+	return strings.Join(append(out, doc...), "\n")
+}
+
+// formatRow renders a schema field as a tree line with an expansion marker,
+// its name and, for leaves, its type and default.
+func formatRow(row schemaRow, expanded bool) string {
+	f := row.field
+	marker := "  "
+	if len(f.Children) > 0 {
+		marker = "▸ "
+		if expanded {
+			marker = "▾ "
+		}
+	}
+
+	name := f.Name
+	if !f.IsPattern {
+		if f.Required {
+			name += "!"
+		} else if f.Optional {
+			name += "?"
+		}
+	}
+
+	line := strings.Repeat("  ", row.depth) + marker + name
+	if len(f.Children) == 0 {
+		line += " " + typeStyle.Render(f.Type)
+		if f.Default != "" {
+			line += defaultStyle.Render(" (default: " + f.Default + ")")
+		}
+	}
+	return line
+}
+
+// window returns the slice of lines of at most height lines that keeps
+// cursor visible.
+func window(lines []string, cursor, height int) []string {
+	if len(lines) <= height {
+		return lines
+	}
+	start := max(cursor-height+1, 0)
+	return lines[start : start+height]
+}
+
+// shortPackage returns the last element of a package import path.
+func shortPackage(pkg string) string {
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		return pkg[i+1:]
+	}
+	return pkg
+}
+
+// truncate shortens s, which may contain styling, to width terminal cells.
+func truncate(s string, width int) string {
+	return ansi.Truncate(s, width, "…")
+}
+
+func clamp(i, n int) int {
+	if n == 0 || i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
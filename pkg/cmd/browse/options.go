@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+
+package browse
+
+import (
+	"io"
+	"log/slog"
+)
+
+type Options struct {
+	BundlePath string
+	Expand     bool
+	CacheDir   string
+	Offline    bool
+	Logger     *slog.Logger
+	Registries map[string]string
+	Input      io.Reader
+	Output     io.Writer
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		Registries: make(map[string]string),
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+	}
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: MIT
+
+package browse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+	cmddocs "go-valkyrie.com/odin/pkg/cmd/docs"
+)
+
+// ErrNotTerminal is returned when the browser is started without a terminal
+// to draw on.
+var ErrNotTerminal = errors.New("odin browse requires an interactive terminal")
+
+func (o *Options) Run(ctx context.Context) error {
+	return run(ctx, *o)
+}
+
+func run(ctx context.Context, opts Options) error {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	}
+
+	input := opts.Input
+	if input == nil {
+		input = os.Stdin
+	}
+	output := opts.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	if !isTerminal(input) || !isTerminal(output) {
+		return ErrNotTerminal
+	}
+
+	docsOpts := cmddocs.Options{
+		BundlePath: opts.BundlePath,
+		CacheDir:   opts.CacheDir,
+		Offline:    opts.Offline,
+		Logger:     logger,
+		Registries: opts.Registries,
+	}
+	templates, err := docsOpts.Templates(ctx)
+	if err != nil {
+		return err
+	}
+	if len(templates) == 0 {
+		return fmt.Errorf("no component templates found in %s", opts.BundlePath)
+	}
+	logger.Debug("discovered component templates", "count", len(templates))
+
+	program := tea.NewProgram(
+		newBrowser(templates, opts.Expand),
+		tea.WithContext(ctx),
+		tea.WithInput(input),
+		tea.WithOutput(output),
+		tea.WithAltScreen(),
+	)
+	if _, err := program.Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return err
+	}
+	return nil
+}
+
+// isTerminal reports whether f is a file attached to a terminal.
+func isTerminal(f any) bool {
+	file, ok := f.(interface{ Fd() uintptr })
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())
+}
@@ -5,14 +5,35 @@ package components
 import (
 	"io"
 	"log/slog"
+
+	"go-valkyrie.com/odin/pkg/model"
 )
 
 type Options struct {
 	BundlePath string
 	Format     string
+	Scope      string
+	ShowBase   bool
+	// Wide disables truncating long package paths to fit the terminal in
+	// table output.
+	Wide       bool
 	CacheDir   string
+	Offline    bool
 	Logger     *slog.Logger
 	Registries map[string]string
+	// ExcludeDependencies are patterns of dependencies to skip when
+	// discovering templates; see model.WithExcludeDependencies.
+	ExcludeDependencies []string
+	// ComponentBases override the definitions templates are discovered by,
+	// each given as "<import path>:#<Definition>"; see
+	// model.WithComponentBases.
+	ComponentBases []string
+	// Strict fails on packages that don't build instead of skipping them
+	// with a warning.
+	Strict bool
+	// Timings, if set, records how long loading the bundle and discovering
+	// templates took.
+	Timings *model.Timings
 }
 
 func DefaultOptions() *Options {
@@ -9,8 +9,11 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/x/term"
 	"go-valkyrie.com/odin/pkg/model"
 )
 
@@ -24,16 +27,38 @@ func run(ctx context.Context, opts Options) error {
 		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 	}
 
+	scope, err := model.ParseTemplateScope(opts.Scope)
+	if err != nil {
+		return err
+	}
+
 	modelOpts := []model.Option{
 		model.WithLogger(logger),
 		model.WithRegistries(opts.Registries),
 		model.WithCacheDir(opts.CacheDir),
+		model.WithOffline(opts.Offline),
+		model.WithTemplateScope(scope),
+		model.WithTimings(opts.Timings),
+		model.WithExcludeDependencies(opts.ExcludeDependencies),
+		model.WithStrictDiscovery(opts.Strict),
+		model.WithComponentBases(opts.ComponentBases),
+	}
+
+	switch opts.Format {
+	case "table", "wide", "json", "ndjson":
+	default:
+		return fmt.Errorf("unsupported output format: %q (supported: table, wide, json, ndjson)", opts.Format)
 	}
 
 	b, err := model.LoadBundle(opts.BundlePath, modelOpts...)
 	if err != nil {
 		return err
 	}
+	defer b.Close()
+
+	if opts.Format == "ndjson" {
+		return runNDJSON(ctx, b)
+	}
 
 	var templates []*model.ComponentTemplate
 	for tmpl, err := range b.ComponentTemplates(ctx) {
@@ -44,45 +69,149 @@ func run(ctx context.Context, opts Options) error {
 	}
 
 	switch opts.Format {
-	case "table":
-		return runTable(templates)
-	case "json":
-		return runJSON(templates)
+	case "table", "wide":
+		// Fit the table to the terminal unless asked for the full width;
+		// output that isn't a terminal is never truncated.
+		width := 0
+		if opts.Format == "table" && !opts.Wide {
+			width = terminalWidth(os.Stdout)
+		}
+		return runTable(templates, opts.ShowBase, width)
 	default:
-		return fmt.Errorf("unsupported output format: %q (supported: table, json)", opts.Format)
+		return runJSON(templates)
 	}
 }
 
-func runTable(templates []*model.ComponentTemplate) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "PACKAGE\tDEFINITION\tVERSION")
+// tablePadding is the space between table columns.
+const tablePadding = 3
 
+// minPackageWidth is the narrowest the package column is truncated to.
+const minPackageWidth = 20
+
+func runTable(templates []*model.ComponentTemplate, showBase bool, width int) error {
+	header := []string{"PACKAGE", "DEFINITION", "VERSION", "SOURCE"}
+	if showBase {
+		header = append(header, "BASE")
+	}
+	rows := [][]string{header}
 	for _, tmpl := range templates {
-		fmt.Fprintf(w, "%s\t%s\t%s\n", tmpl.Package, tmpl.Name, tmpl.Version)
+		row := []string{tmpl.Package, tmpl.Name, tmpl.Version, templateSource(tmpl)}
+		if showBase {
+			row = append(row, tmpl.Base)
+		}
+		rows = append(rows, row)
 	}
 
+	if width > 0 {
+		truncatePackages(rows, width)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, tablePadding, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
 	return w.Flush()
 }
 
-type componentJSON struct {
+// truncatePackages shortens the package column of rows, from the left, so
+// that the table fits in width columns. The package column is never made
+// narrower than minPackageWidth.
+func truncatePackages(rows [][]string, width int) {
+	columns := len(rows[0])
+	others := make([]int, columns)
+	for _, row := range rows {
+		for i := 1; i < columns; i++ {
+			others[i] = max(others[i], utf8.RuneCountInString(row[i]))
+		}
+	}
+	available := width
+	for i := 1; i < columns; i++ {
+		available -= others[i] + tablePadding
+	}
+	available = max(available, minPackageWidth)
+
+	for _, row := range rows[1:] {
+		row[0] = truncateLeft(row[0], available)
+	}
+}
+
+// truncateLeft shortens s to at most n runes by replacing its start with an
+// ellipsis, keeping the end of a path, which tells packages apart.
+func truncateLeft(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return "…" + string(runes[len(runes)-n+1:])
+}
+
+// terminalWidth returns the width of the terminal f is attached to, or 0 if
+// it isn't a terminal.
+func terminalWidth(f *os.File) int {
+	if !term.IsTerminal(f.Fd()) {
+		return 0
+	}
+	width, _, err := term.GetSize(f.Fd())
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// ComponentJSON is the JSON representation of a component template in the
+// components inventory.
+type ComponentJSON struct {
 	Package string `json:"package"`
 	Name    string `json:"name"`
 	Module  string `json:"module"`
 	Version string `json:"version"`
+	Source  string `json:"source"`
+	Base    string `json:"base"`
+}
+
+// templateSource describes where a template was discovered.
+func templateSource(tmpl *model.ComponentTemplate) string {
+	if tmpl.Local {
+		return "local"
+	}
+	return "dependency"
+}
+
+// NewComponentJSON returns the inventory entry for tmpl.
+func NewComponentJSON(tmpl *model.ComponentTemplate) ComponentJSON {
+	return ComponentJSON{
+		Package: tmpl.Package,
+		Name:    tmpl.Name,
+		Module:  tmpl.Module,
+		Version: tmpl.Version,
+		Source:  templateSource(tmpl),
+		Base:    tmpl.Base,
+	}
 }
 
 func runJSON(templates []*model.ComponentTemplate) error {
-	components := make([]componentJSON, 0, len(templates))
+	components := make([]ComponentJSON, 0, len(templates))
 	for _, tmpl := range templates {
-		components = append(components, componentJSON{
-			Package: tmpl.Package,
-			Name:    tmpl.Name,
-			Module:  tmpl.Module,
-			Version: tmpl.Version,
-		})
+		components = append(components, NewComponentJSON(tmpl))
 	}
 
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	return enc.Encode(components)
 }
+
+// runNDJSON writes each template as a line of JSON as soon as it's
+// discovered, rather than collecting them first, so large catalogs can be
+// consumed as a stream.
+func runNDJSON(ctx context.Context, b *model.Bundle) error {
+	enc := json.NewEncoder(os.Stdout)
+	for tmpl, err := range b.ComponentTemplates(ctx) {
+		if err != nil {
+			return fmt.Errorf("discovering component templates: %w", err)
+		}
+		if err := enc.Encode(NewComponentJSON(tmpl)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
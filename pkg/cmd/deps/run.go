@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+
+package deps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+func (o *Options) Run(ctx context.Context) error {
+	return run(ctx, *o)
+}
+
+func run(ctx context.Context, opts Options) error {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	}
+
+	b, err := model.LoadBundle(
+		opts.BundlePath,
+		model.WithLogger(logger),
+		model.WithRegistries(opts.Registries),
+		model.WithCacheDir(opts.CacheDir),
+		model.WithOffline(opts.Offline),
+	)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	statuses, err := b.CheckDependencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case "table":
+		return runTable(statuses)
+	case "json":
+		return runJSON(statuses)
+	default:
+		return fmt.Errorf("unsupported output format: %q (supported: table, json)", opts.Format)
+	}
+}
+
+// dependencyState summarises a dependency status in a single word.
+func dependencyState(status model.DependencyStatus) string {
+	switch {
+	case status.Err != nil:
+		return "error"
+	case status.Outdated():
+		return "outdated"
+	default:
+		return "up-to-date"
+	}
+}
+
+func runTable(statuses []model.DependencyStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "MODULE\tCURRENT\tLATEST\tSTATUS")
+
+	for _, status := range statuses {
+		state := dependencyState(status)
+		if status.Err != nil {
+			state = fmt.Sprintf("%s: %v", state, status.Err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status.Path, status.Version, status.Latest, state)
+	}
+
+	return w.Flush()
+}
+
+type dependencyJSON struct {
+	Module   string `json:"module"`
+	Current  string `json:"current"`
+	Latest   string `json:"latest,omitempty"`
+	Outdated bool   `json:"outdated"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+func runJSON(statuses []model.DependencyStatus) error {
+	deps := make([]dependencyJSON, 0, len(statuses))
+	for _, status := range statuses {
+		dep := dependencyJSON{
+			Module:   status.Path,
+			Current:  status.Version,
+			Latest:   status.Latest,
+			Outdated: status.Outdated(),
+			Status:   dependencyState(status),
+		}
+		if status.Err != nil {
+			dep.Error = status.Err.Error()
+		}
+		deps = append(deps, dep)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(deps)
+}
@@ -5,22 +5,52 @@ package docs
 import (
 	"io"
 	"log/slog"
+
+	"go-valkyrie.com/odin/pkg/model"
 )
 
 type Options struct {
 	BundlePath string
 	Reference  string
 	Expand     bool
+	// Depth limits how many levels of nested fields are shown; 0 shows only
+	// top-level fields and a negative depth is unlimited.
+	Depth      int
 	Format     string
 	OutputPath string
 	NoSummary  bool
+	Explain    string
+	// Path restricts the output to the config field at this path and its
+	// subtree.
+	Path string
+	// Example, if set, adds an example config block in this format (cue or
+	// yaml) to markdown output.
+	Example    string
 	CacheDir   string
+	Offline    bool
 	Logger     *slog.Logger
 	Registries map[string]string
+	// ExcludeDependencies are patterns of dependencies to skip when
+	// discovering templates; see model.WithExcludeDependencies.
+	ExcludeDependencies []string
+	// ComponentBases override the definitions templates are discovered by,
+	// each given as "<import path>:#<Definition>"; see
+	// model.WithComponentBases.
+	ComponentBases []string
+	// CaseSensitive matches definition and package names in the reference
+	// in the same case only.
+	CaseSensitive bool
+	// Strict fails on packages that don't build instead of skipping them
+	// with a warning.
+	Strict bool
+	// Timings, if set, records how long loading the bundle and discovering
+	// templates took.
+	Timings *model.Timings
 }
 
 func DefaultOptions() *Options {
 	return &Options{
+		Depth:      -1,
 		Registries: make(map[string]string),
 		Logger:     slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
 	}
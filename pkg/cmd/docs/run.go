@@ -22,7 +22,27 @@ func (o *Options) Run(ctx context.Context) error {
 	return run(ctx, *o)
 }
 
-func run(ctx context.Context, opts Options) error {
+// References returns the short references of every component template
+// available to the bundle, suitable for shell completion.
+func (o *Options) References(ctx context.Context) ([]string, error) {
+	templates, err := loadTemplates(ctx, *o)
+	if err != nil {
+		return nil, err
+	}
+	return docs.AvailableReferences(templates), nil
+}
+
+// Templates returns every component template available to the bundle.
+func (o *Options) Templates(ctx context.Context) ([]*model.ComponentTemplate, error) {
+	return loadTemplates(ctx, *o)
+}
+
+// WriteMarkdown writes the markdown documentation for tmpl to w.
+func (o *Options) WriteMarkdown(w io.Writer, tmpl *model.ComponentTemplate) error {
+	return runMarkdown(tmpl, *o, w)
+}
+
+func loadBundle(opts Options) (*model.Bundle, error) {
 	logger := opts.Logger
 	if logger == nil {
 		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
@@ -32,34 +52,68 @@ func run(ctx context.Context, opts Options) error {
 		model.WithLogger(logger),
 		model.WithRegistries(opts.Registries),
 		model.WithCacheDir(opts.CacheDir),
+		model.WithOffline(opts.Offline),
+		model.WithTimings(opts.Timings),
+		model.WithExcludeDependencies(opts.ExcludeDependencies),
+		model.WithStrictDiscovery(opts.Strict),
+		model.WithComponentBases(opts.ComponentBases),
 	}
 
-	b, err := model.LoadBundle(opts.BundlePath, modelOpts...)
+	return model.LoadBundle(opts.BundlePath, modelOpts...)
+}
+
+func loadTemplates(ctx context.Context, opts Options) ([]*model.ComponentTemplate, error) {
+	b, err := loadBundle(opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer b.Close()
+	return collectTemplates(ctx, b)
+}
 
+func collectTemplates(ctx context.Context, b *model.Bundle) ([]*model.ComponentTemplate, error) {
 	var templates []*model.ComponentTemplate
 	for tmpl, err := range b.ComponentTemplates(ctx) {
 		if err != nil {
-			return err
+			return nil, err
 		}
 		templates = append(templates, tmpl)
 	}
+	return templates, nil
+}
+
+func run(ctx context.Context, opts Options) error {
+	b, err := loadBundle(opts)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	if opts.Explain != "" {
+		tmpl, err := b.FindComponentTemplate(ctx, opts.Reference, model.WithCaseSensitive(opts.CaseSensitive))
+		if err != nil {
+			return err
+		}
+		return runExplain(tmpl, opts)
+	}
 
 	// Resolve reference to one or more templates
 	var resolvedTemplates []*model.ComponentTemplate
 	if strings.Contains(opts.Reference, "/") && !strings.Contains(opts.Reference, ":#") {
 		// Package path reference
-		resolvedTemplates = docs.ResolvePackagePath(opts.Reference, templates)
+		templates, err := collectTemplates(ctx, b)
+		if err != nil {
+			return err
+		}
+		resolvedTemplates = docs.ResolvePackagePath(opts.Reference, templates, model.WithCaseSensitive(opts.CaseSensitive))
 		if len(resolvedTemplates) == 0 {
 			// Fall back to ResolveReference for helpful error message
-			_, err := docs.ResolveReference(opts.Reference, templates)
+			_, err := docs.ResolveReference(opts.Reference, templates, model.WithCaseSensitive(opts.CaseSensitive))
 			return err
 		}
 	} else {
 		// Single template reference
-		tmpl, err := docs.ResolveReference(opts.Reference, templates)
+		tmpl, err := b.FindComponentTemplate(ctx, opts.Reference, model.WithCaseSensitive(opts.CaseSensitive))
 		if err != nil {
 			return err
 		}
@@ -77,6 +131,16 @@ func run(ctx context.Context, opts Options) error {
 		format = "mdbook"
 	}
 
+	switch opts.Example {
+	case "":
+	case "cue", "yaml":
+		if format == "text" {
+			return fmt.Errorf("examples are only included in markdown output")
+		}
+	default:
+		return fmt.Errorf("unsupported example format: %q (supported: cue, yaml)", opts.Example)
+	}
+
 	// Route to appropriate output handler
 	switch format {
 	case "text":
@@ -140,8 +204,19 @@ func runText(tmpl *model.ComponentTemplate, opts Options, w io.Writer) error {
 	printConcreteField(w, tmpl.Value, "apiVersion", label, value)
 	printConcreteField(w, tmpl.Value, "kind", label, value)
 
+	if opts.Path != "" {
+		fields, err := tmpl.ConfigSchemaAt(opts.Path, schema.WithExpand(opts.Expand), schema.WithMaxDepth(opts.Depth))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, header(fmt.Sprintf("Config (%s):", opts.Path)))
+		schema.FormatSchema(w, fields, 2)
+		return nil
+	}
+
 	// Print config schema
-	fields := tmpl.ConfigSchema(schema.WithExpand(opts.Expand))
+	fields := tmpl.ConfigSchema(schema.WithExpand(opts.Expand), schema.WithMaxDepth(opts.Depth))
 	if len(fields) > 0 {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, header("Config:"))
@@ -149,7 +224,7 @@ func runText(tmpl *model.ComponentTemplate, opts Options, w io.Writer) error {
 	}
 
 	// Print declarations
-	declarations := tmpl.Declarations(schema.WithExpand(opts.Expand))
+	declarations := tmpl.Declarations(schema.WithExpand(opts.Expand), schema.WithMaxDepth(opts.Depth))
 	if len(declarations) > 0 {
 		schema.FormatDeclarations(w, declarations, 2)
 	}
@@ -157,6 +232,48 @@ func runText(tmpl *model.ComponentTemplate, opts Options, w io.Writer) error {
 	return nil
 }
 
+func runExplain(tmpl *model.ComponentTemplate, opts Options) error {
+	explanation, err := docs.Explain(tmpl, opts.Explain)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.OutputPath != "" {
+		f, err := os.Create(opts.OutputPath)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	header := color.New(color.Bold, color.FgCyan).SprintFunc()
+	label := color.New(color.Bold).SprintFunc()
+	position := color.New(color.Faint).SprintFunc()
+
+	fmt.Fprintf(w, "%s %s %s\n", header(tmpl.Package), header(tmpl.Name), header(explanation.Path))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, label("Value:"))
+	fmt.Fprintln(w, indent(explanation.Value, "  "))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, label("Conjuncts:"))
+	for _, c := range explanation.Conjuncts {
+		pos := "<unknown position>"
+		if c.Pos.IsValid() {
+			pos = c.Pos.String()
+		}
+		fmt.Fprintf(w, "  %s\n", position(pos))
+		fmt.Fprintln(w, indent(c.Expr, "    "))
+	}
+	return nil
+}
+
+// indent prefixes every line of s with prefix.
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(strings.TrimRight(s, "\n"), "\n", "\n"+prefix)
+}
+
 func runMarkdownMulti(templates []*model.ComponentTemplate, opts Options) error {
 	var w io.Writer = os.Stdout
 	if opts.OutputPath != "" {
@@ -219,16 +336,31 @@ func runMarkdown(tmpl *model.ComponentTemplate, opts Options, w io.Writer) error
 		fmt.Fprintln(w)
 	}
 
+	if opts.Path != "" {
+		fields, err := tmpl.ConfigSchemaAt(opts.Path, schema.WithExpand(opts.Expand), schema.WithMaxDepth(opts.Depth))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "## Config (`%s`)\n", opts.Path)
+		fmt.Fprintln(w)
+		schema.FormatSchemaMarkdown(w, fields, 0)
+		return nil
+	}
+
 	// Print config schema
-	fields := tmpl.ConfigSchema(schema.WithExpand(opts.Expand))
+	fields := tmpl.ConfigSchema(schema.WithExpand(opts.Expand), schema.WithMaxDepth(opts.Depth))
 	if len(fields) > 0 {
 		fmt.Fprintln(w, "## Config")
 		fmt.Fprintln(w)
 		schema.FormatSchemaMarkdown(w, fields, 0)
 	}
 
+	if opts.Example != "" {
+		writeMarkdownExample(w, tmpl, opts.Example)
+	}
+
 	// Print declarations
-	declarations := tmpl.Declarations(schema.WithExpand(opts.Expand))
+	declarations := tmpl.Declarations(schema.WithExpand(opts.Expand), schema.WithMaxDepth(opts.Depth))
 	if len(declarations) > 0 {
 		schema.FormatDeclarationsMarkdown(w, declarations, 0)
 	}
@@ -236,6 +368,32 @@ func runMarkdown(tmpl *model.ComponentTemplate, opts Options, w io.Writer) error
 	return nil
 }
 
+// writeMarkdownExample writes a fenced block with an example config for tmpl
+// in format, cue or yaml: defaults filled in and placeholders for the rest.
+func writeMarkdownExample(w io.Writer, tmpl *model.ComponentTemplate, format string) {
+	// The example is always fully expanded, whatever --expand and --depth
+	// say, so that it's complete.
+	fields := tmpl.ConfigSchema(schema.WithExpand(true))
+	if len(fields) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "## Example")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "```%s\n", format)
+	switch format {
+	case "cue":
+		fmt.Fprintln(w, "config: {")
+		schema.FormatExampleCUE(w, fields, 1)
+		fmt.Fprintln(w, "}")
+	case "yaml":
+		fmt.Fprintln(w, "config:")
+		schema.FormatExampleYAML(w, fields, 1)
+	}
+	fmt.Fprintln(w, "```")
+}
+
 func runMarkdownDirectory(templates []*model.ComponentTemplate, opts Options, generateSummary bool) error {
 	// Create output directory
 	if err := os.MkdirAll(opts.OutputPath, 0755); err != nil {
@@ -251,7 +409,7 @@ func runMarkdownDirectory(templates []*model.ComponentTemplate, opts Options, ge
 	var groupOrder []string
 
 	for _, tmpl := range templates {
-		shorthand := shorthandName(tmpl.Package)
+		shorthand := model.PackageShorthand(tmpl.Package)
 		if groups[shorthand] == nil {
 			groups[shorthand] = &pkgGroup{
 				shorthand: shorthand,
@@ -320,18 +478,6 @@ func runMarkdownDirectory(templates []*model.ComponentTemplate, opts Options, ge
 	return nil
 }
 
-func shorthandName(pkg string) string {
-	// Strip @vN suffix if present
-	if idx := strings.LastIndex(pkg, "@"); idx != -1 {
-		pkg = pkg[:idx]
-	}
-	// Get last path segment
-	if idx := strings.LastIndex(pkg, "/"); idx != -1 {
-		return pkg[idx+1:]
-	}
-	return pkg
-}
-
 func printConcreteField(w io.Writer, v cue.Value, path string, labelFn, valueFn func(a ...interface{}) string) {
 	field := v.LookupPath(cue.ParsePath(path))
 	if field.Err() != nil {
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+
+package example
+
+import (
+	"io"
+	"log/slog"
+)
+
+type Options struct {
+	BundlePath string
+	Reference  string
+	// Values generates an example for the bundle's values instead of a
+	// component template's config; Reference is ignored.
+	Values       bool
+	OnlyRequired bool
+	// CaseSensitive matches definition and package names in the reference
+	// in the same case only.
+	CaseSensitive bool
+	Format        string
+	OutputPath    string
+	CacheDir      string
+	Offline       bool
+	Logger        *slog.Logger
+	Registries    map[string]string
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		Registries: make(map[string]string),
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+
+package example
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"go-valkyrie.com/odin/pkg/model"
+	"go-valkyrie.com/odin/pkg/schema"
+)
+
+func (o *Options) Run(ctx context.Context) error {
+	return run(ctx, *o)
+}
+
+func run(ctx context.Context, opts Options) error {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	}
+
+	b, err := model.LoadBundle(
+		opts.BundlePath,
+		model.WithLogger(logger),
+		model.WithRegistries(opts.Registries),
+		model.WithCacheDir(opts.CacheDir),
+		model.WithOffline(opts.Offline),
+	)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	var (
+		fields []*schema.SchemaField
+		label  string
+		title  string
+	)
+	if opts.Values {
+		fields = b.ValuesSchema()
+		label = "values"
+		title = fmt.Sprintf("Example values for %s", b.Name())
+	} else {
+		tmpl, err := b.FindComponentTemplate(ctx, opts.Reference, model.WithCaseSensitive(opts.CaseSensitive))
+		if err != nil {
+			return err
+		}
+		fields = tmpl.ConfigSchema(schema.WithExpand(true))
+		label = "config"
+		title = fmt.Sprintf("Example config for %s %s", tmpl.Package, tmpl.Name)
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.OutputPath != "" {
+		f, err := os.Create(opts.OutputPath)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	exampleOpts := []schema.ExampleOption{schema.WithOnlyRequired(opts.OnlyRequired)}
+
+	switch opts.Format {
+	case "cue":
+		fmt.Fprintf(w, "// %s\n", title)
+		fmt.Fprintf(w, "%s: {\n", label)
+		schema.FormatExampleCUE(w, fields, 1, exampleOpts...)
+		fmt.Fprintln(w, "}")
+	case "yaml":
+		fmt.Fprintf(w, "# %s\n", title)
+		fmt.Fprintf(w, "%s:\n", label)
+		schema.FormatExampleYAML(w, fields, 1, exampleOpts...)
+	default:
+		return fmt.Errorf("unsupported output format: %q (supported: cue, yaml)", opts.Format)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+
+package fetch
+
+import (
+	"io"
+	"log/slog"
+)
+
+type Options struct {
+	BundlePath string
+	// Output is where the fetch report is written; defaults to stdout.
+	Output     io.Writer
+	CacheDir   string
+	Logger     *slog.Logger
+	Registries map[string]string
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		Registries: make(map[string]string),
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+func (o *Options) Run(ctx context.Context) error {
+	return run(ctx, *o)
+}
+
+func run(ctx context.Context, opts Options) error {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	}
+	out := opts.Output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	results, err := model.FetchDependencies(ctx,
+		opts.BundlePath,
+		model.WithLogger(logger),
+		model.WithRegistries(opts.Registries),
+		model.WithCacheDir(opts.CacheDir),
+	)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "MODULE\tVERSION\tSTATUS")
+	failed := 0
+	for _, result := range results {
+		state := "fetched"
+		switch {
+		case result.Err != nil:
+			state = fmt.Sprintf("error: %v", result.Err)
+			failed++
+		case result.Cached:
+			state = "cached"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Path, result.Version, state)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to fetch %d of %d modules", failed, len(results))
+	}
+	return nil
+}
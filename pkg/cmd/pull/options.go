@@ -3,6 +3,7 @@
 package pull
 
 import (
+	"io"
 	"log/slog"
 )
 
@@ -11,9 +12,27 @@ type Options struct {
 	// Reference is the OCI reference (e.g., ghcr.io/org/app:tag)
 	Reference string
 
+	// DefaultRegistry is the registry for a Reference that doesn't name one
+	DefaultRegistry string
+
+	// Mirrors map registry hosts to the mirrors to pull from instead; see
+	// oci.MirrorReference
+	Mirrors map[string]string
+
 	// OutputDir is the directory to extract the bundle to
 	OutputDir string
 
+	// Strict fails the pull if the artifact isn't an odin bundle
+	Strict bool
+
+	// Concurrency is how many blobs are copied at once; see
+	// oci.WithConcurrency. It must be positive.
+	Concurrency int
+
+	// Output receives the pulled reference and output directory, defaulting
+	// to os.Stdout
+	Output io.Writer
+
 	// Logger for output
 	Logger *slog.Logger
 }
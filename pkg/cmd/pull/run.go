@@ -5,6 +5,8 @@ package pull
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 
 	"go-valkyrie.com/odin/pkg/oci"
@@ -12,8 +14,12 @@ import (
 
 // Run executes the pull command
 func Run(ctx context.Context, opts Options) error {
+	if opts.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", opts.Concurrency)
+	}
+
 	// Parse OCI reference
-	ref, err := oci.ParseReference(opts.Reference)
+	ref, err := oci.ParseReferenceWithDefault(opts.Reference, opts.DefaultRegistry)
 	if err != nil {
 		return fmt.Errorf("invalid reference: %w", err)
 	}
@@ -31,10 +37,24 @@ func Run(ctx context.Context, opts Options) error {
 	}
 
 	// Pull bundle
-	if err := oci.Pull(ctx, ref, outputDir, opts.Logger); err != nil {
+	desc, err := oci.Pull(ctx, ref, outputDir, opts.Strict, opts.Logger, oci.WithMirrors(opts.Mirrors), oci.WithConcurrency(opts.Concurrency))
+	if err != nil {
 		return fmt.Errorf("failed to pull bundle: %w", err)
 	}
 
 	opts.Logger.Info("bundle extracted", "directory", outputDir)
-	return nil
+	return writePulled(opts.Output, ref, desc.Digest.String(), outputDir)
+}
+
+// writePulled writes the reference pinned to the digest pulled and the
+// directory the bundle was extracted to.
+func writePulled(w io.Writer, ref *oci.Reference, digest, outputDir string) error {
+	if w == nil {
+		w = os.Stdout
+	}
+	if _, err := fmt.Fprintf(w, "pulled: %s\n", ref.Pinned(digest)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "output: %s\n", outputDir)
+	return err
 }
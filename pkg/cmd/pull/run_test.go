@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"bytes"
+	"testing"
+
+	"go-valkyrie.com/odin/pkg/oci"
+)
+
+func TestWritePulled(t *testing.T) {
+	ref, err := oci.ParseReference("ghcr.io/org/app:v1")
+	if err != nil {
+		t.Fatalf("ParseReference() error = %v", err)
+	}
+	digest := "sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	var buf bytes.Buffer
+	if err := writePulled(&buf, ref, digest, "/tmp/app-v1"); err != nil {
+		t.Fatalf("writePulled() error = %v", err)
+	}
+	want := "pulled: ghcr.io/org/app@" + digest + "\noutput: /tmp/app-v1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writePulled() =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -3,6 +3,7 @@
 package push
 
 import (
+	"io"
 	"log/slog"
 )
 
@@ -11,12 +12,33 @@ type Options struct {
 	// Reference is the OCI reference (e.g., ghcr.io/org/app:tag)
 	Reference string
 
+	// DefaultRegistry is the registry for a Reference that doesn't name one
+	DefaultRegistry string
+
 	// BundlePath is the path to the bundle to push
 	BundlePath string
 
 	// Annotations are custom OCI manifest annotations (e.g., org.opencontainers.image.source)
 	Annotations map[string]string
 
+	// Concurrency is how many blobs are copied at once; see
+	// oci.WithConcurrency. It must be positive.
+	Concurrency int
+
+	// Output receives the pushed references, defaulting to os.Stdout
+	Output io.Writer
+
 	// Logger for output
 	Logger *slog.Logger
+
+	// Rendered pushes the bundle's rendered manifests instead of its source
+	Rendered bool
+
+	// CacheDir, Registries, ValuesLocations and Namespace configure rendering
+	// when Rendered is set
+	CacheDir        string
+	Offline         bool
+	Registries      map[string]string
+	ValuesLocations []string
+	Namespace       string
 }
@@ -5,22 +5,81 @@ package push
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 
+	"go-valkyrie.com/odin/pkg/cmd/template"
 	"go-valkyrie.com/odin/pkg/oci"
 )
 
 // Run executes the push command
 func Run(ctx context.Context, opts Options) error {
+	if opts.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", opts.Concurrency)
+	}
+
 	// Parse OCI reference
-	ref, err := oci.ParseReference(opts.Reference)
+	ref, err := oci.ParseReferenceWithDefault(opts.Reference, opts.DefaultRegistry)
 	if err != nil {
 		return fmt.Errorf("invalid reference: %w", err)
 	}
 
+	if opts.Rendered {
+		return pushRendered(ctx, ref, opts)
+	}
+
 	// Push bundle
-	if err := oci.Push(ctx, ref, opts.BundlePath, opts.Annotations, opts.Logger); err != nil {
+	desc, err := oci.Push(ctx, ref, opts.BundlePath, opts.Annotations, opts.Logger, oci.WithConcurrency(opts.Concurrency))
+	if err != nil {
 		return fmt.Errorf("failed to push bundle: %w", err)
 	}
 
+	return writePushed(opts.Output, ref, desc.Digest.String())
+}
+
+// writePushed writes the reference pinned to the digest pushed, which
+// dependents should use, and the tag it was pushed to.
+func writePushed(w io.Writer, ref *oci.Reference, digest string) error {
+	if w == nil {
+		w = os.Stdout
+	}
+	if _, err := fmt.Fprintf(w, "pushed: %s\n", ref.Pinned(digest)); err != nil {
+		return err
+	}
+	if tagged := ref.Tagged(); tagged != nil {
+		if _, err := fmt.Fprintf(w, "tag:    %s\n", tagged); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// pushRendered renders the bundle into a temporary directory, one YAML file
+// per resource, and pushes that directory as a manifests artifact.
+func pushRendered(ctx context.Context, ref *oci.Reference, opts Options) error {
+	dir, err := os.MkdirTemp("", "odin-rendered-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	renderOpts := template.Options{
+		BundlePath:      opts.BundlePath,
+		CacheDir:        opts.CacheDir,
+		Offline:         opts.Offline,
+		Logger:          opts.Logger,
+		Registries:      opts.Registries,
+		ValuesLocations: opts.ValuesLocations,
+		Namespace:       opts.Namespace,
+	}
+	if err := renderOpts.RenderToDir(ctx, dir); err != nil {
+		return fmt.Errorf("failed to render bundle: %w", err)
+	}
+
+	desc, err := oci.PushManifests(ctx, ref, dir, opts.Annotations, opts.Logger, oci.WithConcurrency(opts.Concurrency))
+	if err != nil {
+		return fmt.Errorf("failed to push manifests: %w", err)
+	}
+
+	return writePushed(opts.Output, ref, desc.Digest.String())
+}
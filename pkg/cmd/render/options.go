@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+
+package render
+
+import (
+	"io"
+	"log/slog"
+)
+
+type Options struct {
+	BundlePath string
+	Reference  string
+	// Name is the name the template is instantiated with, its metadata.name.
+	// It defaults to the template's name in lower case, e.g. "webapp" for
+	// #WebApp.
+	Name string
+	// ValuesLocations are the files setting the template's config, under a
+	// config field, optionally prefixed with a format as for odin template.
+	ValuesLocations []string
+	// CaseSensitive matches definition and package names in the reference
+	// in the same case only.
+	CaseSensitive bool
+	// Output is where the rendered resources are written; defaults to
+	// stdout.
+	Output     io.Writer
+	CacheDir   string
+	Offline    bool
+	Logger     *slog.Logger
+	Registries map[string]string
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		Registries: make(map[string]string),
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+func (o *Options) Run(ctx context.Context) error {
+	return run(ctx, *o)
+}
+
+func run(ctx context.Context, opts Options) error {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	}
+	out := opts.Output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	b, err := model.LoadBundle(
+		opts.BundlePath,
+		model.WithLogger(logger),
+		model.WithRegistries(opts.Registries),
+		model.WithCacheDir(opts.CacheDir),
+		model.WithOffline(opts.Offline),
+	)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	tmpl, err := b.FindComponentTemplate(ctx, opts.Reference, model.WithCaseSensitive(opts.CaseSensitive))
+	if err != nil {
+		return err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = strings.ToLower(strings.TrimPrefix(tmpl.Name, "#"))
+	}
+	logger.Debug("rendering component template", "pkg", tmpl.Package, "def", tmpl.Name, "name", name)
+
+	resources, err := b.RenderTemplate(tmpl, name, opts.ValuesLocations)
+	if err != nil {
+		return err
+	}
+	return writeYAML(out, resources)
+}
+
+// writeYAML writes the resources as a YAML stream, each headed by a comment
+// naming it, as odin template does.
+func writeYAML(w io.Writer, resources []*model.Resource) error {
+	for i, resource := range resources {
+		if i > 0 {
+			fmt.Fprintf(w, "---\n")
+		}
+
+		data, err := resource.ToYAML()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "# %v.%v\n", resource.Owner().Selector(), resource.Selector())
+		fmt.Fprint(w, string(data))
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"go-valkyrie.com/odin/pkg/model"
+	"go-valkyrie.com/odin/pkg/schema"
+)
+
+// wantsHTML reports whether r asks for an HTML page rather than the
+// endpoint's usual format, either with ?format=html or, as browsers do, with
+// an Accept header listing text/html. An explicit format wins over Accept.
+func wantsHTML(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format == "html"
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// indexEntry is a component listed on the HTML index.
+type indexEntry struct {
+	Reference string
+	Package   string
+	Name      string
+	DocsURL   string
+}
+
+// docsPage is the HTML documentation of a component, with the same content
+// as its markdown.
+type docsPage struct {
+	Package    string
+	Name       string
+	Doc        []string
+	APIVersion string
+	Kind       string
+	Fields     []*schema.SchemaField
+}
+
+func newIndexEntry(tmpl *model.ComponentTemplate) indexEntry {
+	// The fully qualified reference always resolves to this template.
+	ref := tmpl.Package + ":" + tmpl.Name
+	return indexEntry{
+		Reference: tmpl.ShortReference(),
+		Package:   tmpl.Package,
+		Name:      tmpl.Name,
+		DocsURL:   "/components/" + url.PathEscape(ref) + "/docs?format=html",
+	}
+}
+
+// newDocsPage collects the documentation of tmpl. The caller must hold the
+// lock guarding the template's value.
+func newDocsPage(tmpl *model.ComponentTemplate, opts ...schema.WalkOption) docsPage {
+	page := docsPage{
+		Package: tmpl.Package,
+		Name:    tmpl.Name,
+		Fields:  tmpl.ConfigSchema(opts...),
+	}
+	for _, cg := range tmpl.Value.Doc() {
+		if text := strings.TrimSpace(cg.Text()); text != "" {
+			page.Doc = append(page.Doc, text)
+		}
+	}
+	if s, err := tmpl.Value.LookupPath(cue.ParsePath("apiVersion")).String(); err == nil {
+		page.APIVersion = s
+	}
+	if s, err := tmpl.Value.LookupPath(cue.ParsePath("kind")).String(); err == nil {
+		page.Kind = s
+	}
+	return page
+}
+
+var pageTemplates = template.Must(template.New("").Parse(`
+{{- define "head" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.}}</title>
+</head>
+<body>
+{{- end -}}
+
+{{- define "index" -}}
+{{template "head" "Components"}}
+<h1>Components</h1>
+<ul>
+{{- range .}}
+<li><a href="{{.DocsURL}}">{{.Reference}}</a> <code>{{.Package}} {{.Name}}</code></li>
+{{- end}}
+</ul>
+</body>
+</html>
+{{end -}}
+
+{{- define "fields" -}}
+<ul>
+{{- range .}}
+<li>
+{{- with .Doc}}<p>{{.}}</p>{{end -}}
+<strong>{{.Name}}</strong>
+{{- if not .IsPattern}}{{if .Required}} (required){{else if .Optional}} (optional){{end}}{{end -}}
+{{- if .Children}}{{template "fields" .Children}}
+{{- else}}: <code>{{.Type}}</code>
+{{- with .Default}} (default: <code>{{.}}</code>){{end -}}
+{{- with .Examples}} (e.g. {{range $i, $e := .}}{{if $i}}, {{end}}<code>{{$e}}</code>{{end}}){{end -}}
+{{- if .Truncated}} (truncated){{end -}}
+{{- end -}}
+</li>
+{{- end}}
+</ul>
+{{- end -}}
+
+{{- define "docs" -}}
+{{template "head" .Name}}
+<h1>{{.Package}} {{.Name}}</h1>
+{{- range .Doc}}
+<blockquote>{{.}}</blockquote>
+{{- end}}
+{{- if or .APIVersion .Kind}}
+<table>
+<tr><th>Field</th><th>Value</th></tr>
+{{- with .APIVersion}}
+<tr><td>apiVersion</td><td><code>{{.}}</code></td></tr>
+{{- end}}
+{{- with .Kind}}
+<tr><td>kind</td><td><code>{{.}}</code></td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Fields}}
+<h2>Config</h2>
+{{template "fields" .Fields}}
+{{- end}}
+</body>
+</html>
+{{end -}}
+`))
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"io"
+	"log/slog"
+)
+
+type Options struct {
+	BundlePath string
+	Address    string
+	Expand     bool
+	CacheDir   string
+	Offline    bool
+	Logger     *slog.Logger
+	Registries map[string]string
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		Address:    "localhost:8080",
+		Registries: make(map[string]string),
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+	}
+}
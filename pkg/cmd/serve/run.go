@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go-valkyrie.com/odin/pkg/cmd/components"
+	cmddocs "go-valkyrie.com/odin/pkg/cmd/docs"
+	"go-valkyrie.com/odin/pkg/docs"
+	"go-valkyrie.com/odin/pkg/model"
+	"go-valkyrie.com/odin/pkg/schema"
+)
+
+// shutdownTimeout bounds how long in-flight requests get to finish once the
+// server is asked to stop.
+const shutdownTimeout = 5 * time.Second
+
+// readHeaderTimeout bounds how long a client may take to send its request
+// headers, so slow clients can't hold connections open indefinitely.
+const readHeaderTimeout = 10 * time.Second
+
+func (o *Options) Run(ctx context.Context) error {
+	return run(ctx, *o)
+}
+
+func run(ctx context.Context, opts Options) error {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	}
+
+	docsOpts := cmddocs.Options{
+		BundlePath: opts.BundlePath,
+		Expand:     opts.Expand,
+		Depth:      -1,
+		CacheDir:   opts.CacheDir,
+		Offline:    opts.Offline,
+		Logger:     logger,
+		Registries: opts.Registries,
+	}
+
+	// Discovery fetches and evaluates every dependency, so it's done once up
+	// front and the results are served from memory.
+	templates, err := docsOpts.Templates(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Debug("discovered component templates", "count", len(templates))
+
+	listener, err := net.Listen("tcp", opts.Address)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", opts.Address, err)
+	}
+
+	server := &http.Server{
+		Handler:           newHandler(templates, docsOpts, logger),
+		ReadHeaderTimeout: readHeaderTimeout,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+	logger.Info("serving component docs", "address", "http://"+listener.Addr().String())
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+type handler struct {
+	// mu serializes access to the templates' CUE values, which aren't safe
+	// for concurrent use.
+	mu        sync.Mutex
+	templates []*model.ComponentTemplate
+	docsOpts  cmddocs.Options
+	logger    *slog.Logger
+}
+
+// newHandler returns the API handler:
+//
+//	GET /components              component inventory
+//	GET /components/{ref}/schema config schema of a component
+//	GET /components/{ref}/docs   markdown documentation of a component
+//
+// The inventory and docs are served as HTML pages instead when asked for
+// with ?format=html or an Accept header listing text/html, so they can be
+// browsed. References use the same formats as odin docs; references
+// containing '/' must be path-escaped.
+func newHandler(templates []*model.ComponentTemplate, docsOpts cmddocs.Options, logger *slog.Logger) http.Handler {
+	h := &handler{templates: templates, docsOpts: docsOpts, logger: logger}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /components", h.listComponents)
+	mux.HandleFunc("GET /components/{ref}/schema", h.componentSchema)
+	mux.HandleFunc("GET /components/{ref}/docs", h.componentDocs)
+	return mux
+}
+
+func (h *handler) listComponents(w http.ResponseWriter, r *http.Request) {
+	if wantsHTML(r) {
+		entries := make([]indexEntry, 0, len(h.templates))
+		for _, tmpl := range h.templates {
+			entries = append(entries, newIndexEntry(tmpl))
+		}
+		h.writeHTML(w, "index", entries)
+		return
+	}
+	inventory := make([]components.ComponentJSON, 0, len(h.templates))
+	for _, tmpl := range h.templates {
+		inventory = append(inventory, components.NewComponentJSON(tmpl))
+	}
+	h.writeJSON(w, inventory)
+}
+
+func (h *handler) componentSchema(w http.ResponseWriter, r *http.Request) {
+	tmpl, ok := h.resolve(w, r)
+	if !ok {
+		return
+	}
+	h.mu.Lock()
+	fields := tmpl.ConfigSchema(schema.WithExpand(h.docsOpts.Expand))
+	h.mu.Unlock()
+	if fields == nil {
+		fields = []*schema.SchemaField{}
+	}
+	h.writeJSON(w, fields)
+}
+
+func (h *handler) componentDocs(w http.ResponseWriter, r *http.Request) {
+	tmpl, ok := h.resolve(w, r)
+	if !ok {
+		return
+	}
+	if wantsHTML(r) {
+		h.mu.Lock()
+		page := newDocsPage(tmpl, schema.WithExpand(h.docsOpts.Expand), schema.WithMaxDepth(h.docsOpts.Depth))
+		h.mu.Unlock()
+		h.writeHTML(w, "docs", page)
+		return
+	}
+	var buf bytes.Buffer
+	h.mu.Lock()
+	err := h.docsOpts.WriteMarkdown(&buf, tmpl)
+	h.mu.Unlock()
+	if err != nil {
+		h.logger.Error("failed to render docs", "ref", r.PathValue("ref"), "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// resolve looks up the template named by the request's ref, writing a 404
+// when it can't be resolved.
+func (h *handler) resolve(w http.ResponseWriter, r *http.Request) (*model.ComponentTemplate, bool) {
+	h.mu.Lock()
+	tmpl, err := docs.ResolveReference(r.PathValue("ref"), h.templates)
+	h.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return nil, false
+	}
+	return tmpl, true
+}
+
+func (h *handler) writeHTML(w http.ResponseWriter, name string, data any) {
+	var buf bytes.Buffer
+	if err := pageTemplates.ExecuteTemplate(&buf, name, data); err != nil {
+		h.logger.Error("failed to render page", "page", name, "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
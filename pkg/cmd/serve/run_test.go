@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	cmddocs "go-valkyrie.com/odin/pkg/cmd/docs"
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+const testSchema = `
+// WebApp runs a <web> application.
+#WebApp: {
+	apiVersion: "example.com/v1"
+	kind:       "WebApp"
+	config: {
+		// Image to run.
+		image: string
+		replicas?: int | *1
+	}
+}
+`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	v := cuecontext.New().CompileString(testSchema, cue.Filename("webapp.cue"))
+	if err := v.Err(); err != nil {
+		t.Fatal(err)
+	}
+	templates := []*model.ComponentTemplate{{
+		Package: "example.com/platform/workload@v0",
+		Name:    "#WebApp",
+		Value:   v.LookupPath(cue.ParsePath("#WebApp")),
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	srv := httptest.NewServer(newHandler(templates, cmddocs.Options{Depth: -1}, logger))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, srv *httptest.Server, path, accept string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func TestHandlerIndex(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, body := get(t, srv, "/components", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var inventory []map[string]any
+	if err := json.Unmarshal([]byte(body), &inventory); err != nil {
+		t.Fatalf("decoding inventory: %v\n%s", err, body)
+	}
+	if len(inventory) != 1 {
+		t.Errorf("inventory has %d components, want 1", len(inventory))
+	}
+
+	docsURL := "/components/" + url.PathEscape("example.com/platform/workload@v0:#WebApp") + "/docs?format=html"
+	for _, tt := range []struct{ name, path, accept string }{
+		{"format", "/components?format=html", ""},
+		{"accept", "/components", "text/html,application/xhtml+xml;q=0.9"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := get(t, srv, tt.path, tt.accept)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want 200", resp.StatusCode)
+			}
+			if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+				t.Errorf("Content-Type = %q, want text/html", ct)
+			}
+			if !strings.Contains(body, "workload.WebApp") {
+				t.Errorf("index does not list workload.WebApp:\n%s", body)
+			}
+			if !strings.Contains(body, `href="`+docsURL+`"`) {
+				t.Errorf("index does not link to %s:\n%s", docsURL, body)
+			}
+		})
+	}
+
+	// The links on the index resolve to the component's page.
+	resp, _ = get(t, srv, docsURL, "")
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET %s status = %d, want 200", docsURL, resp.StatusCode)
+	}
+}
+
+func TestHandlerComponentDocs(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, body := get(t, srv, "/components/WebApp/docs", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/markdown") {
+		t.Errorf("Content-Type = %q, want text/markdown", ct)
+	}
+	if !strings.Contains(body, "image") {
+		t.Errorf("markdown does not document image:\n%s", body)
+	}
+
+	for _, tt := range []struct{ name, path, accept string }{
+		{"format", "/components/WebApp/docs?format=html", ""},
+		{"accept", "/components/WebApp/docs", "text/html"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := get(t, srv, tt.path, tt.accept)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want 200", resp.StatusCode)
+			}
+			if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+				t.Errorf("Content-Type = %q, want text/html", ct)
+			}
+			for _, want := range []string{
+				"<h1>example.com/platform/workload@v0 #WebApp</h1>",
+				"WebApp runs a &lt;web&gt; application.",
+				"<code>example.com/v1</code>",
+				"<p>Image to run.</p><strong>image</strong>: <code>string</code>",
+				"<strong>replicas</strong> (optional): <code>int</code> (default: <code>1</code>)",
+			} {
+				if !strings.Contains(body, want) {
+					t.Errorf("page does not contain %q:\n%s", want, body)
+				}
+			}
+		})
+	}
+
+	// An explicit format wins over the Accept header.
+	resp, _ = get(t, srv, "/components/WebApp/docs?format=markdown", "text/html")
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/markdown") {
+		t.Errorf("Content-Type = %q, want text/markdown", ct)
+	}
+
+	resp, body = get(t, srv, "/components/WebApp/schema", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("schema status = %d, want 200", resp.StatusCode)
+	}
+	if !strings.Contains(body, `"image"`) {
+		t.Errorf("schema does not contain image:\n%s", body)
+	}
+}
+
+func TestHandlerNotFound(t *testing.T) {
+	srv := newTestServer(t)
+
+	for _, path := range []string{
+		"/components/Missing/docs",
+		"/components/Missing/docs?format=html",
+		"/components/Missing/schema",
+		"/nowhere",
+	} {
+		resp, _ := get(t, srv, path, "")
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("GET %s status = %d, want 404", path, resp.StatusCode)
+		}
+	}
+}
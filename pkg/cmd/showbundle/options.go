@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: MIT
+
+package showbundle
+
+import (
+	"log/slog"
+)
+
+// Options contains the configuration for showing bundle metadata.
+type Options struct {
+	// BundlePath is the path to the bundle.
+	BundlePath string
+
+	// Format is the output format (text, json).
+	Format string
+
+	// CacheDir is the cache directory for bundle loading.
+	CacheDir string
+
+	// Offline loads the bundle using only modules in the cache.
+	Offline bool
+
+	// Logger is the logger to use.
+	Logger *slog.Logger
+
+	// Registries maps module prefixes to OCI registries.
+	Registries map[string]string
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+
+package showbundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+type dependencyJSON struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+}
+
+type bundleJSON struct {
+	Name            string           `json:"name"`
+	Module          string           `json:"module"`
+	LanguageVersion string           `json:"languageVersion,omitempty"`
+	Dependencies    []dependencyJSON `json:"dependencies"`
+	Components      int              `json:"components"`
+}
+
+// Run executes the show bundle command.
+func (o *Options) Run(ctx context.Context) error {
+	b, err := model.LoadBundle(
+		o.BundlePath,
+		model.WithLogger(o.Logger),
+		model.WithRegistries(o.Registries),
+		model.WithCacheDir(o.CacheDir),
+		model.WithOffline(o.Offline),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load bundle: %w", err)
+	}
+	defer b.Close()
+
+	moduleFile, err := b.ModuleFile()
+	if err != nil {
+		return err
+	}
+
+	info := bundleJSON{
+		Name:         b.Name(),
+		Module:       moduleFile.Module,
+		Dependencies: []dependencyJSON{},
+	}
+	if info.Name == "<error>" {
+		info.Name = o.BundlePath
+	}
+	if moduleFile.Language != nil {
+		info.LanguageVersion = moduleFile.Language.Version
+	}
+	deps, err := b.Dependencies()
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		info.Dependencies = append(info.Dependencies, dependencyJSON{Module: dep.Path, Version: dep.Version})
+	}
+	for range b.Components() {
+		info.Components++
+	}
+
+	switch strings.ToLower(o.Format) {
+	case "text":
+		return formatText(os.Stdout, info)
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	default:
+		return fmt.Errorf("unsupported format: %s (supported: text, json)", o.Format)
+	}
+}
+
+func formatText(w io.Writer, info bundleJSON) error {
+	bold := color.New(color.Bold)
+	fmt.Fprintf(w, "Bundle: ")
+	bold.Fprintf(w, "%s\n\n", info.Name)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "Module:\t%s\n", info.Module)
+	if info.LanguageVersion != "" {
+		fmt.Fprintf(tw, "Language version:\t%s\n", info.LanguageVersion)
+	}
+	fmt.Fprintf(tw, "Components:\t%d\n", info.Components)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nDependencies:\n")
+	if len(info.Dependencies) == 0 {
+		fmt.Fprintf(w, "  (none)\n")
+		return nil
+	}
+	tw = tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	for _, dep := range info.Dependencies {
+		fmt.Fprintf(tw, "  %s\t%s\n", dep.Module, dep.Version)
+	}
+	return tw.Flush()
+}
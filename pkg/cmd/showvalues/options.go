@@ -14,15 +14,27 @@ type Options struct {
 	// Format is the output format (text, cue, markdown).
 	Format string
 
+	// Depth limits how many levels of nested fields are shown in text and
+	// markdown output; 0 shows only top-level fields and a negative depth is
+	// unlimited.
+	Depth int
+
 	// OutputPath is the file to write output to (empty for stdout).
 	OutputPath string
 
 	// CacheDir is the cache directory for bundle loading.
 	CacheDir string
 
+	// Offline loads the bundle using only modules in the cache.
+	Offline bool
+
 	// Logger is the logger to use.
 	Logger *slog.Logger
 
 	// Registries maps module prefixes to OCI registries.
 	Registries map[string]string
+
+	// ValuesLocations are values files to apply. When set, each field is
+	// marked with whether its value is a default, set or overridden.
+	ValuesLocations []string
 }
@@ -19,15 +19,20 @@ import (
 // Run executes the show values command.
 func (o *Options) Run(ctx context.Context) error {
 	// Load the bundle
-	b, err := model.LoadBundle(
-		o.BundlePath,
+	modelOpts := []model.Option{
 		model.WithLogger(o.Logger),
 		model.WithRegistries(o.Registries),
 		model.WithCacheDir(o.CacheDir),
-	)
+		model.WithOffline(o.Offline),
+	}
+	if len(o.ValuesLocations) > 0 {
+		modelOpts = append(modelOpts, model.WithValues(o.ValuesLocations...))
+	}
+	b, err := model.LoadBundle(o.BundlePath, modelOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to load bundle: %w", err)
 	}
+	defer b.Close()
 
 	// Extract values from bundle
 	valuesPath := cue.ParsePath("values")
@@ -77,7 +82,7 @@ func (o *Options) formatText(w io.Writer, b *model.Bundle, valuesValue cue.Value
 	bold.Fprintf(w, "%s\n\n", bundleName)
 
 	// Walk schema and format
-	fields := b.ValuesSchema()
+	fields := b.ValuesSchema(schema.WithMaxDepth(o.Depth))
 	schema.FormatSchema(w, fields, 0)
 
 	return nil
@@ -109,7 +114,7 @@ func (o *Options) formatMarkdown(w io.Writer, b *model.Bundle, valuesValue cue.V
 	fmt.Fprintf(w, "# Bundle Values: %s\n\n", bundleName)
 
 	// Walk schema and format as markdown
-	fields := b.ValuesSchema()
+	fields := b.ValuesSchema(schema.WithMaxDepth(o.Depth))
 	schema.FormatSchemaMarkdown(w, fields, 2)
 
 	return nil
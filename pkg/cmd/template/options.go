@@ -5,18 +5,54 @@ package template
 import (
 	"io"
 	"log/slog"
+
+	"go-valkyrie.com/odin/pkg/model"
 )
 
 type Options struct {
-	BundlePath      string
+	// BundlePath is the bundle's location; model.StdinLocation ("-") reads
+	// it from Input.
+	BundlePath string
+	// Input is read for a bundle at model.StdinLocation, defaulting to
+	// os.Stdin.
+	Input           io.Reader
 	CacheDir        string
+	Offline         bool
 	Logger          *slog.Logger
 	Registries      map[string]string
 	ValuesLocations []string
-	ValuesPath      string
-	ValuesFormat    string
-	Output          io.Writer
-	Namespace       string
+	// SetFiles set values to the contents of files, each given as
+	// "key=file"; see model.WithSetFiles.
+	SetFiles     []string
+	ValuesPath   string
+	ValuesFormat string
+	Output       io.Writer
+	// OutputPath, if set, is a file the rendered resources are written to
+	// instead of Output. Each render replaces its contents, and a failed
+	// render leaves it as it was.
+	OutputPath string
+	// Format is the output format, yaml (the default) or cue.
+	Format string
+	// YAMLExplicitStart starts every YAML document with "---", including the
+	// first, rather than only separating documents with it.
+	YAMLExplicitStart bool
+	Namespace         string
+	// ConcreteConfig fails before rendering if any component's config isn't
+	// concrete, naming the config fields left unset.
+	ConcreteConfig bool
+	// StampRevision annotates every resource with the git revision of the
+	// bundle.
+	StampRevision bool
+	// Order is the order resources are emitted in, OrderName (the default)
+	// or OrderApply.
+	Order string
+	// Selector keeps only the resources whose labels match every key=value
+	// given; see model.WithLabelSelector.
+	Selector []string
+	// KindOrder overrides model.DefaultApplyOrder for OrderApply.
+	KindOrder []string
+	// Timings, if set, records how long loading the bundle took.
+	Timings *model.Timings
 }
 
 func DefaultOptions() *Options {
@@ -24,5 +60,7 @@ func DefaultOptions() *Options {
 		Registries:      make(map[string]string),
 		Logger:          slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
 		ValuesLocations: []string{},
+		Order:           OrderName,
+		Format:          "yaml",
 	}
 }
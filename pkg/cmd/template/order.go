@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: MIT
+
+package template
+
+import "go-valkyrie.com/odin/pkg/model"
+
+const (
+	// OrderName sorts resources by component and resource name.
+	OrderName = model.OrderName
+	// OrderApply sorts resources by kind so they can be applied in one pass,
+	// with the name order as a tiebreak.
+	OrderApply = model.OrderApply
+)
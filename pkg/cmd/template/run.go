@@ -3,15 +3,15 @@
 package template
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
-	"slices"
+	"path/filepath"
+	"strings"
 
-	"cuelang.org/go/cue"
-	"cuelang.org/go/pkg/strings"
 	"go-valkyrie.com/odin/pkg/model"
 )
 
@@ -22,67 +22,84 @@ var (
 	}
 )
 
+// RevisionAnnotation is the annotation --stamp-revision sets to the bundle's
+// git revision.
+const RevisionAnnotation = model.RevisionAnnotation
+
 func (o *Options) Run(ctx context.Context) error {
 	return run(ctx, *o)
 }
 
-func run(ctx context.Context, opts Options) error {
-	logger := opts.Logger
-	if logger == nil {
-		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+// RenderToDir renders the bundle and writes each resource to its own YAML
+// file in dir, named <component>.<resource>.yaml.
+func (o *Options) RenderToDir(ctx context.Context, dir string) error {
+	resources, err := render(ctx, *o)
+	if err != nil {
+		return err
 	}
 
-	w := opts.Output
-	if w == nil {
-		w = io.Writer(os.Stdout)
-	}
+	for _, resource := range resources {
+		data, err := resource.ToYAML()
+		if err != nil {
+			return err
+		}
 
-	modelOpts := []model.Option{
-		model.WithLogger(logger),
-		model.WithRegistries(opts.Registries),
-		model.WithCacheDir(opts.CacheDir),
+		name := fmt.Sprintf("%v.%v.yaml", resource.Owner().Selector(), resource.Selector())
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return err
+		}
 	}
 
-	if opts.Namespace != "" {
-		modelOpts = append(modelOpts, model.WithNamespace(opts.Namespace))
-	}
+	return nil
+}
 
-	if len(opts.ValuesLocations) > 0 {
-		modelOpts = append(modelOpts, model.WithValues(opts.ValuesLocations))
+func run(ctx context.Context, opts Options) error {
+	w := opts.Output
+	if w == nil {
+		w = io.Writer(os.Stdout)
 	}
 
-	b, err := model.LoadBundle(opts.BundlePath, modelOpts...)
+	resources, err := render(ctx, opts)
 	if err != nil {
 		return err
 	}
 
-	if err := b.Error(); err != nil {
+	if opts.OutputPath == "" {
+		return write(w, resources, opts)
+	}
+	// Render fully before touching the file, so that it is rewritten rather
+	// than appended to and a failed render leaves it intact.
+	var buf bytes.Buffer
+	if err := write(&buf, resources, opts); err != nil {
 		return err
 	}
-
-	resources := make([]*model.Resource, 0)
-	for component := range b.Components() {
-		if err := component.ValidConfig(); err != nil {
-			return err
-		}
-		resources = slices.AppendSeq(resources, component.Resources())
+	if err := os.WriteFile(opts.OutputPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
 	}
+	return nil
+}
 
-	slices.SortFunc(resources, func(left, right *model.Resource) int {
-		lname := fmt.Sprintf("%s.%s", left.Owner().Selector(), left.Selector())
-		rname := fmt.Sprintf("%s.%s", right.Owner().Selector(), right.Selector())
-		return strings.Compare(lname, rname)
-	})
+// write writes the resources to w in the format opts asks for.
+func write(w io.Writer, resources []*model.Resource, opts Options) error {
+	switch strings.ToLower(opts.Format) {
+	case "", "yaml":
+		return writeYAML(w, resources, opts.YAMLExplicitStart)
+	case "cue":
+		return writeCUE(w, resources)
+	default:
+		return fmt.Errorf("unsupported format: %s (supported: yaml, cue)", opts.Format)
+	}
+}
 
+// writeYAML writes the resources as a multi-document YAML stream, each
+// preceded by a comment naming it. Documents are separated by "---", which
+// also starts the first document if explicitStart is set.
+func writeYAML(w io.Writer, resources []*model.Resource, explicitStart bool) error {
 	for i, resource := range resources {
-		if i > 0 {
+		if i > 0 || explicitStart {
 			fmt.Fprintf(w, "---\n")
 		}
 
-		if err := resource.Value().Validate(cue.Concrete(true)); err != nil {
-			return err
-		}
-
 		data, err := resource.ToYAML()
 		if err != nil {
 			return err
@@ -94,3 +111,73 @@ func run(ctx context.Context, opts Options) error {
 
 	return nil
 }
+
+// writeCUE writes the resources as a single CUE file, each resource nested
+// under its component and resource name.
+func writeCUE(w io.Writer, resources []*model.Resource) error {
+	for i, resource := range resources {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		data, err := resource.ToCUE()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "%v: %v: %s\n", resource.Owner().Selector(), resource.Selector(), data)
+	}
+
+	return nil
+}
+
+// render loads the bundle and renders its resources, sorted according to
+// opts.Order.
+func render(ctx context.Context, opts Options) ([]*model.Resource, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	}
+
+	modelOpts := []model.Option{
+		model.WithLogger(logger),
+		model.WithRegistries(opts.Registries),
+		model.WithCacheDir(opts.CacheDir),
+		model.WithOffline(opts.Offline),
+		model.WithTimings(opts.Timings),
+	}
+
+	if opts.Input != nil {
+		modelOpts = append(modelOpts, model.WithStdin(opts.Input))
+	}
+
+	if opts.Namespace != "" {
+		modelOpts = append(modelOpts, model.WithNamespace(opts.Namespace))
+	}
+
+	if len(opts.ValuesLocations) > 0 {
+		modelOpts = append(modelOpts, model.WithValues(opts.ValuesLocations...))
+	}
+
+	if len(opts.SetFiles) > 0 {
+		modelOpts = append(modelOpts, model.WithSetFiles(opts.SetFiles...))
+	}
+
+	selector, err := model.ParseLabelSelector(opts.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := model.LoadBundle(opts.BundlePath, modelOpts...)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	return b.Render(ctx,
+		model.WithLabelSelector(selector),
+		model.WithResourceOrder(opts.Order, opts.KindOrder),
+		model.WithRevisionStamp(opts.StampRevision),
+		model.WithConcreteConfig(opts.ConcreteConfig),
+	)
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+// watchDebounce is how long to wait after the last change before
+// re-rendering, so that editors writing several files don't trigger a render
+// for each one.
+const watchDebounce = 200 * time.Millisecond
+
+// Watch renders the bundle, then re-renders it whenever a .cue file in the
+// bundle, one of the values files or a file set with SetFiles changes, until
+// ctx is cancelled. Render errors are logged and watching continues. Each
+// render is appended to Output, or replaces the contents of OutputPath,
+// whose changes don't trigger a render. Only local bundles can be watched.
+func (o *Options) Watch(ctx context.Context) error {
+	opts := *o
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	}
+
+	if info, err := os.Stat(opts.BundlePath); err != nil || !info.IsDir() {
+		return fmt.Errorf("--watch is only supported for local bundle directories")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watchTree(watcher, opts.BundlePath); err != nil {
+		return err
+	}
+
+	var outputPath string
+	if opts.OutputPath != "" {
+		if outputPath, err = filepath.Abs(opts.OutputPath); err != nil {
+			return err
+		}
+	}
+
+	valuesFiles := make(map[string]bool, len(opts.ValuesLocations)+len(opts.SetFiles))
+	var watchedFiles []string
+	for _, location := range opts.ValuesLocations {
+		watchedFiles = append(watchedFiles, model.ValuesPath(location))
+	}
+	for _, assignment := range opts.SetFiles {
+		if _, file, ok := strings.Cut(assignment, "="); ok {
+			watchedFiles = append(watchedFiles, file)
+		}
+	}
+	for _, file := range watchedFiles {
+		path, err := filepath.Abs(file)
+		if err != nil {
+			return err
+		}
+		valuesFiles[path] = true
+		// Watch the directory rather than the file, so the watch survives
+		// editors that save by replacing the file.
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+	}
+
+	rerender := func() {
+		if err := run(ctx, opts); err != nil {
+			logger.Error("render failed", "err", err)
+			return
+		}
+		logger.Info("rendered bundle", "bundle", opts.BundlePath)
+	}
+
+	rerender()
+	logger.Info("watching for changes", "bundle", opts.BundlePath)
+
+	isChange := func(event fsnotify.Event) bool {
+		if event.Has(fsnotify.Create) {
+			if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+				if err := watchTree(watcher, event.Name); err != nil {
+					logger.Warn("failed to watch new directory", "dir", event.Name, "err", err)
+				}
+				return false
+			}
+		}
+		return isWatchedChange(event, valuesFiles, outputPath)
+	}
+	return watchLoop(ctx, watcher.Events, watcher.Errors, watchDebounce, isChange, rerender, logger)
+}
+
+// watchLoop calls render once debounce has passed without another event
+// for which isChange reports true, until ctx is cancelled or the watcher's
+// channels are closed.
+func watchLoop(ctx context.Context, events <-chan fsnotify.Event, errs <-chan error, debounce time.Duration,
+	isChange func(fsnotify.Event) bool, render func(), logger *slog.Logger) error {
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-errs:
+			if !ok {
+				return nil
+			}
+			logger.Warn("file watcher error", "err", err)
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if !isChange(event) {
+				continue
+			}
+			logger.Debug("detected change", "file", event.Name, "op", event.Op)
+			timer.Reset(debounce)
+		case <-timer.C:
+			render()
+		}
+	}
+}
+
+// watchTree adds root and every directory below it to watcher, skipping
+// hidden directories and the module cache under cue.mod/pkg.
+func watchTree(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if d.Name() == "pkg" && filepath.Base(filepath.Dir(path)) == "cue.mod" {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// isWatchedChange reports whether event modifies a .cue file or one of the
+// values files. Changes to outputPath, the absolute path renders are written
+// to if any, never count, so writing the output doesn't trigger a render.
+func isWatchedChange(event fsnotify.Event, valuesFiles map[string]bool, outputPath string) bool {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) &&
+		!event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
+		return false
+	}
+	path, err := filepath.Abs(event.Name)
+	if err != nil {
+		return false
+	}
+	if outputPath != "" && path == outputPath {
+		return false
+	}
+	return filepath.Ext(path) == ".cue" || valuesFiles[path]
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestIsWatchedChange(t *testing.T) {
+	dir := t.TempDir()
+	values := filepath.Join(dir, "values.yaml")
+	output := filepath.Join(dir, "rendered.cue")
+	valuesFiles := map[string]bool{values: true}
+
+	tests := []struct {
+		name   string
+		event  fsnotify.Event
+		output string
+		want   bool
+	}{
+		{"cue write", fsnotify.Event{Name: filepath.Join(dir, "bundle.cue"), Op: fsnotify.Write}, "", true},
+		{"cue create", fsnotify.Event{Name: filepath.Join(dir, "new.cue"), Op: fsnotify.Create}, "", true},
+		{"cue remove", fsnotify.Event{Name: filepath.Join(dir, "old.cue"), Op: fsnotify.Remove}, "", true},
+		{"cue rename", fsnotify.Event{Name: filepath.Join(dir, "old.cue"), Op: fsnotify.Rename}, "", true},
+		{"cue chmod", fsnotify.Event{Name: filepath.Join(dir, "bundle.cue"), Op: fsnotify.Chmod}, "", false},
+		{"values file", fsnotify.Event{Name: values, Op: fsnotify.Write}, "", true},
+		{"other file", fsnotify.Event{Name: filepath.Join(dir, "notes.yaml"), Op: fsnotify.Write}, "", false},
+		{"output file", fsnotify.Event{Name: output, Op: fsnotify.Write}, output, false},
+		{"output file created", fsnotify.Event{Name: output, Op: fsnotify.Create}, output, false},
+		{"cue beside output file", fsnotify.Event{Name: filepath.Join(dir, "bundle.cue"), Op: fsnotify.Write}, output, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isWatchedChange(tt.event, valuesFiles, tt.output); got != tt.want {
+				t.Errorf("isWatchedChange(%v) = %v, want %v", tt.event, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchLoopDebouncesRenders(t *testing.T) {
+	const debounce = 20 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan fsnotify.Event)
+	errs := make(chan error)
+	renders := make(chan struct{}, 10)
+	isChange := func(event fsnotify.Event) bool {
+		return filepath.Ext(event.Name) == ".cue"
+	}
+	render := func() { renders <- struct{}{} }
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- watchLoop(ctx, events, errs, debounce, isChange, render, logger)
+	}()
+
+	// A burst of changes renders once, after the last of them.
+	for range 3 {
+		events <- fsnotify.Event{Name: "bundle.cue", Op: fsnotify.Write}
+	}
+	select {
+	case <-renders:
+	case <-time.After(time.Second):
+		t.Fatal("no render after a burst of changes")
+	}
+	select {
+	case <-renders:
+		t.Fatal("a burst of changes rendered more than once")
+	case <-time.After(5 * debounce):
+	}
+
+	// Events that aren't changes and watcher errors don't render.
+	events <- fsnotify.Event{Name: "notes.txt", Op: fsnotify.Write}
+	errs <- fsnotify.ErrEventOverflow
+	select {
+	case <-renders:
+		t.Fatal("rendered without a change")
+	case <-time.After(5 * debounce):
+	}
+
+	// A later change renders again.
+	events <- fsnotify.Event{Name: "bundle.cue", Op: fsnotify.Write}
+	select {
+	case <-renders:
+	case <-time.After(time.Second):
+		t.Fatal("no render after a later change")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("watchLoop() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watchLoop didn't return once ctx was cancelled")
+	}
+}
+
+func TestWatchLoopStopsWhenWatcherCloses(t *testing.T) {
+	events := make(chan fsnotify.Event)
+	close(events)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	err := watchLoop(context.Background(), events, make(chan error), time.Millisecond,
+		func(fsnotify.Event) bool { return true }, func() {}, logger)
+	if err != nil {
+		t.Errorf("watchLoop() error = %v", err)
+	}
+}
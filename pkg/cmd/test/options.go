@@ -8,10 +8,16 @@ import (
 )
 
 type Options struct {
-	ModulePaths   []string          // local CUE modules to serve
-	TestPaths     []string          // txtar files or directories
-	Update        bool              // -u flag
+	ModulePaths   []string // local CUE modules to serve
+	TestPaths     []string // txtar files or directories
+	Update        bool     // -u flag
+	RunPattern    string   // regexp selecting tests by name (--run)
+	FailFast      bool     // stop running tests after the first failure
+	Env           []string // KEY=VALUE pairs set in every test script
+	KeepWork      bool     // keep work directories of failed tests
 	Verbose       bool
+	SummaryFormat string    // also write the summary to Output as one "plain" or "json" line
+	Output        io.Writer // where the summary line is written; defaults to stdout
 	CacheDir      string
 	Logger        *slog.Logger
 	Registries    map[string]string // global registries (includes hard-coded odin registries)
@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/rogpeppe/go-internal/testscript"
@@ -25,6 +26,15 @@ func run(ctx context.Context, opts Options) error {
 		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 	}
 
+	if err := validateSummaryFormat(opts.SummaryFormat); err != nil {
+		return err
+	}
+
+	env, err := parseEnv(opts.Env)
+	if err != nil {
+		return err
+	}
+
 	// Validate module paths
 	for _, mp := range opts.ModulePaths {
 		moduleFile := filepath.Join(mp, "cue.mod", "module.cue")
@@ -55,12 +65,25 @@ func run(ctx context.Context, opts Options) error {
 		return fmt.Errorf("no test files found")
 	}
 
-	logger.Info("discovered test files", "count", len(testFiles))
+	discovered := len(testFiles)
+	testFiles, err = filterTestFiles(testFiles, opts.RunPattern)
+	if err != nil {
+		return err
+	}
+	skipped := discovered - len(testFiles)
+
+	logger.Info("discovered test files", "count", discovered, "matched", len(testFiles), "skipped", skipped)
+
+	if len(testFiles) == 0 {
+		return fmt.Errorf("no test files match %q", opts.RunPattern)
+	}
 
 	// Build params options
 	paramsOpts := []odintest.ParamsOption{
 		odintest.WithFiles(testFiles),
 		odintest.WithUpdateScripts(opts.Update),
+		odintest.WithEnv(env),
+		odintest.WithTestWork(opts.KeepWork),
 		odintest.WithCmds(map[string]func(ts *testscript.TestScript, neg bool, args []string){
 			"odin-setup": odintest.OdinSetupCmd(registryHost, modules),
 			"template":   odintest.TemplateCmd(ctx, opts.Registries, opts.CacheDir, opts.Logger),
@@ -70,20 +93,50 @@ func run(ctx context.Context, opts Options) error {
 	// Create testscript params
 	params := odintest.DefaultParams(paramsOpts...)
 
+	// Record each script's work directory so it can be reported or cleaned up.
+	setup := params.Setup
+	params.Setup = func(env *testscript.Env) error {
+		if ts, ok := env.T().(*testScriptT); ok {
+			ts.workDir = env.WorkDir
+		}
+		return setup(env)
+	}
+
 	// Create a custom test runner
 	runner := &runner{
-		logger:  logger,
-		verbose: opts.Verbose,
-		passed:  0,
-		failed:  0,
+		logger:   logger,
+		verbose:  opts.Verbose,
+		failFast: opts.FailFast,
+		keepWork: opts.KeepWork,
+		passed:   0,
+		failed:   0,
 	}
 
 	// Run tests
 	testscript.RunT(&runT{runner: runner}, params)
 
 	// Print summary
+	// With fail-fast, tests after the first failure are never run, so they are
+	// reported as skipped and the summary covers only part of the suite.
 	total := runner.passed + runner.failed
-	logger.Info("test summary", "total", total, "passed", runner.passed, "failed", runner.failed)
+	skipped += runner.skipped
+	if runner.skipped > 0 {
+		logger.Warn("stopped after first failure", "notRun", runner.skipped)
+	}
+	logger.Info("test summary", "total", total, "passed", runner.passed, "failed", runner.failed, "skipped", skipped)
+
+	w := opts.Output
+	if w == nil {
+		w = os.Stdout
+	}
+	if err := writeSummary(w, opts.SummaryFormat, summary{
+		Total:   total,
+		Passed:  runner.passed,
+		Failed:  runner.failed,
+		Skipped: skipped,
+	}); err != nil {
+		return err
+	}
 
 	if runner.failed > 0 {
 		return fmt.Errorf("%d test(s) failed", runner.failed)
@@ -145,16 +198,54 @@ func discoverTestFiles(paths []string) ([]string, error) {
 	return files, nil
 }
 
+// parseEnv converts KEY=VALUE pairs to a map, rejecting malformed entries.
+func parseEnv(pairs []string) (map[string]string, error) {
+	env := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid environment variable %q: expected KEY=VALUE", pair)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// filterTestFiles keeps the files whose test name (base name without the
+// .txtar extension) matches pattern. An empty pattern keeps every file.
+func filterTestFiles(files []string, pattern string) ([]string, error) {
+	if pattern == "" {
+		return files, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --run pattern: %w", err)
+	}
+
+	var matched []string
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		if re.MatchString(name) {
+			matched = append(matched, file)
+		}
+	}
+	return matched, nil
+}
+
 // runT implements testscript.T interface
 type runT struct {
 	runner *runner
 }
 
 type runner struct {
-	logger  *slog.Logger
-	verbose bool
-	passed  int
-	failed  int
+	logger   *slog.Logger
+	verbose  bool
+	failFast bool
+	keepWork bool
+	passed   int
+	failed   int
+	skipped  int // tests not run because of fail-fast
 }
 
 var (
@@ -163,6 +254,19 @@ var (
 )
 
 func (t *runT) Run(name string, f func(t testscript.T)) {
+	// testscript has no way to abort a run, so short-circuit the remaining
+	// tests once one has failed.
+	if t.runner.failFast && t.runner.failed > 0 {
+		t.runner.skipped++
+		return
+	}
+
+	ts := &testScriptT{
+		name:    name,
+		runner:  t.runner,
+		verbose: t.runner.verbose,
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			// Check if it's a skip or fail panic
@@ -171,23 +275,29 @@ func (t *runT) Run(name string, f func(t testscript.T)) {
 			}
 			if r == failPanic {
 				t.runner.failed++
-				t.runner.logger.Error("test failed", "name", name)
+				attrs := []any{"name", name, "output", ts.output.String()}
+				if t.runner.keepWork && ts.workDir != "" {
+					attrs = append(attrs, "workDir", ts.workDir)
+				}
+				t.runner.logger.Error("test failed", attrs...)
 				return
 			}
 			// Re-panic if it's something else
 			panic(r)
 		}
+		// testscript keeps every work directory when asked to, but only
+		// failures are worth inspecting.
+		if t.runner.keepWork && ts.workDir != "" {
+			if err := os.RemoveAll(ts.workDir); err != nil {
+				t.runner.logger.Warn("failed to remove work directory", "name", name, "dir", ts.workDir, "err", err)
+			}
+		}
 		t.runner.passed++
 		if t.runner.verbose {
 			t.runner.logger.Info("test passed", "name", name)
 		}
 	}()
 
-	ts := &testScriptT{
-		name:    name,
-		runner:  t.runner,
-		verbose: t.runner.verbose,
-	}
 	f(ts)
 }
 
@@ -196,18 +306,18 @@ func (t *runT) FailNow() {
 }
 
 func (t *runT) Fatal(args ...interface{}) {
-	fmt.Println(args...)
+	t.runner.logger.Error(fmt.Sprint(args...))
 	panic(failPanic)
 }
 
 func (t *runT) Skip(args ...interface{}) {
-	fmt.Println(args...)
+	t.runner.logger.Info(fmt.Sprint(args...))
 	panic(skipPanic)
 }
 
 func (t *runT) Log(args ...interface{}) {
 	if t.runner.verbose {
-		fmt.Println(args...)
+		t.runner.logger.Info(fmt.Sprint(args...))
 	}
 }
 
@@ -219,11 +329,14 @@ func (t *runT) Verbose() bool {
 	return t.runner.verbose
 }
 
-// testScriptT implements testscript.T for individual tests
+// testScriptT implements testscript.T for individual tests. Everything the
+// script logs is captured in output so it can be reported if the test fails.
 type testScriptT struct {
 	name    string
 	runner  *runner
 	verbose bool
+	output  strings.Builder
+	workDir string
 }
 
 func (t *testScriptT) Skip(args ...interface{}) {
@@ -241,8 +354,13 @@ func (t *testScriptT) Parallel() {
 }
 
 func (t *testScriptT) Log(args ...interface{}) {
+	msg := fmt.Sprint(args...)
+	t.output.WriteString(msg)
+	if !strings.HasSuffix(msg, "\n") {
+		t.output.WriteByte('\n')
+	}
 	if t.verbose {
-		t.runner.logger.Info(fmt.Sprint(args...), "test", t.name)
+		t.runner.logger.Info(msg, "test", t.name)
 	}
 }
 
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Summary formats accepted by Options.SummaryFormat.
+const (
+	SummaryFormatPlain = "plain"
+	SummaryFormatJSON  = "json"
+)
+
+// summary is the outcome of a test run.
+type summary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+func validateSummaryFormat(format string) error {
+	switch format {
+	case "", SummaryFormatPlain, SummaryFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("unsupported summary format: %q (supported: %s, %s)", format, SummaryFormatPlain, SummaryFormatJSON)
+	}
+}
+
+// writeSummary writes s to w as a single line in the given format. An empty
+// format writes nothing.
+func writeSummary(w io.Writer, format string, s summary) error {
+	switch format {
+	case SummaryFormatPlain:
+		_, err := fmt.Fprintf(w, "RESULT total=%d passed=%d failed=%d skipped=%d\n", s.Total, s.Passed, s.Failed, s.Skipped)
+		return err
+	case SummaryFormatJSON:
+		return json.NewEncoder(w).Encode(s)
+	}
+	return nil
+}
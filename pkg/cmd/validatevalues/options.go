@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+
+package validatevalues
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Output formats accepted by Options.Format.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options contains the configuration for validating values files.
+type Options struct {
+	// BundlePath is the path to the bundle.
+	BundlePath string
+
+	// ValuesLocations are the values files to validate, unified in order.
+	ValuesLocations []string
+
+	// Format is the output format of the errors (text, json).
+	Format string
+
+	// Output is where errors are written; defaults to stdout.
+	Output io.Writer
+
+	// CacheDir is the cache directory for bundle loading.
+	CacheDir string
+
+	// Offline loads the bundle using only modules in the cache.
+	Offline bool
+
+	// Logger is the logger to use.
+	Logger *slog.Logger
+
+	// Registries maps module prefixes to OCI registries.
+	Registries map[string]string
+}
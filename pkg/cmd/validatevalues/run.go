@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+
+package validatevalues
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+// Run validates the values files against the bundle's values and component
+// config schemas and writes any errors found. It returns an error if any
+// value is invalid.
+func (o *Options) Run(ctx context.Context) error {
+	switch o.Format {
+	case "", FormatText, FormatJSON:
+	default:
+		return fmt.Errorf("unsupported format: %q (supported: %s, %s)", o.Format, FormatText, FormatJSON)
+	}
+	if len(o.ValuesLocations) == 0 {
+		return fmt.Errorf("no values files given")
+	}
+
+	b, err := model.LoadBundle(o.BundlePath,
+		model.WithLogger(o.Logger),
+		model.WithRegistries(o.Registries),
+		model.WithCacheDir(o.CacheDir),
+		model.WithOffline(o.Offline),
+		model.WithValues(o.ValuesLocations...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load bundle: %w", err)
+	}
+	defer b.Close()
+
+	errs := b.ValidateValues()
+
+	w := o.Output
+	if w == nil {
+		w = os.Stdout
+	}
+	if err := writeErrors(w, o.Format, errs); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d invalid value(s)", len(errs))
+	}
+	return nil
+}
+
+// writeErrors writes errs to w. The text format is one "<path>: <message>"
+// line per error; the JSON format is an array of objects with path, message
+// and position, and is written even when there are no errors.
+func writeErrors(w io.Writer, format string, errs []model.ValueError) error {
+	if format == FormatJSON {
+		if errs == nil {
+			errs = []model.ValueError{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(errs)
+	}
+	for _, e := range errs {
+		if _, err := fmt.Fprintln(w, e.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
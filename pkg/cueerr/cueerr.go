@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+
+// Package cueerr formats CUE errors along with the source positions they
+// refer to, so every part of odin reports them the same way.
+package cueerr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	cueerrors "cuelang.org/go/cue/errors"
+)
+
+// Is reports whether err wraps a CUE error.
+func Is(err error) bool {
+	e := cueerrors.Error(nil)
+	return errors.As(err, &e)
+}
+
+// Format writes err to w. Each CUE error wrapped in err is written on its own
+// line followed by the positions it refers to; any other error is written as
+// a single line.
+func Format(w io.Writer, err error) {
+	e := cueerrors.Error(nil)
+	if !errors.As(err, &e) {
+		fmt.Fprintln(w, err.Error())
+		return
+	}
+	for _, err := range cueerrors.Errors(e) {
+		fmt.Fprintln(w, err.Error())
+		if pos := err.InputPositions(); len(pos) > 0 {
+			fmt.Fprintln(w, " Positions:")
+			for _, pos := range pos {
+				fmt.Fprintln(w, "  ", pos.String())
+			}
+		} else {
+			fmt.Fprintln(w, " Position:", err.Position().String())
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+
+package cueerr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+)
+
+func TestFormat(t *testing.T) {
+	cueErr := cuecontext.New().CompileString("a: int\na: \"x\"\n", cue.Filename("test.cue")).Err()
+	if cueErr == nil {
+		t.Fatal("expected the CUE to fail to compile")
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		wantIs bool
+		want   []string
+	}{
+		{
+			name:   "wrapped CUE error",
+			err:    fmt.Errorf("loading bundle: %w", cueErr),
+			wantIs: true,
+			want:   []string{"conflicting values", "Positions:", "test.cue:1:4", "test.cue:2:4"},
+		},
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+			want: []string{"boom\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Is(tt.err); got != tt.wantIs {
+				t.Errorf("Is() = %v, want %v", got, tt.wantIs)
+			}
+			var b strings.Builder
+			Format(&b, tt.err)
+			for _, want := range tt.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("Format() = %q, want it to contain %q", b.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package docs
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/token"
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+// Conjunct is a single constraint contributing to a field's value.
+type Conjunct struct {
+	// Expr is the constraint formatted as CUE.
+	Expr string
+	// Pos is where the constraint is declared.
+	Pos token.Pos
+}
+
+// Explanation describes how a template field's value is assembled from the
+// constraints unified into it.
+type Explanation struct {
+	// Path is the path of the field within the template.
+	Path string
+	// Value is the field's unified value formatted as CUE.
+	Value string
+	// Conjuncts are the individual constraints unified into the field.
+	Conjuncts []Conjunct
+}
+
+// Explain decomposes the field at fieldPath into the conjuncts it was unified
+// from. The path is resolved within the template's config first, falling back
+// to the template itself, so both "replicas" and "config.replicas" work.
+func Explain(tmpl *model.ComponentTemplate, fieldPath string) (*Explanation, error) {
+	path := cue.ParsePath(fieldPath)
+	if err := path.Err(); err != nil {
+		return nil, fmt.Errorf("invalid field path %q: %w", fieldPath, err)
+	}
+
+	fullPath := cue.MakePath(append([]cue.Selector{cue.Str("config")}, path.Selectors()...)...)
+	field := tmpl.Value.LookupPath(fullPath)
+	if !field.Exists() {
+		fullPath = path
+		field = tmpl.Value.LookupPath(fullPath)
+	}
+	if !field.Exists() {
+		return nil, fmt.Errorf("field %q not found in %s", fieldPath, tmpl.Name)
+	}
+
+	value, err := formatValue(field)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{
+		Path:  fullPath.String(),
+		Value: value,
+	}
+	for _, v := range conjuncts(field) {
+		expr, err := formatValue(v)
+		if err != nil {
+			return nil, err
+		}
+		explanation.Conjuncts = append(explanation.Conjuncts, Conjunct{
+			Expr: expr,
+			Pos:  v.Pos(),
+		})
+	}
+	return explanation, nil
+}
+
+// conjuncts recursively splits v on unification, returning the leaf values.
+func conjuncts(v cue.Value) []cue.Value {
+	op, args := v.Expr()
+	if op != cue.AndOp {
+		return []cue.Value{v}
+	}
+	var result []cue.Value
+	for _, arg := range args {
+		result = append(result, conjuncts(arg)...)
+	}
+	return result
+}
+
+func formatValue(v cue.Value) (string, error) {
+	b, err := format.Node(v.Syntax(cue.Raw()))
+	if err != nil {
+		return "", fmt.Errorf("formatting value: %w", err)
+	}
+	return string(b), nil
+}
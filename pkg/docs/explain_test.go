@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package docs
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"go-valkyrie.com/odin/pkg/model"
+)
+
+const explainSource = `
+#Base: {
+	config: port: int & >0
+}
+
+#App: #Base & {
+	config: port: uint | *80
+}
+`
+
+func TestExplain(t *testing.T) {
+	v := cuecontext.New().CompileString(explainSource, cue.Filename("app.cue"))
+	if err := v.Err(); err != nil {
+		t.Fatalf("failed to compile source: %v", err)
+	}
+	tmpl := &model.ComponentTemplate{
+		Package: "example.com/app",
+		Name:    "#App",
+		Value:   v.LookupPath(cue.ParsePath("#App")),
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		wantPath      string
+		wantExprs     []string
+		wantErrSubstr string
+	}{
+		{
+			name:      "path relative to config",
+			path:      "port",
+			wantPath:  "config.port",
+			wantExprs: []string{"int & >=0 | *80", "int", ">0"},
+		},
+		{
+			name:      "path relative to template",
+			path:      "config.port",
+			wantPath:  "config.port",
+			wantExprs: []string{"int & >=0 | *80", "int", ">0"},
+		},
+		{
+			name:          "missing field",
+			path:          "replicas",
+			wantErrSubstr: `field "replicas" not found in #App`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Explain(tmpl, tt.path)
+			if tt.wantErrSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrSubstr) {
+					t.Fatalf("Explain() error = %v, want substring %q", err, tt.wantErrSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+			if got.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", got.Path, tt.wantPath)
+			}
+
+			var exprs []string
+			for _, c := range got.Conjuncts {
+				exprs = append(exprs, c.Expr)
+				if c.Pos.Filename() != "app.cue" {
+					t.Errorf("conjunct %q position = %v, want one in app.cue", c.Expr, c.Pos)
+				}
+			}
+			slices.Sort(exprs)
+			want := slices.Clone(tt.wantExprs)
+			slices.Sort(want)
+			if !slices.Equal(exprs, want) {
+				t.Errorf("conjuncts = %q, want %q", exprs, want)
+			}
+		})
+	}
+}
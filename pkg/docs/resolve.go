@@ -2,159 +2,33 @@
 
 package docs
 
-import (
-	"fmt"
-	"sort"
-	"strings"
+import "go-valkyrie.com/odin/pkg/model"
 
-	"go-valkyrie.com/odin/pkg/model"
-)
+// AmbiguousReferenceError is returned by ResolveReference when a reference
+// matches more than one template.
+type AmbiguousReferenceError = model.AmbiguousReferenceError
 
-// ResolveReference matches a reference string against a list of component templates.
-// Resolution order:
-//  1. Fully qualified (contains ":#") — exact match
-//  2. Definition name — strip "#", case-insensitive; unique match wins
-//  3. Package name — last path segment, strip @vN, case-insensitive; unique def in package wins
-//  4. Dot syntax "package.Definition" — case-insensitive match on both parts
-//  5. Error with available templates
-func ResolveReference(reference string, templates []*model.ComponentTemplate) (*model.ComponentTemplate, error) {
-	if len(templates) == 0 {
-		return nil, fmt.Errorf("no component templates available")
-	}
-
-	// 1. Fully qualified match: package:#Definition
-	if strings.Contains(reference, ":#") {
-		parts := strings.SplitN(reference, ":#", 2)
-		pkg, defName := parts[0], "#"+parts[1]
-		for _, tmpl := range templates {
-			if tmpl.Package == pkg && tmpl.Name == defName {
-				return tmpl, nil
-			}
-		}
-		for _, tmpl := range templates {
-			fullPkg := tmpl.Package + "@" + tmpl.Version
-			if fullPkg == pkg && tmpl.Name == defName {
-				return tmpl, nil
-			}
-		}
-		return nil, fmt.Errorf("no component template found matching %q", reference)
-	}
-
-	refLower := strings.ToLower(reference)
-
-	// 2. Definition name match (strip "#", case-insensitive)
-	var defMatches []*model.ComponentTemplate
-	for _, tmpl := range templates {
-		defName := strings.TrimPrefix(tmpl.Name, "#")
-		if strings.EqualFold(defName, refLower) {
-			defMatches = append(defMatches, tmpl)
-		}
-	}
-	if len(defMatches) == 1 {
-		return defMatches[0], nil
-	}
-
-	// 3. Package name match (last path segment, strip @vN, case-insensitive)
-	var pkgMatches []*model.ComponentTemplate
-	for _, tmpl := range templates {
-		if strings.EqualFold(shorthandName(tmpl.Package), refLower) {
-			pkgMatches = append(pkgMatches, tmpl)
-		}
-	}
-	if len(pkgMatches) == 1 {
-		return pkgMatches[0], nil
-	}
-
-	// 4. Dot syntax: "package.Definition" (case-insensitive)
-	if dotIdx := strings.LastIndex(reference, "."); dotIdx != -1 {
-		pkgPart := strings.ToLower(reference[:dotIdx])
-		defPart := strings.ToLower(reference[dotIdx+1:])
-		for _, tmpl := range templates {
-			pkg := strings.ToLower(shorthandName(tmpl.Package))
-			def := strings.ToLower(strings.TrimPrefix(tmpl.Name, "#"))
-			if pkg == pkgPart && def == defPart {
-				return tmpl, nil
-			}
-		}
-	}
-
-	// 5. Error with available templates
-	// If we had ambiguous matches from step 2 (definition name), show those as candidates
-	if len(defMatches) > 1 {
-		return nil, ambiguousError(reference, defMatches)
-	}
-	// If we had ambiguous matches from step 3 (package name), show those as candidates
-	if len(pkgMatches) > 1 {
-		return nil, ambiguousError(reference, pkgMatches)
-	}
+// ResolveReference matches a reference string against a list of component
+// templates, following the rules of model.ResolveComponentTemplate. Names
+// match case-insensitively unless model.WithCaseSensitive is given.
+func ResolveReference(reference string, templates []*model.ComponentTemplate, opts ...model.ResolveOption) (*model.ComponentTemplate, error) {
+	return model.ResolveComponentTemplate(reference, templates, opts...)
+}
 
-	// No match at all
+// AvailableReferences returns the short reference ("package.Definition") for
+// each template, in the order given. Each returned string can be passed back to
+// ResolveReference.
+func AvailableReferences(templates []*model.ComponentTemplate) []string {
 	available := make([]string, 0, len(templates))
 	for _, tmpl := range templates {
-		available = append(available, displayName(tmpl))
+		available = append(available, tmpl.ShortReference())
 	}
-	return nil, fmt.Errorf("no component template matching %q; available: %s", reference, strings.Join(available, ", "))
+	return available
 }
 
-func ambiguousError(reference string, matches []*model.ComponentTemplate) error {
-	candidates := make([]string, 0, len(matches))
-	for _, m := range matches {
-		candidates = append(candidates, fmt.Sprintf("%s (%s)", displayName(m), m.Package))
-	}
-	return fmt.Errorf("ambiguous reference %q matches multiple templates:\n  %s", reference, strings.Join(candidates, "\n  "))
-}
-
-func displayName(tmpl *model.ComponentTemplate) string {
-	return shorthandName(tmpl.Package) + "." + strings.TrimPrefix(tmpl.Name, "#")
-}
-
-// shorthandName extracts the last path segment from a package import path,
-// stripping any @vN version suffix.
-func shorthandName(pkg string) string {
-	// Strip @vN suffix if present
-	if idx := strings.LastIndex(pkg, "@"); idx != -1 {
-		pkg = pkg[:idx]
-	}
-	// Get last path segment
-	if idx := strings.LastIndex(pkg, "/"); idx != -1 {
-		return pkg[idx+1:]
-	}
-	return pkg
-}
-
-// ResolvePackagePath performs case-insensitive prefix matching against template Package fields.
-// Both the reference and package paths have @vN suffixes stripped before comparison.
-// Returns all matching templates, sorted by Package then Name.
-func ResolvePackagePath(reference string, templates []*model.ComponentTemplate) []*model.ComponentTemplate {
-	// Strip @vN from reference if present
-	refPath := reference
-	if idx := strings.LastIndex(refPath, "@"); idx != -1 {
-		refPath = refPath[:idx]
-	}
-	refPathLower := strings.ToLower(refPath)
-
-	var matches []*model.ComponentTemplate
-	for _, tmpl := range templates {
-		// Strip @vN from package path
-		pkgPath := tmpl.Package
-		if idx := strings.LastIndex(pkgPath, "@"); idx != -1 {
-			pkgPath = pkgPath[:idx]
-		}
-		pkgPathLower := strings.ToLower(pkgPath)
-
-		// Check if package path starts with reference
-		if strings.HasPrefix(pkgPathLower, refPathLower) {
-			matches = append(matches, tmpl)
-		}
-	}
-
-	// Sort by Package then Name
-	sort.Slice(matches, func(i, j int) bool {
-		if matches[i].Package != matches[j].Package {
-			return matches[i].Package < matches[j].Package
-		}
-		return matches[i].Name < matches[j].Name
-	})
-
-	return matches
+// ResolvePackagePath performs prefix matching against template Package
+// fields, following the rules of model.ResolvePackagePath. Package paths
+// match case-insensitively unless model.WithCaseSensitive is given.
+func ResolvePackagePath(reference string, templates []*model.ComponentTemplate, opts ...model.ResolveOption) []*model.ComponentTemplate {
+	return model.ResolvePackagePath(reference, templates, opts...)
 }
@@ -3,6 +3,8 @@
 package docs
 
 import (
+	"errors"
+	"slices"
 	"strings"
 	"testing"
 
@@ -17,6 +19,12 @@ func TestResolveReference(t *testing.T) {
 		{Package: "example.com/other", Name: "#WebApp", Module: "example.com/other", Version: "v1.0.0"}, // Duplicate definition name
 		{Package: "example.com/single", Name: "#Unique", Module: "example.com/single", Version: "v1.0.0"},
 	}
+	// versionedTemplates have the major version in their import path, as
+	// discovery reports them; the local one has no module version.
+	versionedTemplates := []*model.ComponentTemplate{
+		{Package: "example.com/platform/workload@v0", Name: "#WebApp", Module: "example.com/platform@v0", Version: "v0.3.1"},
+		{Package: "test.example.com/bundle@v0", Name: "#Worker", Module: "test.example.com/bundle@v0", Local: true},
+	}
 
 	tests := []struct {
 		name          string
@@ -41,6 +49,60 @@ func TestResolveReference(t *testing.T) {
 			wantPackage: "platform.example.com/workload",
 			wantName:    "#WebApp",
 		},
+		{
+			name:        "fully qualified with partial version",
+			reference:   "platform.example.com/workload@v0:#WebApp",
+			templates:   templates,
+			wantPackage: "platform.example.com/workload",
+			wantName:    "#WebApp",
+		},
+		{
+			name:        "fully qualified with minor version prefix",
+			reference:   "platform.example.com/security@v0.2:#ServiceAccount",
+			templates:   templates,
+			wantPackage: "platform.example.com/security",
+			wantName:    "#ServiceAccount",
+		},
+		{
+			name:          "fully qualified with non-matching version",
+			reference:     "platform.example.com/workload@v1:#WebApp",
+			templates:     templates,
+			wantErrSubstr: "no component template found matching",
+		},
+		{
+			name:          "fully qualified version prefix stops at a component",
+			reference:     "platform.example.com/security@v0.20:#ServiceAccount",
+			templates:     templates,
+			wantErrSubstr: "no component template found matching",
+		},
+		{
+			name:        "fully qualified major version in import path",
+			reference:   "example.com/platform/workload:#WebApp",
+			templates:   versionedTemplates,
+			wantPackage: "example.com/platform/workload@v0",
+			wantName:    "#WebApp",
+		},
+		{
+			name:        "fully qualified local template with empty version",
+			reference:   "test.example.com/bundle@:#Worker",
+			templates:   versionedTemplates,
+			wantPackage: "test.example.com/bundle@v0",
+			wantName:    "#Worker",
+		},
+		{
+			name:        "fully qualified local template with major version",
+			reference:   "test.example.com/bundle@v0:#Worker",
+			templates:   versionedTemplates,
+			wantPackage: "test.example.com/bundle@v0",
+			wantName:    "#Worker",
+		},
+		{
+			name:        "fully qualified module version with major in import path",
+			reference:   "example.com/platform/workload@v0.3:#WebApp",
+			templates:   versionedTemplates,
+			wantPackage: "example.com/platform/workload@v0",
+			wantName:    "#WebApp",
+		},
 		{
 			name:          "fully qualified no match",
 			reference:     "platform.example.com/workload:#Missing",
@@ -236,27 +298,115 @@ func TestResolvePackagePath(t *testing.T) {
 	}
 }
 
-func TestShorthandName(t *testing.T) {
+func TestResolveReferenceAmbiguousError(t *testing.T) {
+	templates := []*model.ComponentTemplate{
+		{Package: "platform.example.com/workload", Name: "#WebApp"},
+		{Package: "example.com/other", Name: "#WebApp"},
+		{Package: "example.com/single", Name: "#Unique"},
+	}
+
+	_, err := ResolveReference("webapp", templates)
+	var ambiguous *AmbiguousReferenceError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("ResolveReference() error = %v, want *AmbiguousReferenceError", err)
+	}
+	if ambiguous.Reference != "webapp" {
+		t.Errorf("Reference = %q, want %q", ambiguous.Reference, "webapp")
+	}
+	if len(ambiguous.Candidates) != 2 || ambiguous.Candidates[0] != templates[0] || ambiguous.Candidates[1] != templates[1] {
+		t.Errorf("Candidates = %v, want the two #WebApp templates", ambiguous.Candidates)
+	}
+	want := "ambiguous reference \"webapp\" matches multiple templates:\n" +
+		"  workload.WebApp (platform.example.com/workload)\n" +
+		"  other.WebApp (example.com/other)"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestResolveReferenceCaseSensitive(t *testing.T) {
+	templates := []*model.ComponentTemplate{
+		{Package: "platform.example.com/workload", Name: "#WebApp"},
+		{Package: "platform.example.com/workload", Name: "#Webapp"},
+		{Package: "platform.example.com/Workload", Name: "#Worker"},
+	}
+
 	tests := []struct {
-		pkg  string
-		want string
+		name          string
+		reference     string
+		caseSensitive bool
+		want          *model.ComponentTemplate
+		wantAmbiguous bool
+		wantErr       bool
 	}{
-		{"platform.example.com/workload", "workload"},
-		{"platform.example.com/workload@v0", "workload"},
-		{"platform.example.com/workload@v0.1.0", "workload"},
-		{"workload", "workload"},
-		{"workload@v1", "workload"},
-		{"example.com/foo/bar", "bar"},
-		{"example.com/foo/bar@v2", "bar"},
-		{"", ""},
+		{name: "insensitive definition is ambiguous", reference: "webapp", wantAmbiguous: true},
+		{name: "insensitive definition prefers exact case", reference: "Webapp", want: templates[1]},
+		{name: "insensitive dot syntax prefers exact case", reference: "workload.Webapp", want: templates[1]},
+		{name: "insensitive dot syntax is ambiguous", reference: "workload.webapp", wantAmbiguous: true},
+		{name: "insensitive package name is ambiguous", reference: "workload", wantAmbiguous: true},
+		{name: "sensitive definition", reference: "WebApp", caseSensitive: true, want: templates[0]},
+		{name: "sensitive definition other case", reference: "Webapp", caseSensitive: true, want: templates[1]},
+		{name: "sensitive dot syntax", reference: "workload.Webapp", caseSensitive: true, want: templates[1]},
+		{name: "sensitive package name", reference: "Workload", caseSensitive: true, want: templates[2]},
+		{name: "sensitive no match in other case", reference: "webapp", caseSensitive: true, wantErr: true},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.pkg, func(t *testing.T) {
-			got := shorthandName(tt.pkg)
-			if got != tt.want {
-				t.Errorf("shorthandName(%q) = %q, want %q", tt.pkg, got, tt.want)
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveReference(tt.reference, templates, model.WithCaseSensitive(tt.caseSensitive))
+			var ambiguous *AmbiguousReferenceError
+			switch {
+			case tt.wantAmbiguous:
+				if !errors.As(err, &ambiguous) {
+					t.Fatalf("ResolveReference(%q) error = %v, want *AmbiguousReferenceError", tt.reference, err)
+				}
+			case tt.wantErr:
+				if err == nil || errors.As(err, &ambiguous) {
+					t.Fatalf("ResolveReference(%q) error = %v, want no match", tt.reference, err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("ResolveReference(%q) error = %v", tt.reference, err)
+				}
+				if got != tt.want {
+					t.Errorf("ResolveReference(%q) = %s:%s, want %s:%s", tt.reference, got.Package, got.Name, tt.want.Package, tt.want.Name)
+				}
 			}
 		})
 	}
+
+	if got := ResolvePackagePath("platform.example.com/workload", templates, model.WithCaseSensitive(true)); len(got) != 2 {
+		t.Errorf("case-sensitive ResolvePackagePath() matched %d templates, want 2", len(got))
+	}
+	if got := ResolvePackagePath("platform.example.com/workload", templates); len(got) != 3 {
+		t.Errorf("ResolvePackagePath() matched %d templates, want 3", len(got))
+	}
+}
+
+func TestAvailableReferences(t *testing.T) {
+	templates := []*model.ComponentTemplate{
+		{Package: "platform.example.com/workload@v0", Name: "#WebApp"},
+		{Package: "platform.example.com/security", Name: "#ServiceAccount"},
+	}
+
+	got := AvailableReferences(templates)
+	want := []string{"workload.WebApp", "security.ServiceAccount"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("AvailableReferences() = %v, want %v", got, want)
+	}
+
+	// Each reference must resolve back to its template.
+	for i, ref := range got {
+		tmpl, err := ResolveReference(ref, templates)
+		if err != nil {
+			t.Fatalf("ResolveReference(%q) error = %v", ref, err)
+		}
+		if tmpl != templates[i] {
+			t.Errorf("ResolveReference(%q) = %s:%s, want %s:%s", ref, tmpl.Package, tmpl.Name, templates[i].Package, templates[i].Name)
+		}
+	}
+
+	if got := AvailableReferences(nil); len(got) != 0 {
+		t.Errorf("AvailableReferences(nil) = %v, want empty", got)
+	}
 }
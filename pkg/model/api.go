@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/load"
+	pkgschema "go-valkyrie.com/odin/pkg/schema"
+)
+
+// APIPackage is the import path of the odin API definitions that bundles and
+// component templates are built on.
+const APIPackage = "go-valkyrie.com/odin/api/v1alpha1"
+
+// APIDefinition is the schema of a definition in the odin API.
+type APIDefinition struct {
+	// Package is the import path the definition was loaded from.
+	Package string
+	// Name is the definition's name, e.g. "#ComponentBase".
+	Name string
+	// Doc is the definition's doc comment.
+	Doc    string
+	Fields []*pkgschema.SchemaField
+}
+
+// APIDefinition loads the version of the odin API the bundle depends on and
+// returns the schema of the named definition, e.g. "#ComponentBase" or
+// "Component"; an empty name means #ComponentBase, which component templates
+// must conform to. If there's no such definition the error lists the ones
+// there are.
+func (b *Bundle) APIDefinition(name string, opts ...pkgschema.WalkOption) (*APIDefinition, error) {
+	defer lock(b.mu)()
+	if name == "" {
+		name = "#ComponentBase"
+	}
+	if !strings.HasPrefix(name, "#") {
+		name = "#" + name
+	}
+	path := cue.ParsePath(name)
+	if err := path.Err(); err != nil {
+		return nil, fmt.Errorf("invalid API definition %q: %w", name, err)
+	}
+
+	insts := load.Instances([]string{APIPackage}, &load.Config{
+		Dir: b.sourcePath,
+		Env: b.env,
+	})
+	if len(insts) == 0 {
+		return nil, fmt.Errorf("loading package %s: not found", APIPackage)
+	}
+	if err := insts[0].Err; err != nil {
+		return nil, fmt.Errorf("loading package %s: %w", APIPackage, err)
+	}
+	pkg := b.ctx.BuildInstance(insts[0])
+	if err := pkg.Err(); err != nil {
+		return nil, fmt.Errorf("building package %s: %w", APIPackage, err)
+	}
+
+	def := pkg.LookupPath(path)
+	if !def.Exists() {
+		return nil, fmt.Errorf("no definition %s in %s (available: %s)", name, APIPackage, strings.Join(definitionNames(pkg), ", "))
+	}
+
+	var docParts []string
+	for _, cg := range def.Doc() {
+		docParts = append(docParts, cg.Text())
+	}
+	return &APIDefinition{
+		Package: APIPackage,
+		Name:    name,
+		Doc:     strings.TrimSpace(strings.Join(docParts, "\n")),
+		Fields:  pkgschema.WalkSchema(def, opts...),
+	}, nil
+}
+
+// definitionNames returns the names of v's exported definitions.
+func definitionNames(v cue.Value) []string {
+	var names []string
+	iter, err := v.Fields(cue.Definitions(true))
+	if err != nil {
+		return nil
+	}
+	for iter.Next() {
+		if sel := iter.Selector(); sel.IsDefinition() && !strings.HasPrefix(sel.String(), "_") {
+			names = append(names, sel.String())
+		}
+	}
+	return names
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBundleAPIDefinition(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	b, err := LoadBundle(dir, opts...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	def, err := b.APIDefinition("")
+	if err != nil {
+		t.Fatalf("APIDefinition() error = %v", err)
+	}
+	if def.Name != "#ComponentBase" || def.Package != APIPackage {
+		t.Errorf("APIDefinition() = %s %s, want %s #ComponentBase", def.Package, def.Name, APIPackage)
+	}
+	names := map[string]bool{}
+	for _, f := range def.Fields {
+		names[f.Name] = true
+	}
+	for _, name := range []string{"apiVersion", "kind", "config"} {
+		if !names[name] {
+			t.Errorf("#ComponentBase fields missing %q (got %v)", name, names)
+		}
+	}
+
+	if def, err := b.APIDefinition("Component"); err != nil {
+		t.Errorf("APIDefinition(Component) error = %v", err)
+	} else if def.Name != "#Component" {
+		t.Errorf("APIDefinition(Component).Name = %q, want #Component", def.Name)
+	}
+
+	_, err = b.APIDefinition("#Nope")
+	if err == nil || !strings.Contains(err.Error(), "#ComponentBase") {
+		t.Errorf("APIDefinition(#Nope) error = %v, want one listing the available definitions", err)
+	}
+}
@@ -3,17 +3,28 @@
 package model
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"iter"
 	"log/slog"
 	"maps"
 	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/ast/astutil"
 	"cuelang.org/go/cue/build"
 	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/encoding/toml"
 	"cuelang.org/go/encoding/yaml"
+	"cuelang.org/go/mod/modfile"
+	"go-valkyrie.com/odin/internal/git"
 	"go-valkyrie.com/odin/internal/schema"
 	"go-valkyrie.com/odin/internal/utils"
 	"go-valkyrie.com/odin/pkg/model/internal/compat"
@@ -21,47 +32,66 @@ import (
 	pkgschema "go-valkyrie.com/odin/pkg/schema"
 )
 
+// configureValuesInstance adds the data files of a values instance (which the
+// CUE loader leaves orphaned) as syntax so they unify with any CUE values files.
 func configureValuesInstance(inst *build.Instance) error {
-	if inst.BuildFiles != nil {
-		return nil
-	}
-
 	for _, f := range inst.OrphanedFiles {
-		reader, err := os.Open(f.Filename)
+		file, err := extractValuesFile(f)
 		if err != nil {
-			continue
+			return fmt.Errorf("failed to load values file %s: %w", f.Filename, err)
 		}
-		defer reader.Close()
-
-		switch f.Encoding {
-		case "yaml":
-			fallthrough
-		case "json":
-			if file, err := yaml.Extract(f.Filename, reader); err != nil {
-				continue
-			} else if err := inst.AddSyntax(file); err != nil {
-				return err
-			}
-		default:
+		if file == nil {
 			continue
 		}
-
+		if err := inst.AddSyntax(file); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+func extractValuesFile(f *build.File) (*ast.File, error) {
+	reader, err := os.Open(f.Filename)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	switch f.Encoding {
+	case build.YAML, build.JSON:
+		return yaml.Extract(f.Filename, reader)
+	case build.TOML:
+		expr, err := toml.NewDecoder(f.Filename, reader).Decode()
+		if err != nil {
+			return nil, err
+		}
+		return astutil.ToFile(expr)
+	default:
+		return nil, nil
+	}
+}
+
 type Option func(bundle *bundleLoader) error
 
 type bundleLoader struct {
-	ctx          *cue.Context
-	env          []string
-	namespace    string
-	logger       *slog.Logger
-	source       source.Source
-	valuesSource source.Source
-	registries   map[string]string
-	cacheDir     string
+	ctx             *cue.Context
+	env             []string
+	namespace       string
+	logger          *slog.Logger
+	source          source.Source
+	valuesLocations []string
+	setFiles        []setFile
+	registries      map[string]string
+	cacheDir        string
+	offline         bool
+	templateScope   TemplateScope
+	componentBases  []string
+	excludeDeps     []string
+	packagePatterns []string
+	strictDiscovery bool
+	timings         *Timings
+	stdin           io.Reader
 }
 
 func WithContext(ctx *cue.Context) Option {
@@ -94,6 +124,24 @@ func WithCacheDir(cacheDir string) Option {
 	}
 }
 
+// WithOffline loads the bundle using only modules already in the cache,
+// failing instead of fetching any that are missing.
+func WithOffline(offline bool) Option {
+	return func(l *bundleLoader) error {
+		l.offline = offline
+		return nil
+	}
+}
+
+// WithStdin sets the reader a bundle is read from when LoadBundle is given
+// StdinLocation, instead of os.Stdin.
+func WithStdin(r io.Reader) Option {
+	return func(l *bundleLoader) error {
+		l.stdin = r
+		return nil
+	}
+}
+
 func WithLogger(logger *slog.Logger) Option {
 	return func(l *bundleLoader) error {
 		l.logger = logger
@@ -108,60 +156,154 @@ func WithNamespace(namespace string) Option {
 	}
 }
 
-func WithValues(locations []string) Option {
+// WithValues adds values overlays to apply to the bundle. Each location is a
+// file path, optionally prefixed with an encoding (e.g. "yaml: values.txt").
+// The "k8s" prefix reads a Kubernetes ConfigMap or Secret manifest, setting a
+// top-level string value for each key of its data, decoded from base64 for
+// a Secret. Locations from repeated calls accumulate and are unified in
+// order.
+func WithValues(locations ...string) Option {
 	return func(l *bundleLoader) error {
-		if vs, err := source.NewValues(locations); err != nil {
-			return err
-		} else {
-			l.valuesSource = vs
-			return nil
-		}
+		l.valuesLocations = append(l.valuesLocations, locations...)
+		return nil
 	}
 }
 
-func (l *bundleLoader) Load() (*Bundle, error) {
-	if l.source == nil {
-		return nil, fmt.Errorf("modelSource is required")
+// setFile is a value to set from the contents of a file; see WithSetFiles.
+type setFile struct {
+	path cue.Path
+	file string
+}
+
+// WithSetFiles sets values to the contents of files. Each assignment is
+// "key=file", where key is a path within values, such as
+// "components.app.tls.cert", and the contents of file, which must be text,
+// become its string value. They're applied after any WithValues overlays.
+func WithSetFiles(assignments ...string) Option {
+	return func(l *bundleLoader) error {
+		for _, assignment := range assignments {
+			key, file, ok := strings.Cut(assignment, "=")
+			if !ok || key == "" || file == "" {
+				return fmt.Errorf("invalid file value %q, must be key=file", assignment)
+			}
+			p := cue.ParsePath(key)
+			if err := p.Err(); err != nil {
+				return fmt.Errorf("invalid file value %q: %w", assignment, err)
+			}
+			l.setFiles = append(l.setFiles, setFile{path: p, file: file})
+		}
+		return nil
 	}
+}
 
-	logger := l.logger
-	if logger == nil {
-		logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+// ValuesPath returns the file path of a values location as accepted by
+// WithValues, without any encoding prefix.
+func ValuesPath(location string) string {
+	return source.ValuesLocationPath(location)
+}
+
+// WithTemplateScope restricts component template discovery to the bundle's
+// dependencies or its own module. The default is ScopeAll.
+func WithTemplateScope(scope TemplateScope) Option {
+	return func(l *bundleLoader) error {
+		l.templateScope = scope
+		return nil
 	}
+}
 
-	if l.registries == nil {
-		l.registries = map[string]string{}
+// WithComponentBases sets the definitions component template discovery
+// recognises templates by, each given as "<import path>:#<Definition>". A
+// definition is a template if it unifies with any of them. The default is
+// DefaultComponentBase.
+func WithComponentBases(bases []string) Option {
+	return func(l *bundleLoader) error {
+		for _, base := range bases {
+			if _, _, err := parseComponentBase(base); err != nil {
+				return err
+			}
+		}
+		l.componentBases = bases
+		return nil
 	}
+}
 
-	// Check if source needs preparation (e.g., OCI sources need to pull first)
-	type preparableSource interface {
-		Prepare() error
+// WithExcludeDependencies skips dependencies whose module path matches any of
+// patterns during component template discovery. A pattern is a path.Match glob
+// that matches a module path or any of its leading path elements, so
+// "example.com/big" and "example.com/*" both exclude "example.com/big/sub".
+func WithExcludeDependencies(patterns []string) Option {
+	return func(l *bundleLoader) error {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid dependency exclusion %q: %w", pattern, err)
+			}
+		}
+		l.excludeDeps = patterns
+		return nil
 	}
-	if p, ok := l.source.(preparableSource); ok {
-		if err := p.Prepare(); err != nil {
-			return nil, fmt.Errorf("failed to prepare source: %w", err)
+}
+
+// WithPackagePatterns restricts component template discovery within each
+// scanned module to the packages matching patterns, given relative to the
+// module root as to "cue export", e.g. "./components/...". A pattern prefixed
+// with "!" excludes the packages under it instead, e.g. "!./internal". The
+// default is "./...", every package in the module. Modules where a pattern
+// matches nothing are simply skipped for it.
+func WithPackagePatterns(patterns []string) Option {
+	return func(l *bundleLoader) error {
+		for _, pattern := range patterns {
+			if _, err := path.Match(packagePrefix(strings.TrimPrefix(pattern, "!")), ""); err != nil {
+				return fmt.Errorf("invalid package pattern %q: %w", pattern, err)
+			}
 		}
+		l.packagePatterns = patterns
+		return nil
 	}
+}
 
-	b, err := newBundle(l.ctx)
+// WithStrictDiscovery makes component template discovery yield an error for
+// each package that fails to load or build, or that marks a definition
+// @odin(component) that doesn't unify with any component base, rather than
+// logging a warning and skipping it.
+func WithStrictDiscovery(strict bool) Option {
+	return func(l *bundleLoader) error {
+		l.strictDiscovery = strict
+		return nil
+	}
+}
+
+// WithTimings records the time spent loading the bundle, and later
+// discovering its component templates, in timings.
+func WithTimings(timings *Timings) Option {
+	return func(l *bundleLoader) error {
+		l.timings = timings
+		return nil
+	}
+}
+
+func (l *bundleLoader) Load() (*Bundle, error) {
+	b, cfg, err := l.prepare()
 	if err != nil {
 		return nil, err
 	}
-
-	bundlePath := l.source.String()
-	b.sourcePath = bundlePath
-	b.logger = logger
-	cfg, err := LoadConfig(bundlePath)
+	loaded, err := l.load(b, cfg)
 	if err != nil {
+		// Nothing can use the prepared source once loading has failed.
+		b.Close()
 		return nil, err
 	}
+	return loaded, nil
+}
 
-	b.addRegistries(l.registries)
-	b.addRegistries(cfg.Registries)
-
-	b.env = utils.CreateCueEnvironment(l.cacheDir, b.Registries())
+// load loads the bundle prepared by prepare, along with its values.
+func (l *bundleLoader) load(b *Bundle, cfg *Config) (*Bundle, error) {
+	logger := b.logger
 
-	logger.Debug("using CUE environment", "env", b.env)
+	if l.offline {
+		if err := b.checkCached(); err != nil {
+			return nil, err
+		}
+	}
 
 	logger.Debug("loading bundle", "source", l.source.String())
 
@@ -175,6 +317,7 @@ func (l *bundleLoader) Load() (*Bundle, error) {
 		Namespace: l.namespace,
 	})
 
+	done := timePhase(logger, l.timings, PhaseLoad, "source", l.source.String())
 	if value, err := l.source.Load(b.ctx, loadOpts); err != nil {
 		return nil, err
 	} else {
@@ -186,20 +329,142 @@ func (l *bundleLoader) Load() (*Bundle, error) {
 	} else {
 		b.value = b.value.Unify(bundleSchema)
 	}
+	done()
 
-	if l.valuesSource != nil {
-		logger.Debug("loading values", "source", l.valuesSource.String())
-		if _b, err := b.LoadValues(l.valuesSource); err != nil {
+	if len(l.valuesLocations) > 0 {
+		valuesSource, err := source.NewValues(l.valuesLocations)
+		if err != nil {
+			return nil, err
+		}
+		logger.Debug("loading values", "source", valuesSource.String())
+		done := timePhase(logger, l.timings, PhaseValues, "source", valuesSource.String())
+		if _b, err := b.LoadValues(valuesSource); err != nil {
 			return nil, err
 		} else {
 			b = _b
 		}
+		done()
+	}
+
+	if len(l.setFiles) > 0 {
+		if b, err = b.loadSetFiles(l.setFiles); err != nil {
+			return nil, err
+		}
 	}
 
 	return b, nil
 }
 
+// prepare readies the bundle's source and sets up a Bundle with the registries
+// and CUE environment to load it with, without loading it.
+func (l *bundleLoader) prepare() (*Bundle, *Config, error) {
+	if l.source == nil {
+		return nil, nil, fmt.Errorf("modelSource is required")
+	}
+
+	logger := l.logger
+	if logger == nil {
+		logger = discardLogger()
+	}
+
+	if l.registries == nil {
+		l.registries = map[string]string{}
+	}
+
+	// Check if source needs preparation (e.g., OCI sources need to pull first)
+	type preparableSource interface {
+		Prepare() error
+	}
+	p, prepared := l.source.(preparableSource)
+	if prepared {
+		if err := p.Prepare(); err != nil {
+			return nil, nil, fmt.Errorf("failed to prepare source: %w", err)
+		}
+	}
+
+	b, err := newBundle(l.ctx)
+	if err != nil {
+		if closer, ok := l.source.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, nil, err
+	}
+	if closer, ok := l.source.(io.Closer); ok {
+		b.closer = closer
+	}
+
+	bundlePath := l.source.String()
+	b.sourcePath = bundlePath
+	b.logger = logger
+	b.templateScope = l.templateScope
+	b.componentBases = l.componentBases
+	b.excludeDeps = l.excludeDeps
+	b.packagePatterns = l.packagePatterns
+	b.strictDiscovery = l.strictDiscovery
+	b.timings = l.timings
+	// Prepared sources are extracted to a temporary directory, so only
+	// their own odin.toml applies.
+	cfg, err := loadConfig(bundlePath, !prepared)
+	if err != nil {
+		b.Close()
+		return nil, nil, err
+	}
+	for _, path := range cfg.LegacyFiles {
+		logger.Warn("odin.registries.toml is deprecated, rename it to odin.toml", "path", path)
+	}
+
+	b.addRegistries(l.registries)
+	b.addRegistries(cfg.Registries)
+
+	if l.offline {
+		b.env = utils.CreateOfflineCueEnvironment(l.cacheDir, b.Registries())
+	} else {
+		b.env = utils.CreateCueEnvironment(l.cacheDir, b.Registries())
+	}
+
+	logger.Debug("using CUE environment", "env", b.env)
+
+	return b, cfg, nil
+}
+
+// loadSetFiles reads the files of setFiles and applies their contents as
+// values.
+func (b *Bundle) loadSetFiles(setFiles []setFile) (*Bundle, error) {
+	defer lock(b.mu)()
+	values := b.ctx.CompileString("{}")
+	for _, sf := range setFiles {
+		data, err := os.ReadFile(sf.file)
+		if err != nil {
+			return nil, fmt.Errorf("reading value for %s: %w", sf.path, err)
+		}
+		if !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+			return nil, fmt.Errorf("reading value for %s: %s is a binary file; base64-encode it and use a values file instead", sf.path, sf.file)
+		}
+		values = values.FillPath(sf.path, string(data))
+	}
+	if err := values.Err(); err != nil {
+		return nil, err
+	}
+	return b.applyValues(values), nil
+}
+
+// StdinLocation is the bundle path that makes LoadBundle read the bundle from
+// standard input (or the reader given to WithStdin), as a txtar archive of
+// its files or a single CUE file. A bundle without a cue.mod/module.cue is
+// given one without dependencies, so bundles that import other modules must
+// be passed as a txtar archive that includes it.
+const StdinLocation = source.StdinLocation
+
 func LoadBundle(bundlePath string, options ...Option) (*Bundle, error) {
+	l, err := newBundleLoader(bundlePath, options)
+	if err != nil {
+		return nil, err
+	}
+	return l.Load()
+}
+
+// newBundleLoader applies options to a loader for the bundle at bundlePath.
+func newBundleLoader(bundlePath string, options []Option) (*bundleLoader, error) {
 	l := &bundleLoader{}
 
 	// Apply options first so we have logger if needed
@@ -209,23 +474,70 @@ func LoadBundle(bundlePath string, options ...Option) (*Bundle, error) {
 		}
 	}
 
+	if l.logger == nil {
+		l.logger = discardLogger()
+	}
+
 	// Create source with logger
-	if src, err := source.New(bundlePath, l.logger); err != nil {
+	if bundlePath == StdinLocation {
+		if l.stdin == nil {
+			l.stdin = os.Stdin
+		}
+		l.source = source.NewStdin(l.stdin)
+	} else if src, err := source.New(bundlePath, l.logger); err != nil {
 		return nil, err
 	} else {
 		l.source = src
 	}
 
-	return l.Load()
+	return l, nil
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 }
 
+// Bundle is a loaded bundle. It is safe for concurrent use: operations that
+// evaluate CUE are serialised by a mutex shared with the bundles derived from
+// it (e.g. by LoadValues) and with the components, resources and templates it
+// returns. cue.Values handed out directly, such as from Value,
+// Component.Config or ComponentTemplate.Value, are not guarded and must not
+// be evaluated concurrently. Bundles sharing a cue.Context supplied through
+// WithContext do not share a mutex.
 type Bundle struct {
-	ctx        *cue.Context
-	env        []string
-	value      cue.Value
-	registries map[string]string
-	sourcePath string
-	logger     *slog.Logger
+	// mu guards evaluation on ctx, which isn't safe for concurrent use.
+	mu    *sync.Mutex
+	ctx   *cue.Context
+	env   []string
+	value cue.Value
+	// schemaValue is value before any values overlays were applied, and
+	// values is the unification of those overlays. Both are zero until
+	// LoadValues is called.
+	schemaValue   cue.Value
+	values        cue.Value
+	registries    map[string]string
+	sourcePath    string
+	logger        *slog.Logger
+	templateScope TemplateScope
+	// componentBases are the definitions templates are recognised by;
+	// empty means DefaultComponentBase.
+	componentBases []string
+	// excludeDeps are patterns of dependencies discovery skips.
+	excludeDeps []string
+	// packagePatterns select the packages of each module discovery builds.
+	packagePatterns []string
+	// strictDiscovery makes discovery fail on packages that don't build.
+	strictDiscovery bool
+	// onlyPackage, if set, limits discovery to the package with this
+	// unversioned import path; see FindComponentTemplate.
+	onlyPackage string
+	timings     *Timings
+	// closer removes the temporary directory a prepared source was written
+	// to; see Close. Copies of the bundle share it.
+	closer io.Closer
+	// baseCache holds the component bases once loaded; see
+	// loadComponentBases. Copies of the bundle share it, as they share ctx.
+	baseCache *componentBaseCache
 }
 
 func newBundle(cuectx *cue.Context) (*Bundle, error) {
@@ -234,19 +546,45 @@ func newBundle(cuectx *cue.Context) (*Bundle, error) {
 	}
 
 	b := &Bundle{
+		mu:         &sync.Mutex{},
 		ctx:        cuectx,
 		env:        make([]string, 0, 4),
 		registries: make(map[string]string),
+		baseCache:  &componentBaseCache{},
 	}
 
 	return b, nil
 }
 
+// lock acquires mu, if set, and returns the function releasing it. Values
+// built outside a Bundle have no mutex and are not locked.
+func lock(mu *sync.Mutex) (unlock func()) {
+	if mu == nil {
+		return func() {}
+	}
+	mu.Lock()
+	return mu.Unlock
+}
+
+// Close removes the temporary directory a bundle loaded from an OCI
+// reference, a git repository or stdin was written to. Templates and
+// packages can no longer be discovered from such a bundle once it is closed.
+// Copies of the bundle, such as those returned by LoadValues, share the
+// directory. Close does nothing for a bundle loaded from a local directory.
+func (b *Bundle) Close() error {
+	if b.closer == nil {
+		return nil
+	}
+	return b.closer.Close()
+}
+
 func (b *Bundle) GoString() string {
+	defer lock(b.mu)()
 	return fmt.Sprintf("#Bundle & %v", b.value)
 }
 
 func (b *Bundle) LoadValues(src source.Source) (*Bundle, error) {
+	defer lock(b.mu)()
 	values, err := src.Load(b.ctx, &source.LoadOptions{
 		Env:                   b.env,
 		InstanceConfiguration: configureValuesInstance,
@@ -254,38 +592,61 @@ func (b *Bundle) LoadValues(src source.Source) (*Bundle, error) {
 	if err != nil {
 		return nil, err
 	}
+	return b.applyValues(values), nil
+}
 
-	value := b.value.FillPath(cue.ParsePath("values"), values)
-
-	newBundle := &Bundle{
-		ctx:        b.ctx,
-		env:        b.env,
-		value:      value,
-		registries: b.registries,
-		sourcePath: b.sourcePath,
-		logger:     b.logger,
+// applyValues returns a copy of the bundle with values unified into its
+// values, keeping track of the schema they were applied to. The caller must
+// hold mu.
+func (b *Bundle) applyValues(values cue.Value) *Bundle {
+	newBundle := b.withValue(b.value.FillPath(cue.ParsePath("values"), values))
+	if !b.schemaValue.Exists() {
+		newBundle.schemaValue = b.value
+		newBundle.values = values
+	} else {
+		newBundle.values = b.values.Unify(values)
 	}
-	return newBundle, nil
+	return newBundle
+}
+
+// withValue returns a copy of the bundle with its value replaced, preserving
+// the context, environment and other loader state. The copy shares the
+// bundle's mutex, since it shares its context.
+func (b *Bundle) withValue(value cue.Value) *Bundle {
+	newBundle := *b
+	newBundle.value = value
+	return &newBundle
 }
 
 func (b *Bundle) Components() iter.Seq[*Component] {
 	return func(yield func(*Component) bool) {
+		// Collect the components before yielding so the lock isn't held
+		// while the caller's loop body runs.
+		unlock := lock(b.mu)
+		var components []*Component
 		i, err := b.value.LookupPath(cue.ParsePath("components")).Fields(cue.Definitions(false))
-		if err != nil {
-			return
+		if err == nil {
+			for i.Next() {
+				components = append(components, newComponent(b.mu, i.Selector(), i.Value()))
+			}
 		}
+		unlock()
 
-		for i.Next() {
-			yield(newComponent(i.Selector(), i.Value()))
+		for _, component := range components {
+			if !yield(component) {
+				return
+			}
 		}
 	}
 }
 
 func (b *Bundle) Error() error {
+	defer lock(b.mu)()
 	return b.value.Err()
 }
 
 func (b *Bundle) Name() string {
+	defer lock(b.mu)()
 	if name, err := b.value.LookupPath(cue.ParsePath("metadata.name")).String(); err != nil {
 		return "<error>"
 	} else {
@@ -298,6 +659,68 @@ func (b *Bundle) Value() cue.Value {
 	return b.value
 }
 
+// ModuleFile returns the parsed cue.mod/module.cue of the CUE module
+// containing the bundle.
+func (b *Bundle) ModuleFile() (*modfile.File, error) {
+	_, moduleFile, err := loadModuleFile(b.sourcePath)
+	return moduleFile, err
+}
+
+// Revision identifies the commit a bundle was loaded from.
+type Revision struct {
+	// Commit is the full SHA of the checked out commit.
+	Commit string
+	// Dirty reports whether the worktree had uncommitted changes.
+	Dirty bool
+}
+
+func (r Revision) String() string {
+	if r.Dirty {
+		return r.Commit + "-dirty"
+	}
+	return r.Commit
+}
+
+// SourceRevision returns the git revision of the repository containing the
+// bundle. It returns false if the bundle isn't inside a git worktree.
+func (b *Bundle) SourceRevision() (Revision, bool) {
+	repo, err := git.OpenPath(b.sourcePath)
+	if err != nil {
+		b.logger.Debug("bundle is not in a git repository", "path", b.sourcePath, "err", err)
+		return Revision{}, false
+	}
+	commit, dirty, err := git.HeadRevision(repo)
+	if err != nil {
+		b.logger.Debug("failed to read git revision", "path", b.sourcePath, "err", err)
+		return Revision{}, false
+	}
+	return Revision{Commit: commit, Dirty: dirty}, true
+}
+
+// Dependency is a CUE module required by a bundle's module.
+type Dependency struct {
+	Path    string
+	Version string
+}
+
+// Dependencies returns the modules required by the bundle's cue.mod/module.cue,
+// sorted by module path.
+func (b *Bundle) Dependencies() ([]Dependency, error) {
+	moduleFile, err := b.ModuleFile()
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]Dependency, 0, len(moduleFile.Deps))
+	for path, dep := range moduleFile.Deps {
+		deps = append(deps, Dependency{Path: path, Version: dep.Version})
+	}
+	slices.SortFunc(deps, func(a, b Dependency) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return deps, nil
+}
+
 func (b *Bundle) addRegistries(registries map[string]string) {
 	if b.registries != nil {
 		maps.Copy(b.registries, registries)
@@ -309,14 +732,24 @@ func (b *Bundle) Registries() map[string]string {
 }
 
 // ValuesSchema returns the schema fields for the bundle's values section,
-// with validation pattern constraints filtered out.
-func (b *Bundle) ValuesSchema() []*pkgschema.SchemaField {
-	valuesValue := b.value.LookupPath(cue.ParsePath("values"))
+// with validation pattern constraints filtered out. If values were loaded, the
+// fields describe the schema they were applied to and each leaf records the
+// origin of its effective value.
+func (b *Bundle) ValuesSchema(opts ...pkgschema.WalkOption) []*pkgschema.SchemaField {
+	defer lock(b.mu)()
+	schemaValue := b.value
+	if b.schemaValue.Exists() {
+		schemaValue = b.schemaValue
+	}
+	valuesValue := schemaValue.LookupPath(cue.ParsePath("values"))
 	if !valuesValue.Exists() || valuesValue.Err() != nil {
 		return nil
 	}
-	fields := pkgschema.WalkSchema(valuesValue)
+	fields := pkgschema.WalkSchema(valuesValue, opts...)
 	filterValuesSchemaPatterns(fields)
+	if b.values.Exists() {
+		pkgschema.AnnotateOrigins(fields, b.values)
+	}
 	return fields
 }
 
@@ -0,0 +1,1717 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cuelabs.dev/go/oci/ociregistry/ocimem"
+	"cuelabs.dev/go/oci/ociregistry/ociserver"
+	"cuelang.org/go/cue"
+	"cuelang.org/go/mod/modregistrytest"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"go-valkyrie.com/odin/pkg/oci"
+	pkgschema "go-valkyrie.com/odin/pkg/schema"
+)
+
+func TestLoadBundleOCISourceUsesLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	// Nothing listens on port 1, so the pull fails fast; we only care that the
+	// OCI source was constructed with the configured logger.
+	_, err := LoadBundle("oci://localhost:1/org/bundle:v1", WithLogger(logger))
+	if err == nil {
+		t.Fatal("LoadBundle() expected error pulling from unreachable registry")
+	}
+	if !strings.Contains(err.Error(), "failed to prepare source") {
+		t.Errorf("LoadBundle() error = %v, want prepare failure", err)
+	}
+
+	if !strings.Contains(buf.String(), "pulling bundle") {
+		t.Errorf("expected OCI source to log through configured logger, got: %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "localhost:1/org/bundle:v1") {
+		t.Errorf("expected log output to include reference, got: %q", buf.String())
+	}
+}
+
+func TestLoadBundleOCIRejectsNonBundle(t *testing.T) {
+	server := httptest.NewServer(ociserver.New(ocimem.New(), nil))
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	host := net.JoinHostPort("localhost", serverURL.Port())
+
+	// A directory of CUE without a cue.mod pushes fine but isn't a bundle.
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "bundle.cue"), []byte("package bundle\n"), 0644); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ref := &oci.Reference{Registry: host, Repository: "org/notabundle", Reference: "v1"}
+	if _, err := oci.Push(context.Background(), ref, src, nil, logger); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	_, err = LoadBundle("oci://"+ref.String(), WithLogger(logger))
+	if err == nil {
+		t.Fatal("LoadBundle() expected error for an artifact without cue.mod")
+	}
+	want := "pulled artifact is not a valid odin bundle: missing cue.mod/module.cue"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("LoadBundle() error = %v, want %q", err, want)
+	}
+}
+
+// platformModule is a dependency module providing component templates built
+// on the odin API.
+var platformModule = map[string]string{
+	"cue.mod/module.cue": `module: "example.com/platform@v0"
+language: version: "v0.14.0"
+deps: "go-valkyrie.com/odin/api@v0": v: "v0.0.0-test"
+`,
+	"workload/workload.cue": `package workload
+
+import odin "go-valkyrie.com/odin/api/v1alpha1"
+
+// #WebApp is a Deployment with a Service.
+#WebApp: C=odin.#Component & {
+	config: {
+		image:    string
+		replicas: uint
+		port:     uint | *80
+	}
+
+	resources: deployment: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		metadata: name: C.metadata.name
+		spec: replicas: C.config.replicas
+	}
+}
+
+#Deployment: C=odin.#Component & {
+	config: image: string
+
+	resources: deployment: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		metadata: name: C.metadata.name
+	}
+}
+`,
+}
+
+// writeFiles writes files, keyed by slash-separated relative path, under dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// setupTemplateBundle writes a bundle depending on platformModule and the odin
+// API, both served from an in-process registry, and returns the bundle
+// directory along with loader options pointing at the registry.
+func setupTemplateBundle(t *testing.T, bundleCue string) (string, []Option) {
+	t.Helper()
+
+	apiPath, err := filepath.Abs(filepath.Join("..", "..", "api"))
+	if err != nil {
+		t.Fatalf("failed to resolve api path: %v", err)
+	}
+
+	// modregistrytest serves each directory named <module_path>_<version>.
+	registryDir := t.TempDir()
+	if err := os.CopyFS(filepath.Join(registryDir, "go-valkyrie.com_odin_api_v0.0.0-test"), os.DirFS(apiPath)); err != nil {
+		t.Fatalf("failed to copy odin API module: %v", err)
+	}
+	writeFiles(t, filepath.Join(registryDir, "example.com_platform_v0.0.0-test"), platformModule)
+
+	registry, err := modregistrytest.New(os.DirFS(registryDir), "")
+	if err != nil {
+		t.Fatalf("failed to start registry: %v", err)
+	}
+	t.Cleanup(registry.Close)
+	host := registry.Host()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/bundle@v0"
+language: version: "v0.14.0"
+deps: {
+	"example.com/platform@v0": v: "v0.0.0-test"
+	"go-valkyrie.com/odin/api@v0": v: "v0.0.0-test"
+}
+`,
+		"bundle.cue": bundleCue,
+	})
+
+	return dir, []Option{
+		WithRegistries(map[string]string{
+			"go-valkyrie.com": host,
+			"example.com":     host,
+		}),
+		WithCacheDir(t.TempDir()),
+	}
+}
+
+const webAppBundle = `package bundle
+
+import (
+	odin "go-valkyrie.com/odin/api/v1alpha1"
+	"example.com/platform/workload"
+)
+
+odin.#Bundle & {
+	metadata: name: "webapp"
+
+	components: myapp: workload.#WebApp & {
+		metadata: name: "myapp"
+	}
+}
+`
+
+func TestLoadValuesPreservesEnvironment(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+
+	valuesPath := filepath.Join(t.TempDir(), "values.yaml")
+	values := `components:
+  myapp:
+    image: nginx:latest
+    replicas: 2
+`
+	if err := os.WriteFile(valuesPath, []byte(values), 0644); err != nil {
+		t.Fatalf("failed to write values file: %v", err)
+	}
+
+	b, err := LoadBundle(dir, append(opts, WithValues(valuesPath))...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	if err := b.Error(); err != nil {
+		t.Fatalf("bundle error = %v", err)
+	}
+	if len(b.env) == 0 {
+		t.Fatal("bundle lost its CUE environment after loading values")
+	}
+
+	var names []string
+	for tmpl, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			t.Fatalf("ComponentTemplates() error = %v", err)
+		}
+		names = append(names, tmpl.Name)
+	}
+	slices.Sort(names)
+
+	want := []string{"#Deployment", "#WebApp"}
+	if !slices.Equal(names, want) {
+		t.Errorf("ComponentTemplates() = %v, want %v", names, want)
+	}
+}
+
+// writePlainBundle writes a bundle without dependencies whose single component
+// takes its config from values.
+func writePlainBundle(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/plain@v0"
+language: version: "v0.14.0"
+`,
+		"bundle.cue": `package bundle
+
+metadata: name: "plain"
+
+components: app: {
+	apiVersion: "example.com/v1"
+	kind:       "App"
+	metadata: name: "app"
+	config: {
+		image:    string
+		replicas: int | *1
+	}
+	resources: {}
+}
+`,
+	})
+	return dir
+}
+
+func TestWithValuesMultipleLocations(t *testing.T) {
+	valuesDir := t.TempDir()
+	writeFiles(t, valuesDir, map[string]string{
+		"image.txt":     "components:\n  app:\n    image: nginx:latest\n",
+		"replicas.data": `{"components": {"app": {"replicas": 3}}}`,
+		"extra.yaml":    "components:\n  app:\n    image: nginx:latest\n",
+		"values.conf":   "[components.app]\nimage = \"nginx:toml\"\nreplicas = 2\n",
+		"replicas.cue":  "components: app: replicas: 5\n",
+	})
+
+	tests := []struct {
+		name         string
+		options      []Option
+		wantImage    string
+		wantReplicas int64
+		wantErr      bool
+	}{
+		{
+			name: "format prefixes in a single call",
+			options: []Option{
+				WithValues(
+					"yaml: "+filepath.Join(valuesDir, "image.txt"),
+					"json: "+filepath.Join(valuesDir, "replicas.data"),
+				),
+			},
+			wantImage:    "nginx:latest",
+			wantReplicas: 3,
+		},
+		{
+			name: "repeated calls accumulate",
+			options: []Option{
+				WithValues("yaml: " + filepath.Join(valuesDir, "image.txt")),
+				WithValues(filepath.Join(valuesDir, "extra.yaml")),
+			},
+			wantImage:    "nginx:latest",
+			wantReplicas: 1,
+		},
+		{
+			name: "toml format prefix",
+			options: []Option{
+				WithValues("toml: " + filepath.Join(valuesDir, "values.conf")),
+			},
+			wantImage:    "nginx:toml",
+			wantReplicas: 2,
+		},
+		{
+			name: "cue and data files unify",
+			options: []Option{
+				WithValues(
+					filepath.Join(valuesDir, "replicas.cue"),
+					"yaml: "+filepath.Join(valuesDir, "image.txt"),
+				),
+			},
+			wantImage:    "nginx:latest",
+			wantReplicas: 5,
+		},
+		{
+			name: "unsupported format prefix",
+			options: []Option{
+				WithValues("xml: " + filepath.Join(valuesDir, "image.txt")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing file",
+			options: []Option{
+				WithValues(filepath.Join(valuesDir, "missing.yaml")),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := LoadBundle(writePlainBundle(t), tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadBundle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			config := b.Value().LookupPath(cue.ParsePath("components.app.config"))
+			image, err := config.LookupPath(cue.ParsePath("image")).String()
+			if err != nil {
+				t.Fatalf("config.image: %v", err)
+			}
+			if image != tt.wantImage {
+				t.Errorf("config.image = %q, want %q", image, tt.wantImage)
+			}
+			replicas, _ := config.LookupPath(cue.ParsePath("replicas")).Default()
+			if got, err := replicas.Int64(); err != nil {
+				t.Fatalf("config.replicas: %v", err)
+			} else if got != tt.wantReplicas {
+				t.Errorf("config.replicas = %d, want %d", got, tt.wantReplicas)
+			}
+		})
+	}
+}
+
+func TestWithValuesK8sManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/k8s@v0"
+language: version: "v0.14.0"
+`,
+		"bundle.cue": `package bundle
+
+metadata: name: "k8s"
+
+values: {
+	image:    string
+	password: string | *""
+}
+
+components: app: {
+	config: {
+		image:    values.image
+		password: values.password
+	}
+	resources: {}
+}
+`,
+	})
+	valuesDir := t.TempDir()
+	writeFiles(t, valuesDir, map[string]string{
+		"configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-values
+data:
+  image: nginx:1.27
+  app.properties: |
+    debug=true
+`,
+		"secret.yaml": `apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+type: Opaque
+data:
+  password: aHVudGVyMg==
+`,
+		"deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`,
+	})
+
+	b, err := LoadBundle(dir, WithLogger(discardLogger()), WithValues(
+		"k8s: "+filepath.Join(valuesDir, "configmap.yaml"),
+		"k8s: "+filepath.Join(valuesDir, "secret.yaml"),
+	))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	if err := b.Error(); err != nil {
+		t.Fatalf("bundle error = %v", err)
+	}
+
+	for path, want := range map[string]string{
+		"components.app.config.image":    "nginx:1.27",
+		"components.app.config.password": "hunter2",
+		`values."app.properties"`:        "debug=true\n",
+	} {
+		got, err := b.Value().LookupPath(cue.ParsePath(path)).String()
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+		} else if got != want {
+			t.Errorf("%s = %q, want %q", path, got, want)
+		}
+	}
+
+	_, err = LoadBundle(dir, WithLogger(discardLogger()), WithValues("k8s: "+filepath.Join(valuesDir, "deployment.yaml")))
+	if err == nil || !strings.Contains(err.Error(), "must be a ConfigMap or Secret") {
+		t.Errorf("LoadBundle(deployment) error = %v, want a ConfigMap or Secret error", err)
+	}
+}
+
+func TestWithSetFiles(t *testing.T) {
+	filesDir := t.TempDir()
+	writeFiles(t, filesDir, map[string]string{
+		"image.txt":   "registry.example.com/app:v1\n",
+		"values.yaml": "components:\n  app:\n    image: nginx:latest\n",
+		"binary.dat":  "\x00\x01\x02",
+	})
+
+	tests := []struct {
+		name      string
+		options   []Option
+		wantImage string
+		wantErr   string
+	}{
+		{
+			name:      "text file",
+			options:   []Option{WithSetFiles("components.app.image=" + filepath.Join(filesDir, "image.txt"))},
+			wantImage: "registry.example.com/app:v1\n",
+		},
+		{
+			name: "conflicts with values file",
+			options: []Option{
+				WithValues(filepath.Join(filesDir, "values.yaml")),
+				WithSetFiles("components.app.image=" + filepath.Join(filesDir, "image.txt")),
+			},
+			wantErr: "conflicting values",
+		},
+		{
+			name:    "missing file",
+			options: []Option{WithSetFiles("components.app.image=" + filepath.Join(filesDir, "missing.txt"))},
+			wantErr: "reading value for components.app.image",
+		},
+		{
+			name:    "binary file",
+			options: []Option{WithSetFiles("components.app.image=" + filepath.Join(filesDir, "binary.dat"))},
+			wantErr: "binary file",
+		},
+		{
+			name:    "missing key",
+			options: []Option{WithSetFiles(filepath.Join(filesDir, "image.txt"))},
+			wantErr: "must be key=file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := LoadBundle(writePlainBundle(t), append(tt.options, WithLogger(discardLogger()))...)
+			if err == nil && tt.wantErr != "" {
+				err = b.Error()
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+
+			image, err := b.Value().LookupPath(cue.ParsePath("components.app.config.image")).String()
+			if err != nil {
+				t.Fatalf("config.image: %v", err)
+			}
+			if image != tt.wantImage {
+				t.Errorf("config.image = %q, want %q", image, tt.wantImage)
+			}
+		})
+	}
+}
+
+func TestComponentTemplatesScope(t *testing.T) {
+	bundleCue := webAppBundle + `
+// #Worker is a template defined in the bundle's own module.
+#Worker: odin.#Component & {
+	config: queue: string
+	resources: {}
+}
+`
+	dir, opts := setupTemplateBundle(t, bundleCue)
+
+	tests := []struct {
+		name  string
+		scope TemplateScope
+		want  []string
+	}{
+		{
+			name:  "all",
+			scope: ScopeAll,
+			want:  []string{"#Deployment", "#WebApp", "#Worker"},
+		},
+		{
+			name:  "dependencies",
+			scope: ScopeDependencies,
+			want:  []string{"#Deployment", "#WebApp"},
+		},
+		{
+			name:  "local",
+			scope: ScopeLocal,
+			want:  []string{"#Worker"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := LoadBundle(dir, append(opts, WithTemplateScope(tt.scope))...)
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+
+			var names []string
+			for tmpl, err := range b.ComponentTemplates(context.Background()) {
+				if err != nil {
+					t.Fatalf("ComponentTemplates() error = %v", err)
+				}
+				if wantLocal := tmpl.Name == "#Worker"; tmpl.Local != wantLocal {
+					t.Errorf("%s: Local = %v, want %v", tmpl.Name, tmpl.Local, wantLocal)
+				}
+				names = append(names, tmpl.Name)
+			}
+			slices.Sort(names)
+
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("ComponentTemplates() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTemplateScope(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    TemplateScope
+		wantErr bool
+	}{
+		{input: "", want: ScopeAll},
+		{input: "all", want: ScopeAll},
+		{input: "dependencies", want: ScopeDependencies},
+		{input: "local", want: ScopeLocal},
+		{input: "remote", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTemplateScope(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTemplateScope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTemplateScope() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundleDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []Dependency
+	}{
+		{
+			name: "sorted by path",
+			files: map[string]string{
+				"cue.mod/module.cue": `module: "test.example.com/bundle@v0"
+language: version: "v0.14.0"
+deps: {
+	"go-valkyrie.com/odin/api@v0": v: "v0.2.0"
+	"example.com/platform@v0": v: "v0.1.0"
+}
+`,
+				"bundle.cue": "package bundle\n",
+			},
+			want: []Dependency{
+				{Path: "example.com/platform@v0", Version: "v0.1.0"},
+				{Path: "go-valkyrie.com/odin/api@v0", Version: "v0.2.0"},
+			},
+		},
+		{
+			name: "no dependencies",
+			files: map[string]string{
+				"cue.mod/module.cue": `module: "test.example.com/bundle@v0"
+language: version: "v0.14.0"
+`,
+				"bundle.cue": "package bundle\n",
+			},
+			want: []Dependency{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			b := &Bundle{sourcePath: dir}
+			got, err := b.Dependencies()
+			if err != nil {
+				t.Fatalf("Dependencies() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// commitFiles writes files into the worktree of repo, commits them and
+// returns the commit hash.
+func commitFiles(t *testing.T, repo *gogit.Repository, dir string, files map[string]string, message string) plumbing.Hash {
+	t.Helper()
+	writeFiles(t, dir, files)
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	if err := wt.AddGlob("."); err != nil {
+		t.Fatalf("failed to stage files: %v", err)
+	}
+	hash, err := wt.Commit(message, &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	return hash
+}
+
+func TestLoadBundleFromGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is required for the file transport")
+	}
+
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+
+	bundleFiles := func(name string) map[string]string {
+		return map[string]string{
+			"bundles/app/cue.mod/module.cue": `module: "test.example.com/app@v0"
+language: version: "v0.14.0"
+`,
+			"bundles/app/bundle.cue": fmt.Sprintf("package bundle\n\nmetadata: name: %q\n", name),
+			"README.md":              "unrelated\n",
+		}
+	}
+
+	first := commitFiles(t, repo, repoDir, bundleFiles("first"), "first")
+	if _, err := repo.CreateTag("v1", first, nil); err != nil {
+		t.Fatalf("failed to tag: %v", err)
+	}
+	if _, err := repo.CreateTag("v1-annotated", first, &gogit.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		Message: "v1",
+	}); err != nil {
+		t.Fatalf("failed to tag: %v", err)
+	}
+	commitFiles(t, repo, repoDir, bundleFiles("second"), "second")
+
+	// The file transport doesn't serve commits by hash, so checking out a
+	// commit falls back to a full clone; branches and tags are cloned
+	// shallow.
+	tests := []struct {
+		name        string
+		location    string
+		wantName    string
+		wantShallow bool
+	}{
+		{name: "tag", location: "git+file://" + repoDir + "#v1:bundles/app", wantName: "first", wantShallow: true},
+		{name: "annotated tag", location: "git+file://" + repoDir + "#v1-annotated:bundles/app", wantName: "first", wantShallow: true},
+		{name: "commit", location: "git+file://" + repoDir + "#" + first.String() + ":bundles/app", wantName: "first"},
+		{name: "branch", location: "git+file://" + repoDir + "#master:bundles/app", wantName: "second", wantShallow: true},
+		{name: "default branch", location: "git+file://" + repoDir + "#:bundles/app", wantName: "second", wantShallow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := LoadBundle(tt.location, WithCacheDir(t.TempDir()))
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+			if got := b.Name(); got != tt.wantName {
+				t.Errorf("Name() = %q, want %q", got, tt.wantName)
+			}
+			checkout := filepath.Join(b.sourcePath, "..", "..")
+			if _, err := os.Stat(filepath.Join(checkout, "README.md")); err == nil {
+				t.Error("sparse checkout included files outside the bundle directory")
+			}
+			if _, err := os.Stat(filepath.Join(checkout, ".git", "shallow")); (err == nil) != tt.wantShallow {
+				t.Errorf("shallow clone = %v, want %v", err == nil, tt.wantShallow)
+			}
+
+			if err := b.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if _, err := os.Stat(checkout); !os.IsNotExist(err) {
+				t.Errorf("checkout %s still exists after Close(), stat error = %v", checkout, err)
+			}
+		})
+	}
+}
+
+func TestBundleSourceRevision(t *testing.T) {
+	plainDir := writePlainBundle(t)
+	b := &Bundle{sourcePath: plainDir, logger: discardLogger()}
+	if _, ok := b.SourceRevision(); ok {
+		t.Error("SourceRevision() ok = true outside a git repository")
+	}
+
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+	hash := commitFiles(t, repo, repoDir, map[string]string{
+		"bundle/cue.mod/module.cue": "module: \"test.example.com/app@v0\"\n",
+		"bundle/bundle.cue":         "package bundle\n",
+	}, "initial")
+
+	b = &Bundle{sourcePath: filepath.Join(repoDir, "bundle"), logger: discardLogger()}
+	rev, ok := b.SourceRevision()
+	if !ok {
+		t.Fatal("SourceRevision() ok = false inside a git repository")
+	}
+	if rev.Commit != hash.String() || rev.Dirty {
+		t.Errorf("SourceRevision() = %+v, want clean %s", rev, hash)
+	}
+
+	writeFiles(t, repoDir, map[string]string{"bundle/bundle.cue": "package bundle\n\nx: 1\n"})
+	rev, ok = b.SourceRevision()
+	if !ok || !rev.Dirty {
+		t.Errorf("SourceRevision() = %+v, %v, want dirty", rev, ok)
+	}
+	if want := hash.String() + "-dirty"; rev.String() != want {
+		t.Errorf("String() = %q, want %q", rev.String(), want)
+	}
+}
+
+func TestComponentTemplatesBases(t *testing.T) {
+	bundleCue := webAppBundle + `
+#CronBase: {
+	apiVersion: "batch/v1"
+	kind:       "CronJob"
+	schedule:   string
+}
+
+// #Nightly conforms to the custom #CronBase rather than #ComponentBase.
+#Nightly: #CronBase & {
+	schedule: "0 0 * * *"
+}
+`
+	dir, opts := setupTemplateBundle(t, bundleCue)
+	cronBase := "test.example.com/bundle:#CronBase"
+
+	tests := []struct {
+		name    string
+		bases   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "default",
+			want: []string{"#Deployment", "#WebApp"},
+		},
+		{
+			name:  "custom base only",
+			bases: []string{cronBase},
+			want:  []string{"#Nightly"},
+		},
+		{
+			name:  "any of several bases",
+			bases: []string{DefaultComponentBase, cronBase},
+			want:  []string{"#Deployment", "#Nightly", "#WebApp"},
+		},
+		{
+			name:    "missing definition",
+			bases:   []string{"test.example.com/bundle:#Missing"},
+			wantErr: true,
+		},
+		{
+			name:    "package not found",
+			bases:   []string{"example.com/missing:#Base"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadOpts := append(slices.Clone(opts), WithComponentBases(tt.bases))
+			b, err := LoadBundle(dir, loadOpts...)
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+
+			var names []string
+			for tmpl, err := range b.ComponentTemplates(context.Background()) {
+				if err != nil {
+					if !tt.wantErr {
+						t.Fatalf("ComponentTemplates() error = %v", err)
+					}
+					return
+				}
+				wantBase := DefaultComponentBase
+				if tmpl.Name == "#Nightly" {
+					wantBase = cronBase
+				}
+				if tmpl.Base != wantBase {
+					t.Errorf("%s: Base = %q, want %q", tmpl.Name, tmpl.Base, wantBase)
+				}
+				names = append(names, tmpl.Name)
+			}
+			if tt.wantErr {
+				t.Fatal("ComponentTemplates() returned no error")
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("templates = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponentBasesCached(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	b, err := LoadBundle(dir, opts...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	first, err := b.loadComponentBases()
+	if err != nil || len(first) != 1 {
+		t.Fatalf("loadComponentBases() = %v, %v, want one base", first, err)
+	}
+	for _, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			t.Fatalf("ComponentTemplates() error = %v", err)
+		}
+	}
+
+	// Copies of the bundle, such as those made when applying values, share
+	// the cache.
+	copied := b.withValue(b.Value())
+	second, err := copied.loadComponentBases()
+	if err != nil {
+		t.Fatalf("loadComponentBases() error = %v", err)
+	}
+	if &second[0] != &first[0] {
+		t.Error("loadComponentBases() reloaded the bases instead of using the cache")
+	}
+
+	// A bundle loaded with another context can't use them.
+	other, err := LoadBundle(dir, opts...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	other.baseCache = b.baseCache
+	third, err := other.loadComponentBases()
+	if err != nil {
+		t.Fatalf("loadComponentBases() error = %v", err)
+	}
+	if &third[0] == &first[0] {
+		t.Error("loadComponentBases() used bases cached for another context")
+	}
+}
+
+func TestWithComponentBasesValidates(t *testing.T) {
+	l := &bundleLoader{}
+	if err := WithComponentBases([]string{"example.com/api"})(l); err == nil {
+		t.Error("WithComponentBases() accepted a base without a definition")
+	}
+}
+
+func TestComponentTemplatesExcludeDependencies(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     int
+	}{
+		{name: "none", want: 2},
+		{name: "exact", patterns: []string{"example.com/platform"}, want: 0},
+		{name: "glob", patterns: []string{"example.com/*"}, want: 0},
+		{name: "prefix", patterns: []string{"example.com"}, want: 0},
+		{name: "no match", patterns: []string{"example.com/plat"}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := LoadBundle(dir, append(opts, WithExcludeDependencies(tt.patterns))...)
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+			var names []string
+			for tmpl, err := range b.ComponentTemplates(context.Background()) {
+				if err != nil {
+					t.Fatalf("ComponentTemplates() error = %v", err)
+				}
+				names = append(names, tmpl.Name)
+			}
+			if len(names) != tt.want {
+				t.Errorf("ComponentTemplates() = %v, want %d templates", names, tt.want)
+			}
+		})
+	}
+
+	l := &bundleLoader{}
+	if err := WithExcludeDependencies([]string{"example.com/["})(l); err == nil {
+		t.Error("WithExcludeDependencies() accepted a malformed pattern")
+	}
+}
+
+func TestComponentTemplatesPackagePatterns(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	template := func(pkg, def string) string {
+		return "package " + pkg + `
+
+import odin "go-valkyrie.com/odin/api/v1alpha1"
+
+` + def + `: odin.#Component & {
+	config: name: string
+	resources: {}
+}
+`
+	}
+	writeFiles(t, dir, map[string]string{
+		"components/web/web.cue":         template("web", "#Web"),
+		"components/internal/helper.cue": template("internal", "#Helper"),
+		"other/other.cue":                template("other", "#Other"),
+	})
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{name: "default", want: []string{"#Helper", "#Other", "#Web"}},
+		{name: "include", patterns: []string{"./components/..."}, want: []string{"#Helper", "#Web"}},
+		{name: "exclude", patterns: []string{"!./other/..."}, want: []string{"#Helper", "#Web"}},
+		{name: "include and exclude", patterns: []string{"./components/...", "!./components/internal"}, want: []string{"#Web"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := LoadBundle(dir, append(opts, WithTemplateScope(ScopeLocal), WithPackagePatterns(tt.patterns))...)
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+			var names []string
+			for tmpl, err := range b.ComponentTemplates(context.Background()) {
+				if err != nil {
+					t.Fatalf("ComponentTemplates() error = %v", err)
+				}
+				names = append(names, tmpl.Name)
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("ComponentTemplates() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+// TestComponentTemplatesPackagePatternsAcrossModules checks that include
+// patterns matching nothing in some of the modules scanned are neither
+// warned about nor, with strict discovery, errors.
+func TestComponentTemplatesPackagePatternsAcrossModules(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	writeFiles(t, dir, map[string]string{
+		"components/web/web.cue": `package web
+
+import odin "go-valkyrie.com/odin/api/v1alpha1"
+
+#Web: odin.#Component & {
+	config: name: string
+	resources: {}
+}
+`,
+	})
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		// Only the bundle's own module has a components directory.
+		{name: "local only", patterns: []string{"./components/..."}, want: []string{"#Web"}},
+		// Only the platform dependency has a workload package.
+		{name: "dependency only", patterns: []string{"./workload"}, want: []string{"#Deployment", "#WebApp"}},
+	}
+
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s strict=%v", tt.name, strict), func(t *testing.T) {
+				var buf bytes.Buffer
+				logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+				b, err := LoadBundle(dir, append(slices.Clone(opts),
+					WithTemplateScope(ScopeAll),
+					WithPackagePatterns(tt.patterns),
+					WithStrictDiscovery(strict),
+					WithLogger(logger))...)
+				if err != nil {
+					t.Fatalf("LoadBundle() error = %v", err)
+				}
+				var names []string
+				for tmpl, err := range b.ComponentTemplates(context.Background()) {
+					if err != nil {
+						t.Fatalf("ComponentTemplates() error = %v", err)
+					}
+					names = append(names, tmpl.Name)
+				}
+				slices.Sort(names)
+				if !slices.Equal(names, tt.want) {
+					t.Errorf("ComponentTemplates() = %v, want %v", names, tt.want)
+				}
+				if buf.Len() > 0 {
+					t.Errorf("unexpected warnings: %s", buf.String())
+				}
+			})
+		}
+	}
+}
+
+func TestBundleConcurrentUse(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	b, err := LoadBundle(dir, opts...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	const workers = 4
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var names []string
+			for tmpl, err := range b.ComponentTemplates(context.Background()) {
+				if err != nil {
+					errs <- fmt.Errorf("ComponentTemplates() error = %w", err)
+					return
+				}
+				tmpl.ConfigSchema()
+				names = append(names, tmpl.Name)
+			}
+			if len(names) != 2 {
+				errs <- fmt.Errorf("ComponentTemplates() = %v, want 2 templates", names)
+				return
+			}
+			for component := range b.Components() {
+				for resource := range component.Resources() {
+					if resource.Name() == "" {
+						errs <- fmt.Errorf("resource %v has no name", resource.Selector())
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestResourceToCUE(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/plain@v0"
+language: version: "v0.14.0"
+`,
+		"bundle.cue": `package bundle
+
+metadata: name: "plain"
+
+components: app: {
+	config: {}
+	resources: config: {
+		kind: "ConfigMap"
+		metadata: name: "app"
+		// greeting is shown to visitors.
+		data: greeting: "hello"
+	}
+}
+`,
+	})
+
+	b, err := LoadBundle(dir, WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	var got []string
+	for component := range b.Components() {
+		for resource := range component.Resources() {
+			data, err := resource.ToCUE()
+			if err != nil {
+				t.Fatalf("ToCUE() error = %v", err)
+			}
+			got = append(got, string(data))
+		}
+	}
+
+	want := []string{`{
+	kind: "ConfigMap"
+	metadata: {
+		name: "app"
+	}
+	data: {
+		// greeting is shown to visitors.
+		greeting: "hello"
+	}
+}`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToCUE() = %q, want %q", got, want)
+	}
+}
+
+func TestComponentConfigSchema(t *testing.T) {
+	b, err := LoadBundle(writePlainBundle(t), WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	var components []*Component
+	for component := range b.Components() {
+		components = append(components, component)
+	}
+	if len(components) != 1 {
+		t.Fatalf("Components() returned %d components, want 1", len(components))
+	}
+	app := components[0]
+
+	if replicas, ok := app.Config().LookupPath(cue.ParsePath("replicas")).Default(); !ok {
+		t.Errorf("Config() replicas has no default")
+	} else if n, _ := replicas.Int64(); n != 1 {
+		t.Errorf("Config() replicas default = %d, want 1", n)
+	}
+
+	fields := app.ConfigSchema()
+	got := make(map[string]string)
+	for _, f := range fields {
+		got[f.Name] = f.Type + " " + f.Default
+	}
+	want := map[string]string{"image": "string ", "replicas": "int 1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigSchema() = %v, want %v", got, want)
+	}
+}
+
+func TestComponentDoc(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/plain@v0"
+language: version: "v0.14.0"
+`,
+		"bundle.cue": `package bundle
+
+metadata: name: "plain"
+
+// app serves the public website.
+//
+// It's scaled by the platform team.
+components: app: {
+	config: {}
+	resources: {}
+}
+
+components: worker: {
+	config: {}
+	resources: {}
+}
+`,
+	})
+
+	b, err := LoadBundle(dir, WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for component := range b.Components() {
+		got[component.Selector().String()] = component.Doc()
+	}
+	want := map[string]string{
+		"app":    "app serves the public website.\n\nIt's scaled by the platform team.",
+		"worker": "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Doc() = %q, want %q", got, want)
+	}
+}
+
+func TestValuesSchemaOrigins(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/plain@v0"
+language: version: "v0.14.0"
+`,
+		"bundle.cue": `package bundle
+
+metadata: name: "plain"
+
+values: {
+	image:    string
+	replicas: int | *1
+	debug:    bool | *false
+}
+`,
+	})
+	valuesPath := filepath.Join(t.TempDir(), "values.yaml")
+	writeFiles(t, filepath.Dir(valuesPath), map[string]string{
+		"values.yaml": "image: nginx\nreplicas: 3\n",
+	})
+
+	tests := []struct {
+		name   string
+		values []string
+		want   map[string]pkgschema.Origin
+	}{
+		{
+			name: "no values",
+			want: map[string]pkgschema.Origin{"components": "", "image": "", "replicas": "", "debug": ""},
+		},
+		{
+			name:   "with values",
+			values: []string{valuesPath},
+			want: map[string]pkgschema.Origin{
+				"components": "",
+				"image":      pkgschema.OriginSet,
+				"replicas":   pkgschema.OriginOverridden,
+				"debug":      pkgschema.OriginDefault,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithLogger(discardLogger())}
+			if len(tt.values) > 0 {
+				opts = append(opts, WithValues(tt.values...))
+			}
+			b, err := LoadBundle(dir, opts...)
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+
+			got := make(map[string]pkgschema.Origin)
+			for _, f := range b.ValuesSchema() {
+				got[f.Name] = f.Origin
+				if f.Name == "replicas" && f.Default != "1" {
+					t.Errorf("replicas default = %q, want %q", f.Default, "1")
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValuesSchema() origins = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigSchemaAt(t *testing.T) {
+	bundleCue := webAppBundle + `
+#Database: odin.#Component & {
+	config: {
+		name: string
+		// database configures the connection.
+		database: {
+			host: string
+			pool: size: int | *5
+		}
+	}
+}
+`
+	dir, opts := setupTemplateBundle(t, bundleCue)
+	b, err := LoadBundle(dir, opts...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	var tmpl *ComponentTemplate
+	for candidate, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			t.Fatalf("ComponentTemplates() error = %v", err)
+		}
+		if candidate.Name == "#Database" {
+			tmpl = candidate
+		}
+	}
+	if tmpl == nil {
+		t.Fatal("#Database template not discovered")
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		wantName     string
+		wantChildren int
+		wantErr      string
+	}{
+		{name: "struct", path: "database", wantName: "database", wantChildren: 2},
+		{name: "nested", path: "database.pool", wantName: "pool", wantChildren: 1},
+		{name: "leaf", path: "database.host", wantName: "host"},
+		{name: "missing", path: "cache", wantErr: "available: name, database"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := tmpl.ConfigSchemaAt(tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ConfigSchemaAt() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigSchemaAt() error = %v", err)
+			}
+			if len(fields) != 1 || fields[0].Name != tt.wantName || len(fields[0].Children) != tt.wantChildren {
+				t.Errorf("ConfigSchemaAt() = %+v, want %s with %d children", fields, tt.wantName, tt.wantChildren)
+			}
+		})
+	}
+}
+
+func TestComponentTemplatesStrictDiscovery(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	writeFiles(t, dir, map[string]string{
+		"broken/broken.cue": `package broken
+
+import odin "go-valkyrie.com/odin/api/v1alpha1"
+
+#Broken: odin.#Component & {
+	config: replicas: int & "three"
+	resources: {}
+}
+`,
+	})
+	opts = append(opts, WithTemplateScope(ScopeLocal))
+
+	b, err := LoadBundle(dir, opts...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	for tmpl, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			t.Fatalf("ComponentTemplates() error = %v, want broken package skipped", err)
+		}
+		t.Errorf("ComponentTemplates() yielded %s from a broken package", tmpl.Name)
+	}
+
+	b, err = LoadBundle(dir, append(opts, WithStrictDiscovery(true))...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	var errs []error
+	for _, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "broken") {
+		t.Errorf("ComponentTemplates() errors = %v, want one for the broken package", errs)
+	}
+}
+
+func TestComponentTemplatesWarnsAboutAbstractTemplates(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	writeFiles(t, dir, map[string]string{
+		"abstract/abstract.cue": `package abstract
+
+import odin "go-valkyrie.com/odin/api/v1alpha1"
+
+// #Workload is one of several kinds, so it isn't a template itself.
+#Workload: odin.#ComponentBase & {
+	apiVersion: "example.com/v1"
+	kind:       "Deployment" | "StatefulSet"
+	config: image: string
+}
+
+// #Options is an open struct that happens to unify with the base.
+#Options: {...}
+`,
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	b, err := LoadBundle(dir, append(opts, WithTemplateScope(ScopeLocal), WithLogger(logger))...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	for tmpl, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			t.Fatalf("ComponentTemplates() error = %v", err)
+		}
+		t.Errorf("ComponentTemplates() yielded %s, want no templates", tmpl.Name)
+	}
+
+	if !strings.Contains(buf.String(), "def=#Workload") || !strings.Contains(buf.String(), "not a concrete template") {
+		t.Errorf("expected a warning about #Workload, got: %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "#Options") {
+		t.Errorf("unexpected warning about #Options: %q", buf.String())
+	}
+}
+
+func TestComponentTemplatesMarkedWithAttribute(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	writeFiles(t, dir, map[string]string{
+		"local/local.cue": `package local
+
+import odin "go-valkyrie.com/odin/api/v1alpha1"
+
+// #Workload is listed despite its kind not being concrete, since it's
+// marked as a template.
+#Workload: odin.#ComponentBase & {
+	apiVersion: "example.com/v1"
+	kind:       "Deployment" | "StatefulSet"
+	config: image: string
+} @odin(component)
+
+// #Job is found by unifying with the component base.
+#Job: C=odin.#Component & {
+	config: image: string
+
+	resources: job: {
+		apiVersion: "batch/v1"
+		kind:       "Job"
+		metadata: name: C.metadata.name
+	}
+}
+
+// #Options is an open struct that happens to unify with the base.
+#Options: {...}
+`,
+	})
+
+	b, err := LoadBundle(dir, append(opts, WithTemplateScope(ScopeLocal))...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	bases := map[string]string{}
+	for tmpl, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			t.Fatalf("ComponentTemplates() error = %v", err)
+		}
+		bases[tmpl.Name] = tmpl.Base
+	}
+
+	want := map[string]string{
+		"#Workload": DefaultComponentBase,
+		"#Job":      DefaultComponentBase,
+	}
+	if !maps.Equal(bases, want) {
+		t.Errorf("ComponentTemplates() names and bases = %v, want %v", bases, want)
+	}
+}
+
+func TestComponentTemplatesMarkedNotUnifying(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	writeFiles(t, dir, map[string]string{
+		"local/local.cue": `package local
+
+import odin "go-valkyrie.com/odin/api/v1alpha1"
+
+// #Broken is marked as a template but conflicts with the component base.
+#Broken: {
+	apiVersion: 1
+	kind:       "Deployment"
+} @odin(component)
+
+#Job: odin.#Component & {
+	config: image: string
+}
+`,
+	})
+
+	b, err := LoadBundle(dir, append(opts, WithTemplateScope(ScopeLocal))...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	var names []string
+	for tmpl, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			t.Fatalf("ComponentTemplates() error = %v", err)
+		}
+		names = append(names, tmpl.Name)
+	}
+	if want := []string{"#Job"}; !slices.Equal(names, want) {
+		t.Errorf("ComponentTemplates() = %v, want %v", names, want)
+	}
+
+	b, err = LoadBundle(dir, append(opts, WithTemplateScope(ScopeLocal), WithStrictDiscovery(true))...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	var discoveryErr error
+	for _, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			discoveryErr = err
+			break
+		}
+	}
+	if discoveryErr == nil || !strings.Contains(discoveryErr.Error(), "#Broken") || !strings.Contains(discoveryErr.Error(), "apiVersion") {
+		t.Errorf("ComponentTemplates() strict error = %v, want one naming #Broken and the apiVersion conflict", discoveryErr)
+	}
+}
+
+func TestLoadBundleFromStdin(t *testing.T) {
+	const bundle = `package bundle
+
+metadata: name: "piped"
+
+components: app: {
+	config: {}
+	resources: config: {kind: "ConfigMap", metadata: name: "app"}
+}
+`
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"single file", bundle},
+		{"txtar", "-- cue.mod/module.cue --\nmodule: \"test.example.com/piped@v0\"\nlanguage: version: \"v0.14.0\"\n-- bundle.cue --\n" + bundle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := LoadBundle(StdinLocation, WithStdin(strings.NewReader(tt.input)), WithLogger(discardLogger()))
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+			if b.Name() != "piped" {
+				t.Errorf("Name() = %q, want piped", b.Name())
+			}
+			resources, err := b.Render(context.Background())
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := renderedNames(resources); len(got) != 1 || got[0] != "app.config" {
+				t.Errorf("Render() = %v, want [app.config]", got)
+			}
+		})
+	}
+
+	if _, err := LoadBundle(StdinLocation, WithStdin(strings.NewReader("-- ../escape.cue --\n"+bundle))); err == nil {
+		t.Error("LoadBundle() accepted a file outside the bundle")
+	}
+	if _, err := LoadBundle(StdinLocation, WithStdin(strings.NewReader(""))); err == nil {
+		t.Error("LoadBundle() accepted an empty stdin")
+	}
+}
+
+func TestLoadBundleFromStdinRemovesTempDir(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	leftovers := func() []string {
+		t.Helper()
+		dirs, err := filepath.Glob(filepath.Join(tmp, "odin-stdin-*"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dirs
+	}
+
+	b, err := LoadBundle(StdinLocation, WithStdin(strings.NewReader("package bundle\n\nmetadata: name: \"piped\"\n")), WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	if dirs := leftovers(); len(dirs) != 1 {
+		t.Fatalf("temp directories before Close() = %v, want one", dirs)
+	}
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if dirs := leftovers(); len(dirs) != 0 {
+		t.Errorf("temp directories after Close() = %v, want none", dirs)
+	}
+
+	// A bundle that fails to load is removed straight away.
+	if _, err := LoadBundle(StdinLocation, WithStdin(strings.NewReader("-- odin.toml --\nregistries = [\n-- bundle.cue --\npackage bundle\n")), WithLogger(discardLogger())); err == nil {
+		t.Fatal("LoadBundle() accepted a malformed odin.toml")
+	}
+	if dirs := leftovers(); len(dirs) != 0 {
+		t.Errorf("temp directories after a failed load = %v, want none", dirs)
+	}
+}
+
+func TestLoadBundleFromStdinIgnoresParentConfig(t *testing.T) {
+	// Bundles read from stdin are written under the temp directory, which
+	// here looks like a repository with a malformed odin.toml.
+	tmp := t.TempDir()
+	writeFiles(t, tmp, map[string]string{
+		".git/HEAD": "ref: refs/heads/main\n",
+		"odin.toml": "registries = [\n",
+	})
+	t.Setenv("TMPDIR", tmp)
+
+	input := "package bundle\n\nmetadata: name: \"piped\"\n"
+	if _, err := LoadBundle(StdinLocation, WithStdin(strings.NewReader(input)), WithLogger(discardLogger())); err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+}
+
+func TestLoadBundleOffline(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	cacheDir := t.TempDir()
+	opts = append(opts, WithCacheDir(cacheDir))
+
+	_, err := LoadBundle(dir, append(opts, WithOffline(true))...)
+	if !errors.Is(err, ErrNotCached) {
+		t.Fatalf("LoadBundle() offline with an empty cache error = %v, want ErrNotCached", err)
+	}
+	if want := "module example.com/platform@v0.0.0-test not in cache"; err.Error() != want {
+		t.Errorf("LoadBundle() error = %q, want %q", err, want)
+	}
+
+	// Loading online fills the cache, after which the offline load works.
+	if _, err := LoadBundle(dir, opts...); err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	b, err := LoadBundle(dir, append(opts, WithOffline(true))...)
+	if err != nil {
+		t.Fatalf("LoadBundle() offline with a filled cache error = %v", err)
+	}
+	if err := b.value.Err(); err != nil {
+		t.Errorf("offline bundle value error = %v", err)
+	}
+}
+
+func TestFetchDependencies(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	opts = append(opts, WithCacheDir(t.TempDir()))
+	ctx := context.Background()
+
+	results, err := FetchDependencies(ctx, dir, opts...)
+	if err != nil {
+		t.Fatalf("FetchDependencies() error = %v", err)
+	}
+	want := []string{"example.com/platform@v0", "go-valkyrie.com/odin/api@v0"}
+	if len(results) != len(want) {
+		t.Fatalf("FetchDependencies() = %v, want %d results", results, len(want))
+	}
+	for i, result := range results {
+		if result.Path != want[i] || result.Cached || result.Err != nil {
+			t.Errorf("results[%d] = %+v, want %s fetched", i, result, want[i])
+		}
+	}
+
+	results, err = FetchDependencies(ctx, dir, opts...)
+	if err != nil {
+		t.Fatalf("FetchDependencies() again error = %v", err)
+	}
+	for i, result := range results {
+		if !result.Cached || result.Err != nil {
+			t.Errorf("results[%d] = %+v, want already cached", i, result)
+		}
+	}
+
+	if _, err := LoadBundle(dir, append(opts, WithOffline(true))...); err != nil {
+		t.Errorf("LoadBundle() offline after fetching error = %v", err)
+	}
+}
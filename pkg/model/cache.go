@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/mod/module"
+	gomodule "golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// PruneCache removes the modules in cacheDir, the cache directory given to
+// WithCacheDir, that the bundle doesn't depend on, keeping those it does so
+// they needn't be fetched again. Every registry's cache under cacheDir is
+// pruned. It returns the pruned modules as path@version, with their paths
+// escaped as in the cache, sorted. If dryRun is set nothing is removed.
+func (b *Bundle) PruneCache(cacheDir string, dryRun bool) ([]string, error) {
+	deps, err := b.Dependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		key, err := cacheKey(dep)
+		if err != nil {
+			return nil, err
+		}
+		keep[key] = true
+	}
+
+	registryDirs, err := os.ReadDir(cacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	pruned := map[string]bool{}
+	for _, registryDir := range registryDirs {
+		if !registryDir.IsDir() {
+			continue
+		}
+		modDir := filepath.Join(cacheDir, registryDir.Name(), "mod")
+		if err := pruneExtracted(filepath.Join(modDir, "extract"), keep, pruned, dryRun); err != nil {
+			return nil, err
+		}
+		if err := pruneDownloads(filepath.Join(modDir, "download"), keep, pruned, dryRun); err != nil {
+			return nil, err
+		}
+	}
+
+	for key := range pruned {
+		b.logger.Debug("pruned module from cache", "module", key, "dryRun", dryRun)
+	}
+	return slices.Sorted(maps.Keys(pruned)), nil
+}
+
+// cacheKey returns how the module cache names dep's version, escaped
+// path@version.
+func cacheKey(dep Dependency) (string, error) {
+	modVer, err := module.NewVersion(dep.Path, dep.Version)
+	if err != nil {
+		return "", err
+	}
+	path, err := module.EscapePath(modVer.BasePath())
+	if err != nil {
+		return "", err
+	}
+	version, err := module.EscapeVersion(modVer.Version())
+	if err != nil {
+		return "", err
+	}
+	return path + "@" + version, nil
+}
+
+// pruneExtracted removes the extracted module directories under dir,
+// <path>@<version>, that aren't in keep.
+func pruneExtracted(dir string, keep, pruned map[string]bool, dryRun bool) error {
+	return walkCache(dir, func(path string, d fs.DirEntry) error {
+		if !d.IsDir() || !strings.Contains(d.Name(), "@") {
+			return nil
+		}
+		key, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		key = filepath.ToSlash(key)
+		if !keep[key] {
+			pruned[key] = true
+			if !dryRun {
+				if err := removeCached(path); err != nil {
+					return err
+				}
+			}
+		}
+		return fs.SkipDir
+	})
+}
+
+// pruneDownloads removes the downloaded files under dir,
+// <path>/@v/<version>.<ext>, of the modules that aren't in keep. Other files,
+// such as the list of a module's versions, are left alone.
+func pruneDownloads(dir string, keep, pruned map[string]bool, dryRun bool) error {
+	return walkCache(dir, func(path string, d fs.DirEntry) error {
+		if d.IsDir() || filepath.Base(filepath.Dir(path)) != "@v" {
+			return nil
+		}
+		version, ok := downloadVersion(d.Name())
+		if !ok {
+			return nil
+		}
+		modPath, err := filepath.Rel(dir, filepath.Dir(filepath.Dir(path)))
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(modPath) + "@" + version
+		if !keep[key] {
+			pruned[key] = true
+			if !dryRun {
+				return removeCached(path)
+			}
+		}
+		return nil
+	})
+}
+
+// downloadVersion returns the escaped version a downloaded file named
+// <version>.mod, <version>.zip or <version>.info is for, and whether name is
+// one of these.
+func downloadVersion(name string) (string, bool) {
+	ext := filepath.Ext(name)
+	switch ext {
+	case ".mod", ".zip", ".info":
+	default:
+		return "", false
+	}
+	version := strings.TrimSuffix(name, ext)
+	unescaped, err := gomodule.UnescapeVersion(version)
+	if err != nil || !semver.IsValid(unescaped) {
+		return "", false
+	}
+	return version, true
+}
+
+// walkCache walks dir, if it exists, calling fn for everything below it.
+func walkCache(dir string, fn func(path string, d fs.DirEntry) error) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		return fn(path, d)
+	})
+}
+
+// removeCached removes path, first making it and everything below it
+// writable, since the module cache makes extracted modules read-only.
+func removeCached(path string) error {
+	if err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.Chmod(path, 0755)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
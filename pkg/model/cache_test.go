@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBundlePruneCache(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	cacheDir := t.TempDir()
+	opts = append(opts, WithCacheDir(cacheDir))
+
+	if _, err := FetchDependencies(context.Background(), dir, opts...); err != nil {
+		t.Fatalf("FetchDependencies() error = %v", err)
+	}
+
+	// A module left behind by a dependency the bundle no longer has.
+	stale := filepath.Join(cacheDir, "0123abcd", "mod")
+	writeFiles(t, stale, map[string]string{
+		"extract/example.com/old@v0.1.0/cue.mod/module.cue": "module: \"example.com/old@v0\"\n",
+		"download/example.com/old/@v/v0.1.0.zip":            "",
+		"download/example.com/old/@v/v0.1.0.mod":            "",
+		"download/example.com/old/@v/list":                  "v0.1.0\n",
+	})
+	if err := os.Chmod(filepath.Join(stale, "extract", "example.com", "old@v0.1.0"), 0555); err != nil {
+		t.Fatalf("failed to make module read-only: %v", err)
+	}
+
+	b, err := LoadBundle(dir, opts...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	want := []string{"example.com/old@v0.1.0"}
+	pruned, err := b.PruneCache(cacheDir, true)
+	if err != nil {
+		t.Fatalf("PruneCache() dry run error = %v", err)
+	}
+	if !slices.Equal(pruned, want) {
+		t.Errorf("PruneCache() dry run = %v, want %v", pruned, want)
+	}
+	if _, err := os.Stat(filepath.Join(stale, "extract", "example.com", "old@v0.1.0")); err != nil {
+		t.Errorf("dry run removed the stale module: %v", err)
+	}
+
+	pruned, err = b.PruneCache(cacheDir, false)
+	if err != nil {
+		t.Fatalf("PruneCache() error = %v", err)
+	}
+	if !slices.Equal(pruned, want) {
+		t.Errorf("PruneCache() = %v, want %v", pruned, want)
+	}
+	for _, path := range []string{"extract/example.com/old@v0.1.0", "download/example.com/old/@v/v0.1.0.zip"} {
+		if _, err := os.Stat(filepath.Join(stale, path)); !os.IsNotExist(err) {
+			t.Errorf("%s still cached after pruning (err = %v)", path, err)
+		}
+	}
+	// Files other than a version's downloads are left alone.
+	if _, err := os.Stat(filepath.Join(stale, "download", "example.com", "old", "@v", "list")); err != nil {
+		t.Errorf("pruning removed the module's version list: %v", err)
+	}
+
+	// The bundle's own dependencies are kept.
+	if _, err := LoadBundle(dir, append(opts, WithOffline(true))...); err != nil {
+		t.Errorf("LoadBundle() offline after pruning error = %v", err)
+	}
+}
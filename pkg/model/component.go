@@ -6,14 +6,22 @@ import (
 	"cuelang.org/go/cue"
 	"fmt"
 	"iter"
+	"slices"
+	"strings"
+	"sync"
+
+	"go-valkyrie.com/odin/pkg/schema"
 )
 
 type Component struct {
+	// mu is the owning bundle's evaluation mutex.
+	mu       *sync.Mutex
 	selector cue.Selector
 	value    cue.Value
 }
 
 func (c *Component) GoString() string {
+	defer lock(c.mu)()
 	return fmt.Sprintf("#Component{selector: %s, value: %v}", c.selector, c.value)
 }
 
@@ -23,23 +31,116 @@ func (c *Component) Selector() cue.Selector {
 
 func (c *Component) Resources() iter.Seq[*Resource] {
 	return func(yield func(*Resource) bool) {
+		unlock := lock(c.mu)
+		var resources []*Resource
 		i, err := c.value.LookupPath(cue.ParsePath("resources")).Fields(cue.Definitions(false))
-		if err != nil {
-			return
+		if err == nil {
+			for i.Next() {
+				resources = append(resources, newResource(c, i.Selector(), i.Value()))
+			}
+		}
+		unlock()
+
+		for _, resource := range resources {
+			if !yield(resource) {
+				return
+			}
+		}
+	}
+}
+
+// Doc returns the doc comments of the component, joined by newlines, or an
+// empty string if it has none.
+func (c *Component) Doc() string {
+	defer lock(c.mu)()
+	var docParts []string
+	for _, cg := range c.value.Doc() {
+		docParts = append(docParts, cg.Text())
+	}
+	return strings.TrimSpace(strings.Join(docParts, "\n"))
+}
+
+// Config returns the component's config, with any values the bundle sets
+// for it applied. Only the lookup is guarded by the bundle's mutex: like
+// Bundle.Value, the returned value must not be evaluated concurrently with
+// other uses of the bundle. Prefer ConfigSchema or ConcreteConfigErrors,
+// which evaluate it under the lock.
+func (c *Component) Config() cue.Value {
+	defer lock(c.mu)()
+	return c.value.LookupPath(cue.ParsePath("config"))
+}
+
+// ConfigSchema returns the schema fields for the component's config, like
+// ComponentTemplate.ConfigSchema, but reflecting the config as the bundle
+// instantiates it.
+func (c *Component) ConfigSchema(opts ...schema.WalkOption) []*schema.SchemaField {
+	defer lock(c.mu)()
+	configValue := c.value.LookupPath(cue.ParsePath("config"))
+	if configValue.Err() != nil {
+		return nil
+	}
+	return schema.WalkSchema(configValue, opts...)
+}
+
+// ConcreteConfigErrors reports each field of the component's config that
+// isn't concrete once values are applied, such as a required field left
+// unset, or that conflicts, with paths from the component, e.g.
+// "config.replicas", and the positions the fields are declared at.
+func (c *Component) ConcreteConfigErrors() []ValueError {
+	defer lock(c.mu)()
+	return concreteConfigErrors(c.value, componentPath)
+}
+
+// concreteConfigErrors reports each field of the config of the component
+// value that isn't concrete, with paths given by toPath. The caller must hold
+// the bundle's mutex.
+func concreteConfigErrors(value cue.Value, toPath func(path []string) string) []ValueError {
+	err := value.LookupPath(cue.ParsePath("config")).Validate(cue.Concrete(true), cue.Final())
+	var errs []ValueError
+	for _, valueErr := range valueErrors(err, toPath) {
+		if !slices.Contains(errs, valueErr) {
+			errs = append(errs, valueErr)
 		}
+	}
+	return errs
+}
+
+// componentPath converts the path of an error within the bundle to its path
+// within the component: "components.<name>.config.x" becomes "config.x".
+func componentPath(path []string) string {
+	if len(path) >= 2 && path[0] == "components" {
+		path = path[2:]
+	}
+	return strings.Join(path, ".")
+}
+
+// ConfigError is returned by Render with WithConcreteConfig when a
+// component's config isn't concrete.
+type ConfigError struct {
+	Component string
+	Errors    []ValueError
+}
 
-		for i.Next() {
-			yield(newResource(c, i.Selector(), i.Value()))
+func (e *ConfigError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "component %s has incomplete config:", e.Component)
+	for _, valueErr := range e.Errors {
+		fmt.Fprintf(&sb, "\n  %s", valueErr)
+		if valueErr.Position != "" {
+			fmt.Fprintf(&sb, " (%s)", valueErr.Position)
 		}
 	}
+	return sb.String()
 }
 
 func (c *Component) ValidConfig() error {
+	defer lock(c.mu)()
 	return c.value.LookupPath(cue.ParsePath("config")).Validate(cue.Final())
 }
 
-func newComponent(selector cue.Selector, value cue.Value) *Component {
+func newComponent(mu *sync.Mutex, selector cue.Selector, value cue.Value) *Component {
 	return &Component{
+		mu:       mu,
 		selector: selector,
 		value:    value,
 	}
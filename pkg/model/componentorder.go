@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+)
+
+// ComponentsInOrder returns the bundle's components ordered so each comes
+// after the components it references, such as a component that sets its
+// config from components.db.config.host coming after db. Components that
+// don't depend on each other keep their declaration order, as returned by
+// Components. References that form a cycle are an error naming the
+// components in it.
+func (b *Bundle) ComponentsInOrder() ([]*Component, error) {
+	components := slices.Collect(b.Components())
+
+	unlock := lock(b.mu)
+	deps := make(map[string][]string, len(components))
+	byName := make(map[string]*Component, len(components))
+	for _, c := range components {
+		name := c.Selector().String()
+		byName[name] = c
+		seen := make(map[string]bool)
+		collectComponentRefs(c.value, func(ref string) {
+			if ref != name && !seen[ref] {
+				seen[ref] = true
+				deps[name] = append(deps[name], ref)
+			}
+		})
+	}
+	unlock()
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(components))
+	ordered := make([]*Component, 0, len(components))
+	var stack []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			cycle := append(stack[slices.Index(stack, name):], name)
+			return fmt.Errorf("components reference each other in a cycle: %s", strings.Join(cycle, " -> "))
+		}
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range deps[name] {
+			// References to components that don't exist are left for
+			// evaluation to report.
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+		ordered = append(ordered, byName[name])
+		return nil
+	}
+	for _, c := range components {
+		if err := visit(c.Selector().String()); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// collectComponentRefs calls ref with the name of each component that v, or
+// any field or list element within it, refers to through a path starting at
+// "components.<name>".
+func collectComponentRefs(v cue.Value, ref func(name string)) {
+	collectExprComponentRefs(v, ref)
+	switch v.IncompleteKind() {
+	case cue.StructKind:
+		iter, err := v.Fields()
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			collectComponentRefs(iter.Value(), ref)
+		}
+	case cue.ListKind:
+		iter, err := v.List()
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			collectComponentRefs(iter.Value(), ref)
+		}
+	}
+}
+
+// collectExprComponentRefs looks for references to components in the
+// expression v was evaluated from, including each operand of a unification,
+// disjunction or interpolation.
+func collectExprComponentRefs(v cue.Value, ref func(name string)) {
+	_, path := v.ReferencePath()
+	if selectors := path.Selectors(); len(selectors) > 0 {
+		if len(selectors) >= 2 && selectors[0].String() == "components" {
+			ref(selectors[1].String())
+		}
+		return
+	}
+	if op, args := v.Expr(); op != cue.NoOp {
+		for _, arg := range args {
+			collectExprComponentRefs(arg, ref)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeOrderBundle(t *testing.T, components string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/order@v0"
+language: version: "v0.14.0"
+`,
+		"bundle.cue": "package bundle\n\nmetadata: name: \"order\"\n\n" + components,
+	})
+	return dir
+}
+
+func TestComponentsInOrder(t *testing.T) {
+	dir := writeOrderBundle(t, `
+components: web: {
+	config: {
+		dbHost: "\(components.db.config.host):5432"
+		replicas: int | *2
+	}
+	resources: {}
+}
+
+components: cache: {
+	config: {}
+	resources: {}
+}
+
+components: db: {
+	config: {
+		host:      "db." + components.network.config.domain
+		namespace: components.network.config.namespace
+	}
+	resources: {}
+}
+
+components: network: {
+	config: {
+		domain:    "internal"
+		namespace: string | *"default"
+		self:      components.network.config.domain
+	}
+	resources: {}
+}
+`)
+	b, err := LoadBundle(dir, WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	var declared []string
+	for c := range b.Components() {
+		declared = append(declared, c.Selector().String())
+	}
+	if want := []string{"web", "cache", "db", "network"}; !slices.Equal(declared, want) {
+		t.Errorf("Components() = %v, want declaration order %v", declared, want)
+	}
+
+	components, err := b.ComponentsInOrder()
+	if err != nil {
+		t.Fatalf("ComponentsInOrder() error = %v", err)
+	}
+	var got []string
+	for _, c := range components {
+		got = append(got, c.Selector().String())
+	}
+	if want := []string{"network", "db", "web", "cache"}; !slices.Equal(got, want) {
+		t.Errorf("ComponentsInOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestComponentsInOrderCycle(t *testing.T) {
+	dir := writeOrderBundle(t, `
+components: a: {
+	config: name: "a-" + components.b.config.suffix
+	resources: {}
+}
+
+components: b: {
+	config: {
+		suffix: "b"
+		peer:   components.a.config.name
+	}
+	resources: {}
+}
+`)
+	b, err := LoadBundle(dir, WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	_, err = b.ComponentsInOrder()
+	if err == nil || !strings.Contains(err.Error(), "a -> b -> a") {
+		t.Errorf("ComponentsInOrder() error = %v, want a cycle a -> b -> a", err)
+	}
+}
+
+func TestComponentsInOrderListReference(t *testing.T) {
+	dir := writeOrderBundle(t, `
+components: web: {
+	config: {}
+	resources: deployment: {
+		kind: "Deployment"
+		spec: containers: [{
+			name: "web"
+			env: [{name: "DB_HOST", value: components.db.config.host}]
+		}]
+	}
+}
+
+components: db: {
+	config: host: "db.internal"
+	resources: {}
+}
+`)
+	b, err := LoadBundle(dir, WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	components, err := b.ComponentsInOrder()
+	if err != nil {
+		t.Fatalf("ComponentsInOrder() error = %v", err)
+	}
+	var got []string
+	for _, c := range components {
+		got = append(got, c.Selector().String())
+	}
+	if want := []string{"db", "web"}; !slices.Equal(got, want) {
+		t.Errorf("ComponentsInOrder() = %v, want %v", got, want)
+	}
+}
@@ -4,11 +4,15 @@ package model
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/build"
@@ -19,17 +23,60 @@ import (
 	"go-valkyrie.com/odin/pkg/schema"
 )
 
+// TemplateScope selects which modules component template discovery scans.
+type TemplateScope string
+
+const (
+	// ScopeAll scans both the bundle's dependencies and the bundle's own module.
+	ScopeAll TemplateScope = "all"
+	// ScopeDependencies scans only the bundle's dependencies.
+	ScopeDependencies TemplateScope = "dependencies"
+	// ScopeLocal scans only the bundle's own module.
+	ScopeLocal TemplateScope = "local"
+)
+
+// ParseTemplateScope converts a string to a TemplateScope. An empty string is
+// treated as ScopeAll.
+func ParseTemplateScope(s string) (TemplateScope, error) {
+	switch scope := TemplateScope(s); scope {
+	case "":
+		return ScopeAll, nil
+	case ScopeAll, ScopeDependencies, ScopeLocal:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("invalid template scope %q (supported: all, dependencies, local)", s)
+	}
+}
+
+func (s TemplateScope) includesDependencies() bool {
+	return s != ScopeLocal
+}
+
+func (s TemplateScope) includesLocal() bool {
+	return s != ScopeDependencies
+}
+
 type ComponentTemplate struct {
 	Package string
 	Name    string
 	Module  string
 	Version string
-	Value   cue.Value
+	// Local reports whether the template is defined in the bundle's own
+	// module rather than in one of its dependencies.
+	Local bool
+	// Base is the component base definition the template matched, in the
+	// same "<import path>:#<Definition>" form given to WithComponentBases.
+	Base  string
+	Value cue.Value
+
+	// mu is the discovering bundle's evaluation mutex.
+	mu *sync.Mutex
 }
 
 // ConfigSchema returns the schema fields for this template's config section.
 // Options can be provided to control behavior (e.g., schema.WithExpand).
 func (t *ComponentTemplate) ConfigSchema(opts ...schema.WalkOption) []*schema.SchemaField {
+	defer lock(t.mu)()
 	configValue := t.Value.LookupPath(cue.ParsePath("config"))
 	if configValue.Err() != nil {
 		return nil
@@ -37,9 +84,57 @@ func (t *ComponentTemplate) ConfigSchema(opts ...schema.WalkOption) []*schema.Sc
 	return schema.WalkSchema(configValue, opts...)
 }
 
+// ConfigSchemaAt returns the schema of the single config field at path, e.g.
+// "database" or "database.pool", with its subtree as children. If the path
+// doesn't exist the error lists the top-level config fields.
+func (t *ComponentTemplate) ConfigSchemaAt(path string, opts ...schema.WalkOption) ([]*schema.SchemaField, error) {
+	defer lock(t.mu)()
+	p := cue.ParsePath(path)
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("invalid config path %q: %w", path, err)
+	}
+	selectors := p.Selectors()
+	if len(selectors) == 0 {
+		return nil, fmt.Errorf("invalid config path %q: path is empty", path)
+	}
+
+	configValue := t.Value.LookupPath(cue.ParsePath("config"))
+	if configValue.Err() != nil {
+		return nil, fmt.Errorf("%s has no config", t.Name)
+	}
+	if !configValue.LookupPath(p).Exists() {
+		return nil, fmt.Errorf("config of %s has no field %q (available: %s)",
+			t.Name, path, strings.Join(fieldNames(configValue), ", "))
+	}
+
+	// Walk the parent so the field is reported along with its name and docs.
+	parent := configValue.LookupPath(cue.MakePath(selectors[:len(selectors)-1]...))
+	name := strings.TrimRight(selectors[len(selectors)-1].String(), "?!")
+	for _, field := range schema.WalkSchema(parent, opts...) {
+		if field.Name == name && !field.IsPattern {
+			return []*schema.SchemaField{field}, nil
+		}
+	}
+	return nil, fmt.Errorf("config of %s has no documented field %q", t.Name, path)
+}
+
+// fieldNames returns the names of v's regular and optional fields.
+func fieldNames(v cue.Value) []string {
+	iter, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for iter.Next() {
+		names = append(names, strings.TrimRight(iter.Selector().String(), "?!"))
+	}
+	return names
+}
+
 // Declarations returns root-level definitions annotated with @odin attribute.
 // Options can be provided to control behavior (e.g., schema.WithExpand).
 func (t *ComponentTemplate) Declarations(opts ...schema.WalkOption) []*schema.Declaration {
+	defer lock(t.mu)()
 	return schema.WalkDeclarations(t.Value, opts...)
 }
 
@@ -47,65 +142,36 @@ func (b *Bundle) ComponentTemplates(ctx context.Context) iter.Seq2[*ComponentTem
 	return func(yield func(*ComponentTemplate, error) bool) {
 		logger := b.logger
 
-		logger.Debug("starting component template discovery", "sourcePath", b.sourcePath)
-
-		// Find the module root by walking up from sourcePath
-		moduleRoot, err := findModuleRoot(b.sourcePath)
-		if err != nil {
-			logger.Debug("failed to find module root", "err", err)
-			if !yield(nil, fmt.Errorf("finding module root: %w", err)) {
-				return
-			}
-			return
+		scope := b.templateScope
+		if scope == "" {
+			scope = ScopeAll
 		}
-		logger.Debug("found module root", "root", moduleRoot)
 
-		// Load the module file directly from cue.mod/module.cue
-		moduleFilePath := filepath.Join(moduleRoot, "cue.mod", "module.cue")
-		moduleFileData, err := os.ReadFile(moduleFilePath)
-		if err != nil {
-			logger.Debug("failed to read module file", "err", err)
-			if !yield(nil, fmt.Errorf("reading module file: %w", err)) {
-				return
-			}
-			return
-		}
+		logger.Debug("starting component template discovery", "sourcePath", b.sourcePath, "scope", scope)
 
-		moduleFile, err := modfile.Parse(moduleFileData, moduleFilePath)
+		moduleRoot, moduleFile, err := loadModuleFile(b.sourcePath)
 		if err != nil {
-			logger.Debug("failed to parse module file", "err", err)
-			if !yield(nil, fmt.Errorf("parsing module file: %w", err)) {
+			logger.Debug("failed to load module file", "err", err)
+			if !yield(nil, err) {
 				return
 			}
 			return
 		}
+		logger.Debug("found module root", "root", moduleRoot)
 
 		logger.Debug("loaded bundle module file", "module", moduleFile.Module, "depCount", len(moduleFile.Deps))
 
-		// Load #ComponentBase from the odin API.
-		apiInsts := load.Instances([]string{"go-valkyrie.com/odin/api/v1alpha1"}, &load.Config{
-			Dir: b.sourcePath,
-			Env: b.env,
-		})
-		if len(apiInsts) == 0 {
-			logger.Debug("no API instances returned")
-			return
-		}
-		if apiInsts[0].Err != nil {
-			logger.Debug("API instance has error", "err", apiInsts[0].Err)
+		componentBases, err := b.loadComponentBases()
+		if err != nil {
+			yield(nil, err)
 			return
 		}
-		apiValue := b.ctx.BuildInstance(apiInsts[0])
-		componentBase := apiValue.LookupPath(cue.ParsePath("#ComponentBase"))
-		if componentBase.Err() != nil {
-			logger.Debug("failed to lookup #ComponentBase", "err", componentBase.Err())
-			if !yield(nil, componentBase.Err()) {
-				return
-			}
+		if len(componentBases) == 0 {
+			logger.Debug("no component bases could be loaded")
 			return
 		}
 
-		logger.Debug("loaded #ComponentBase schema")
+		logger.Debug("loaded component base schemas", "count", len(componentBases))
 
 		// Create a module registry to fetch dependency sources.
 		registry, err := modconfig.NewRegistry(&modconfig.Config{
@@ -119,7 +185,12 @@ func (b *Bundle) ComponentTemplates(ctx context.Context) iter.Seq2[*ComponentTem
 			return
 		}
 
-		for depPath, dep := range moduleFile.Deps {
+		deps := moduleFile.Deps
+		if !scope.includesDependencies() {
+			deps = nil
+		}
+
+		for depPath, dep := range deps {
 			logger.Debug("processing dependency", "dep", depPath, "version", dep.Version)
 
 			// Skip the odin API module itself.
@@ -128,6 +199,16 @@ func (b *Bundle) ComponentTemplates(ctx context.Context) iter.Seq2[*ComponentTem
 				continue
 			}
 
+			if b.onlyPackage != "" && !inModule(b.onlyPackage, depPath) {
+				logger.Debug("skipping dependency that can't contain the package", "dep", depPath, "pkg", b.onlyPackage)
+				continue
+			}
+
+			if pattern, ok := excludedDependency(depPath, b.excludeDeps); ok {
+				logger.Debug("skipping excluded dependency", "dep", depPath, "pattern", pattern)
+				continue
+			}
+
 			// Create a module.Version for this dependency.
 			modVer, err := module.NewVersion(depPath, dep.Version)
 			if err != nil {
@@ -136,7 +217,9 @@ func (b *Bundle) ComponentTemplates(ctx context.Context) iter.Seq2[*ComponentTem
 			}
 
 			// Fetch the module source to get its filesystem location.
+			done := timePhase(logger, b.timings, PhaseFetch, "dep", depPath)
 			sourceLoc, err := registry.Fetch(ctx, modVer)
+			done()
 			if err != nil {
 				logger.Debug("failed to fetch module source", "dep", depPath, "err", err)
 				continue
@@ -153,65 +236,216 @@ func (b *Bundle) ComponentTemplates(ctx context.Context) iter.Seq2[*ComponentTem
 			logger.Debug("discovered module directory", "dep", depPath, "dir", moduleDir)
 
 			// Use ./... wildcard from the module's directory to discover all packages.
-			pkgInsts := load.Instances([]string{"./..."}, &load.Config{
-				Dir: moduleDir,
-				Env: b.env,
-			})
+			done = timePhase(logger, b.timings, PhaseInstances, "dep", depPath)
+			pkgInsts := b.modulePackages(moduleDir)
+			done()
 
 			logger.Debug("discovered packages in module", "dep", depPath, "packageCount", len(pkgInsts))
 
 			for _, inst := range pkgInsts {
-				if !b.scanPackageForTemplates(inst, componentBase, depPath, dep.Version, yield) {
+				if !b.scanPackageForTemplates(inst, componentBases, depPath, dep.Version, false, yield) {
 					return
 				}
 			}
 		}
 
+		if !scope.includesLocal() {
+			return
+		}
+		if b.onlyPackage != "" && !inModule(b.onlyPackage, moduleFile.Module) {
+			return
+		}
+
 		// Scan local module for templates
 		logger.Debug("scanning local module for templates", "moduleRoot", moduleRoot)
-		localInsts := load.Instances([]string{"./..."}, &load.Config{
-			Dir: moduleRoot,
-			Env: b.env,
-		})
+		done := timePhase(logger, b.timings, PhaseInstances, "module", moduleFile.Module)
+		localInsts := b.modulePackages(moduleRoot)
+		done()
 		logger.Debug("discovered local packages", "packageCount", len(localInsts))
 		for _, inst := range localInsts {
-			if !b.scanPackageForTemplates(inst, componentBase, moduleFile.Module, "", yield) {
+			if !b.scanPackageForTemplates(inst, componentBases, moduleFile.Module, "", true, yield) {
 				return
 			}
 		}
 	}
 }
 
+// modulePackages loads the packages of the module in moduleDir that match the
+// bundle's package patterns. The patterns apply to every module scanned, so
+// an include pattern matching nothing in this one is not an error.
+func (b *Bundle) modulePackages(moduleDir string) []*build.Instance {
+	var include, exclude []string
+	for _, pattern := range b.packagePatterns {
+		if p, ok := strings.CutPrefix(pattern, "!"); ok {
+			exclude = append(exclude, packagePrefix(p))
+		} else {
+			include = append(include, pattern)
+		}
+	}
+	if len(include) == 0 {
+		include = []string{"./..."}
+	} else {
+		// A pattern naming a single package that this module lacks would
+		// fail to load rather than match nothing, so leave it out.
+		include = slices.DeleteFunc(include, func(pattern string) bool {
+			if strings.Contains(pattern, "...") {
+				return false
+			}
+			info, err := os.Stat(filepath.Join(moduleDir, filepath.FromSlash(pattern)))
+			return err != nil || !info.IsDir()
+		})
+		if len(include) == 0 {
+			return nil
+		}
+	}
+
+	insts := load.Instances(include, &load.Config{
+		Dir: moduleDir,
+		Env: b.env,
+	})
+
+	var kept []*build.Instance
+	for _, inst := range insts {
+		// load.Instances reports a pattern that matched nothing as an
+		// instance with only that error.
+		if inst.ImportPath == "" && inst.Err != nil && strings.HasSuffix(inst.Err.Error(), "matched no packages") {
+			b.logger.Debug("no packages match pattern in module", "module", moduleDir, "err", inst.Err)
+			continue
+		}
+		if len(exclude) == 0 {
+			kept = append(kept, inst)
+			continue
+		}
+		rel, err := filepath.Rel(moduleDir, inst.Dir)
+		if err == nil {
+			if pattern, ok := matchPathPrefix(filepath.ToSlash(rel), exclude); ok {
+				b.logger.Debug("skipping excluded package", "pkg", inst.ImportPath, "pattern", pattern)
+				continue
+			}
+		}
+		kept = append(kept, inst)
+	}
+	return kept
+}
+
+// packagePrefix converts a package pattern such as "./internal/..." to the
+// module-relative path prefix it covers, "internal".
+func packagePrefix(pattern string) string {
+	pattern = strings.TrimSuffix(pattern, "...")
+	pattern = strings.TrimSuffix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "./")
+	if pattern == "" || pattern == "." {
+		return "*"
+	}
+	return pattern
+}
+
+// unversionedPath strips the version and package qualifier from an import
+// or module path, e.g. "example.com/mod/pkg@v0:name" becomes
+// "example.com/mod/pkg".
+func unversionedPath(p string) string {
+	p, _, _ = strings.Cut(p, "@")
+	p, _, _ = strings.Cut(p, ":")
+	return p
+}
+
+// inModule reports whether the unversioned package path pkg is within the
+// module with path modulePath.
+func inModule(pkg, modulePath string) bool {
+	modulePath = unversionedPath(modulePath)
+	return pkg == modulePath || strings.HasPrefix(pkg, modulePath+"/")
+}
+
+// excludedDependency reports whether depPath matches any of patterns, as
+// given to WithExcludeDependencies, returning the pattern that matched. The
+// major version suffix of depPath, e.g. "@v0", is ignored.
+func excludedDependency(depPath string, patterns []string) (string, bool) {
+	modulePath, _, _ := strings.Cut(depPath, "@")
+	return matchPathPrefix(modulePath, patterns)
+}
+
+// matchPathPrefix reports whether any of the path.Match patterns matches p or
+// one of its leading path elements, returning the pattern that matched.
+func matchPathPrefix(p string, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		prefix := p
+		for {
+			if ok, _ := path.Match(pattern, prefix); ok {
+				return pattern, true
+			}
+			i := strings.LastIndex(prefix, "/")
+			if i < 0 {
+				break
+			}
+			prefix = prefix[:i]
+		}
+	}
+	return "", false
+}
+
 // scanPackageForTemplates scans a single package instance for component templates.
+// A package that fails to build is yielded as an error in strict mode and
+// skipped with a warning otherwise.
 // Returns false if the caller should stop yielding (early termination requested).
 func (b *Bundle) scanPackageForTemplates(
 	inst *build.Instance,
-	componentBase cue.Value,
+	componentBases []componentBase,
 	modulePath string,
 	version string,
+	local bool,
 	yield func(*ComponentTemplate, error) bool,
 ) bool {
+	if b.onlyPackage != "" && unversionedPath(inst.ImportPath) != b.onlyPackage {
+		return true
+	}
+	templates, err := b.packageTemplates(inst, componentBases, modulePath, version, local)
+	if err != nil {
+		if b.strictDiscovery {
+			return yield(nil, err)
+		}
+		b.logger.Warn("skipping package that failed to build; its templates are not listed", "pkg", inst.ImportPath, "err", err)
+		return true
+	}
+	for _, tmpl := range templates {
+		if !yield(tmpl, nil) {
+			return false
+		}
+	}
+	return true
+}
+
+// packageTemplates builds a package instance and returns the component
+// templates it defines, or an error if the package fails to load or build. The
+// bundle's mutex is held throughout, so callers must not hold it.
+func (b *Bundle) packageTemplates(
+	inst *build.Instance,
+	componentBases []componentBase,
+	modulePath string,
+	version string,
+	local bool,
+) ([]*ComponentTemplate, error) {
+	defer lock(b.mu)()
 	logger := b.logger
 
 	if inst.Err != nil {
-		logger.Debug("skipping package with load error", "pkg", inst.ImportPath, "err", inst.Err)
-		return true
+		return nil, fmt.Errorf("loading package %s: %w", inst.ImportPath, inst.Err)
 	}
-
 	logger.Debug("building package", "pkg", inst.ImportPath)
 
+	done := timePhase(logger, b.timings, PhaseBuild, "pkg", inst.ImportPath)
 	value := b.ctx.BuildInstance(inst)
-	if value.Err() != nil {
-		logger.Debug("skipping package that failed to build", "pkg", inst.ImportPath, "err", value.Err())
-		return true
+	done()
+	if err := value.Err(); err != nil {
+		return nil, fmt.Errorf("building package %s: %w", inst.ImportPath, err)
 	}
 
 	fieldIter, err := value.Fields(cue.Definitions(true))
 	if err != nil {
 		logger.Debug("skipping package with no definition fields", "pkg", inst.ImportPath, "err", err)
-		return true
+		return nil, nil
 	}
 
+	var templates []*ComponentTemplate
 	for fieldIter.Next() {
 		name := fieldIter.Selector().String()
 		// Skip private definitions.
@@ -223,40 +457,263 @@ func (b *Bundle) scanPackageForTemplates(
 			continue
 		}
 
-		logger.Debug("checking definition against #ComponentBase", "pkg", inst.ImportPath, "def", name)
-
-		unified := fieldIter.Value().Unify(componentBase)
-		if unified.Err() != nil {
-			logger.Debug("definition does not unify with #ComponentBase", "pkg", inst.ImportPath, "def", name, "err", unified.Err())
+		// A base is what templates conform to, not a template itself.
+		if isComponentBase(inst.ImportPath, name, componentBases) {
+			logger.Debug("skipping component base definition", "pkg", inst.ImportPath, "def", name)
 			continue
 		}
 
-		// Check that apiVersion and kind are concrete after unification.
-		// This filters out open types that unify with #ComponentBase
-		// but aren't actually component templates.
-		apiVersion := unified.LookupPath(cue.ParsePath("apiVersion"))
-		kind := unified.LookupPath(cue.ParsePath("kind"))
-		if apiVersion.Err() != nil || kind.Err() != nil ||
-			!apiVersion.IsConcrete() || !kind.IsConcrete() {
-			logger.Debug("definition unifies but lacks concrete apiVersion/kind",
-				"pkg", inst.ImportPath, "def", name)
+		logger.Debug("checking definition against component bases", "pkg", inst.ImportPath, "def", name)
+
+		done := timePhase(logger, b.timings, PhaseUnify, "pkg", inst.ImportPath, "def", name)
+		unified, base, err := unifyWithAny(fieldIter.Value(), componentBases)
+		done()
+
+		// A definition marked @odin(component) is a template whatever the
+		// structural checks make of it, but it must still unify with a
+		// component base, or it would only fail once it's used.
+		marked := markedAsComponent(fieldIter.Value())
+		if err != nil {
+			if !marked {
+				logger.Debug("definition does not unify with any component base", "pkg", inst.ImportPath, "def", name)
+				continue
+			}
+			if b.strictDiscovery {
+				return nil, fmt.Errorf("template %s in %s is marked @odin(component) but does not unify with any component base: %w", name, inst.ImportPath, err)
+			}
+			logger.Warn("skipping template marked @odin(component) that does not unify with any component base",
+				"pkg", inst.ImportPath, "def", name, "err", err)
 			continue
 		}
 
-		logger.Debug("found component template", "pkg", inst.ImportPath, "def", name)
+		if !marked {
+			// Check that apiVersion and kind are concrete after unification.
+			// This filters out open types that unify with a component base
+			// but aren't actually component templates.
+			apiVersion := unified.LookupPath(cue.ParsePath("apiVersion"))
+			kind := unified.LookupPath(cue.ParsePath("kind"))
+			if apiVersion.Err() != nil || kind.Err() != nil ||
+				!apiVersion.IsConcrete() || !kind.IsConcrete() {
+				// A definition that declares apiVersion or kind itself was
+				// meant as a template, e.g. an abstract one or a union of
+				// kinds, so tell its author why it isn't listed.
+				if declaresTypeMeta(fieldIter.Value()) {
+					logger.Warn("skipping definition that is not a concrete template: apiVersion and kind must both be concrete",
+						"pkg", inst.ImportPath, "def", name, "apiVersion", apiVersion, "kind", kind)
+				} else {
+					logger.Debug("definition unifies but lacks concrete apiVersion/kind",
+						"pkg", inst.ImportPath, "def", name)
+				}
+				continue
+			}
+		}
+
+		logger.Debug("found component template", "pkg", inst.ImportPath, "def", name, "marked", marked)
 		tmpl := &ComponentTemplate{
 			Package: inst.ImportPath,
 			Name:    name,
 			Module:  modulePath,
 			Version: version,
+			Local:   local,
+			Base:    base,
 			Value:   fieldIter.Value(),
+			mu:      b.mu,
 		}
-		if !yield(tmpl, nil) {
-			return false
+		templates = append(templates, tmpl)
+	}
+
+	return templates, nil
+}
+
+// markedAsComponent reports whether the definition v has the
+// @odin(component) attribute, marking it as a component template explicitly.
+func markedAsComponent(v cue.Value) bool {
+	for _, a := range v.Attributes(cue.ValueAttr) {
+		if a.Name() != "odin" {
+			continue
+		}
+		if marked, err := a.Flag(0, "component"); err == nil && marked {
+			return true
 		}
 	}
+	return false
+}
 
-	return true
+// declaresTypeMeta reports whether the definition v has an apiVersion or kind
+// field of its own, before unification with any component base.
+func declaresTypeMeta(v cue.Value) bool {
+	return v.LookupPath(cue.ParsePath("apiVersion")).Exists() ||
+		v.LookupPath(cue.ParsePath("kind")).Exists()
+}
+
+// componentBase is a loaded component base definition.
+type componentBase struct {
+	name string
+	// importPath and def locate the base's definition, e.g.
+	// "go-valkyrie.com/odin/api/v1alpha1" and "#ComponentBase".
+	importPath string
+	def        string
+	value      cue.Value
+}
+
+// isComponentBase reports whether the definition name in the package at
+// importPath is one of bases. Import paths are compared without their major
+// version or package qualifier.
+func isComponentBase(importPath, name string, bases []componentBase) bool {
+	path := unversionedPath(importPath)
+	for _, base := range bases {
+		if base.def == name && unversionedPath(base.importPath) == path {
+			return true
+		}
+	}
+	return false
+}
+
+// unifyWithAny unifies v with each base in turn, returning the first
+// unification without errors along with the name of the base it used. If v
+// unifies with none of them, the error unifying it with the first is
+// returned.
+func unifyWithAny(v cue.Value, bases []componentBase) (cue.Value, string, error) {
+	var firstErr error
+	for _, base := range bases {
+		unified := v.Unify(base.value)
+		err := unified.Err()
+		if err == nil {
+			return unified, base.name, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		firstErr = fmt.Errorf("no component bases")
+	}
+	return cue.Value{}, "", firstErr
+}
+
+// DefaultComponentBase is the definition component templates are recognised
+// by when no bases are configured with WithComponentBases.
+const DefaultComponentBase = "go-valkyrie.com/odin/api/v1alpha1:#ComponentBase"
+
+// parseComponentBase splits a "<import path>:#<Definition>" reference.
+func parseComponentBase(base string) (string, cue.Path, error) {
+	idx := strings.LastIndex(base, ":#")
+	if idx <= 0 {
+		return "", cue.Path{}, fmt.Errorf("invalid component base %q: expected <import path>:#<Definition>", base)
+	}
+	path := cue.ParsePath(base[idx+1:])
+	if err := path.Err(); err != nil {
+		return "", cue.Path{}, fmt.Errorf("invalid component base %q: %w", base, err)
+	}
+	return base[:idx], path, nil
+}
+
+// componentBaseCache holds loaded component bases by the list of bases they
+// were loaded for. The packages they come from are fixed by the bundle's
+// dependencies, so they never need to be reloaded, but cue values can only
+// be used with the context that built them, so the cache is only valid for
+// ctx.
+type componentBaseCache struct {
+	ctx   *cue.Context
+	bases map[string][]componentBase
+}
+
+// loadComponentBases loads the configured component base definitions, or
+// returns them from the bundle's cache if they've been loaded before.
+// Packages that can't be loaded (e.g. an API version the bundle doesn't
+// depend on) are skipped, unless none of the bases set with
+// WithComponentBases can be loaded; a definition missing from a loaded package
+// is an error.
+func (b *Bundle) loadComponentBases() ([]componentBase, error) {
+	defer lock(b.mu)()
+	bases := b.componentBases
+	if len(bases) == 0 {
+		bases = []string{DefaultComponentBase}
+	}
+
+	cache := b.baseCache
+	if cache == nil {
+		cache = &componentBaseCache{}
+	}
+	key := strings.Join(bases, "\n")
+	if cache.ctx == b.ctx {
+		if loaded, ok := cache.bases[key]; ok {
+			return loaded, nil
+		}
+	} else {
+		cache.ctx = b.ctx
+		cache.bases = nil
+	}
+
+	packages := map[string]cue.Value{}
+	var loaded []componentBase
+	var loadErrs []error
+	for _, base := range bases {
+		importPath, defPath, err := parseComponentBase(base)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg, ok := packages[importPath]
+		if !ok {
+			insts := load.Instances([]string{importPath}, &load.Config{
+				Dir: b.sourcePath,
+				Env: b.env,
+			})
+			if len(insts) == 0 || insts[0].Err != nil {
+				b.logger.Debug("skipping component base package that failed to load", "pkg", importPath)
+				loadErr := fmt.Errorf("component base %q: package %s not found", base, importPath)
+				if len(insts) > 0 {
+					loadErr = fmt.Errorf("component base %q: loading package %s: %w", base, importPath, insts[0].Err)
+				}
+				loadErrs = append(loadErrs, loadErr)
+				packages[importPath] = cue.Value{}
+				continue
+			}
+			pkg = b.ctx.BuildInstance(insts[0])
+			packages[importPath] = pkg
+		}
+		if !pkg.Exists() {
+			continue
+		}
+
+		value := pkg.LookupPath(defPath)
+		if err := value.Err(); err != nil {
+			b.logger.Debug("failed to lookup component base", "base", base, "err", err)
+			return nil, fmt.Errorf("component base %q: no definition %s in package %s: %w", base, defPath, importPath, err)
+		}
+		loaded = append(loaded, componentBase{name: base, importPath: importPath, def: defPath.String(), value: value})
+	}
+	if len(loaded) == 0 && len(b.componentBases) > 0 {
+		return nil, errors.Join(loadErrs...)
+	}
+	if cache.bases == nil {
+		cache.bases = make(map[string][]componentBase)
+	}
+	cache.bases[key] = loaded
+	return loaded, nil
+}
+
+// loadModuleFile finds the module containing startPath and parses its
+// cue.mod/module.cue, returning the module root along with the parsed file.
+func loadModuleFile(startPath string) (string, *modfile.File, error) {
+	moduleRoot, err := findModuleRoot(startPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("finding module root: %w", err)
+	}
+
+	moduleFilePath := filepath.Join(moduleRoot, "cue.mod", "module.cue")
+	moduleFileData, err := os.ReadFile(moduleFilePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading module file: %w", err)
+	}
+
+	moduleFile, err := modfile.Parse(moduleFileData, moduleFilePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing module file: %w", err)
+	}
+
+	return moduleRoot, moduleFile, nil
 }
 
 // findModuleRoot walks up from the given directory to find cue.mod/module.cue.
@@ -3,11 +3,14 @@
 package model
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pelletier/go-toml/v2"
+	"go-valkyrie.com/odin/pkg/model/internal/source"
 )
 
 // Config holds the parsed odin.toml configuration.
@@ -24,6 +27,20 @@ import (
 type Config struct {
 	Registries map[string]string
 	Compat     int
+	Bundle     BundleDefaults
+
+	// LegacyFiles lists the deprecated odin.registries.toml files that were
+	// read in place of odin.toml.
+	LegacyFiles []string
+}
+
+// BundleDefaults holds the [bundle] table of odin.toml: defaults that commands
+// apply when the corresponding flag isn't set explicitly. Relative values
+// files are resolved against the directory of the odin.toml declaring them.
+type BundleDefaults struct {
+	Namespace string   `toml:"namespace"`
+	Values    []string `toml:"values"`
+	Format    string   `toml:"format"`
 }
 
 type registryEntry struct {
@@ -33,43 +50,162 @@ type registryEntry struct {
 
 type tomlRoot struct {
 	Registries []registryEntry `toml:"registries"`
-	Compat     int             `toml:"compat"`
+	Compat     *int            `toml:"compat"`
+	Bundle     BundleDefaults  `toml:"bundle"`
 }
 
-// LoadConfig reads odin.toml (preferred) or legacy odin.registries.toml from bundlePath.
+// LoadConfig reads odin.toml from bundlePath and each of its parent
+// directories up to the repository root, so a monorepo can share one
+// odin.toml at its root. The search stops at the nearest directory holding
+// .git, or failing that the nearest holding cue.mod, so files outside the
+// project are never read. A directory without odin.toml falls back to the
+// legacy odin.registries.toml, which is recorded in Config.LegacyFiles.
+// Files are applied from the farthest to the nearest, so nearer files take
+// precedence: registries are merged by module prefix, and compat and
+// [bundle] settings replace those of farther files when set. The
+// bundle-local file always wins.
 func LoadConfig(bundlePath string) (*Config, error) {
+	return loadConfig(bundlePath, true)
+}
+
+// loadConfig reads the config for bundlePath as LoadConfig does. Without
+// parents only the bundle-local file is read, as for bundles extracted to a
+// temporary directory, whose parents have nothing to do with the bundle.
+func loadConfig(bundlePath string, parents bool) (*Config, error) {
 	if bundlePath == "" {
 		bundlePath = "."
 	}
 	cfg := &Config{Registries: map[string]string{}}
 
-	odinToml := filepath.Join(bundlePath, "odin.toml")
-	if st, err := os.Stat(odinToml); err == nil && !st.IsDir() {
-		if err := decodeTomlRegistries(odinToml, cfg); err != nil {
-			return nil, fmt.Errorf("failed to load %s: %w", odinToml, err)
+	paths, err := findConfigFiles(bundlePath, parents)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(paths) - 1; i >= 0; i-- {
+		if err := decodeToml(paths[i], cfg); err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", paths[i], err)
+		}
+		if filepath.Base(paths[i]) == legacyConfigFile {
+			cfg.LegacyFiles = append(cfg.LegacyFiles, paths[i])
 		}
-		return cfg, nil
 	}
 
 	return cfg, nil
 }
 
-func decodeTomlRegistries(path string, cfg *Config) error {
+const (
+	configFile       = "odin.toml"
+	legacyConfigFile = "odin.registries.toml"
+)
+
+// findConfigFiles returns the config files in bundlePath and, with parents,
+// its parent directories up to configRoot, nearest first, taking at most one
+// file per directory. A bundlePath that isn't a local directory (such as an
+// unpulled OCI reference) has no config files.
+func findConfigFiles(bundlePath string, parents bool) ([]string, error) {
+	if st, err := os.Stat(bundlePath); err != nil || !st.IsDir() {
+		return nil, nil
+	}
+
+	dir, err := filepath.Abs(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	root := dir
+	if parents {
+		root = configRoot(dir)
+	}
+
+	var paths []string
+	for {
+		for _, name := range []string{configFile, legacyConfigFile} {
+			path := filepath.Join(dir, name)
+			if st, err := os.Stat(path); err == nil && !st.IsDir() {
+				paths = append(paths, path)
+				break
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if dir == root || parent == dir {
+			return paths, nil
+		}
+		dir = parent
+	}
+}
+
+// configRoot returns the farthest directory config files are read from for a
+// bundle in dir: the nearest directory holding .git, or failing that the
+// nearest holding cue.mod. Outside of both only dir itself is used.
+func configRoot(dir string) string {
+	for _, marker := range []string{".git", "cue.mod"} {
+		for d := dir; ; {
+			if _, err := os.Stat(filepath.Join(d, marker)); err == nil {
+				return d
+			}
+			parent := filepath.Dir(d)
+			if parent == d {
+				break
+			}
+			d = parent
+		}
+	}
+	return dir
+}
+
+// decodeToml decodes the odin.toml at path and merges it into cfg, overriding
+// any settings it defines.
+func decodeToml(path string, cfg *Config) error {
 	f, err := os.Open(path)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 	var root tomlRoot
-	if err := toml.NewDecoder(f).Decode(&root); err != nil {
-		return err
+	dec := toml.NewDecoder(f).DisallowUnknownFields()
+	if err := dec.Decode(&root); err != nil {
+		return unknownKeysError(err)
 	}
-	for _, r := range root.Registries {
-		if r.ModulePrefix == "" || r.Registry == "" {
-			continue
+	for i, r := range root.Registries {
+		if r.ModulePrefix == "" {
+			return fmt.Errorf("registries[%d]: module-prefix is required", i)
+		}
+		if r.Registry == "" {
+			return fmt.Errorf("registries[%d] (%s): registry is required", i, r.ModulePrefix)
 		}
 		cfg.Registries[r.ModulePrefix] = r.Registry
 	}
-	cfg.Compat = root.Compat
+	if root.Compat != nil {
+		cfg.Compat = *root.Compat
+	}
+	if root.Bundle.Namespace != "" {
+		cfg.Bundle.Namespace = root.Bundle.Namespace
+	}
+	if len(root.Bundle.Values) > 0 {
+		// Relative values files are relative to the odin.toml declaring them.
+		values := make([]string, 0, len(root.Bundle.Values))
+		for _, location := range root.Bundle.Values {
+			values = append(values, source.ResolveValuesLocation(location, filepath.Dir(path)))
+		}
+		cfg.Bundle.Values = values
+	}
+	if root.Bundle.Format != "" {
+		cfg.Bundle.Format = root.Bundle.Format
+	}
 	return nil
 }
+
+// unknownKeysError rewrites a strict decoding error to name each unknown key
+// and where it appears. Other errors are returned unchanged.
+func unknownKeysError(err error) error {
+	var strictErr *toml.StrictMissingError
+	if !errors.As(err, &strictErr) {
+		return err
+	}
+	msgs := make([]string, 0, len(strictErr.Errors))
+	for _, e := range strictErr.Errors {
+		row, col := e.Position()
+		msgs = append(msgs, fmt.Sprintf("unknown key %q at line %d, column %d", strings.Join(e.Key(), "."), row, col))
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
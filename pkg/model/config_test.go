@@ -5,6 +5,9 @@ package model
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -13,8 +16,10 @@ func TestLoadConfig(t *testing.T) {
 		name          string
 		setupFunc     func(t *testing.T) string // Returns temp dir path
 		wantErr       bool
+		wantErrSubstr string
 		wantRegisties map[string]string
 		wantCompat    int
+		wantBundle    func(dir string) BundleDefaults
 	}{
 		{
 			name: "valid odin.toml",
@@ -57,17 +62,13 @@ registry = "registry.other.com"
 			wantRegisties: map[string]string{},
 		},
 		{
-			name: "entries with empty fields are skipped",
+			name: "entry missing registry is an error",
 			setupFunc: func(t *testing.T) string {
 				dir := t.TempDir()
 				content := `[[registries]]
 module-prefix = "example.com/module"
 registry = "registry.example.com"
 
-[[registries]]
-module-prefix = ""
-registry = "registry.empty.com"
-
 [[registries]]
 module-prefix = "other.com/foo"
 registry = ""
@@ -77,9 +78,55 @@ registry = ""
 				}
 				return dir
 			},
-			wantRegisties: map[string]string{
-				"example.com/module": "registry.example.com",
+			wantErr:       true,
+			wantErrSubstr: "registries[1] (other.com/foo): registry is required",
+		},
+		{
+			name: "entry missing module-prefix is an error",
+			setupFunc: func(t *testing.T) string {
+				dir := t.TempDir()
+				content := `[[registries]]
+registry = "registry.empty.com"
+`
+				if err := os.WriteFile(filepath.Join(dir, "odin.toml"), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write test file: %v", err)
+				}
+				return dir
+			},
+			wantErr:       true,
+			wantErrSubstr: "registries[0]: module-prefix is required",
+		},
+		{
+			name: "unknown table is an error",
+			setupFunc: func(t *testing.T) string {
+				dir := t.TempDir()
+				content := `[[registrys]]
+module-prefix = "example.com/module"
+registry = "registry.example.com"
+`
+				if err := os.WriteFile(filepath.Join(dir, "odin.toml"), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write test file: %v", err)
+				}
+				return dir
 			},
+			wantErr:       true,
+			wantErrSubstr: `unknown key "registrys" at line 1`,
+		},
+		{
+			name: "unknown registry field is an error",
+			setupFunc: func(t *testing.T) string {
+				dir := t.TempDir()
+				content := `[[registries]]
+modul-prefix = "example.com/module"
+registry = "registry.example.com"
+`
+				if err := os.WriteFile(filepath.Join(dir, "odin.toml"), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write test file: %v", err)
+				}
+				return dir
+			},
+			wantErr:       true,
+			wantErrSubstr: `unknown key "registries.modul-prefix" at line 2`,
 		},
 		{
 			name: "invalid toml",
@@ -169,6 +216,33 @@ registry = "registry.example.com"
 			wantRegisties: map[string]string{},
 			wantCompat:    0,
 		},
+		{
+			name: "bundle defaults",
+			setupFunc: func(t *testing.T) string {
+				dir := t.TempDir()
+				content := `[bundle]
+namespace = "staging"
+values = ["values.yaml", "yaml: extra.txt", "/abs/values.cue"]
+format = "markdown"
+`
+				if err := os.WriteFile(filepath.Join(dir, "odin.toml"), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write test file: %v", err)
+				}
+				return dir
+			},
+			wantRegisties: map[string]string{},
+			wantBundle: func(dir string) BundleDefaults {
+				return BundleDefaults{
+					Namespace: "staging",
+					Values: []string{
+						filepath.Join(dir, "values.yaml"),
+						"yaml: " + filepath.Join(dir, "extra.txt"),
+						"/abs/values.cue",
+					},
+					Format: "markdown",
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,6 +253,9 @@ registry = "registry.example.com"
 				t.Fatalf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if tt.wantErr {
+				if tt.wantErrSubstr != "" && !strings.Contains(err.Error(), tt.wantErrSubstr) {
+					t.Errorf("LoadConfig() error = %v, want substring %q", err, tt.wantErrSubstr)
+				}
 				return
 			}
 			if len(cfg.Registries) != len(tt.wantRegisties) {
@@ -192,6 +269,176 @@ registry = "registry.example.com"
 			if cfg.Compat != tt.wantCompat {
 				t.Errorf("Compat = %d, want %d", cfg.Compat, tt.wantCompat)
 			}
+			if tt.wantBundle != nil {
+				if want := tt.wantBundle(bundlePath); !reflect.DeepEqual(cfg.Bundle, want) {
+					t.Errorf("Bundle = %+v, want %+v", cfg.Bundle, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfigParentDirectories(t *testing.T) {
+	root := t.TempDir()
+	bundleDir := filepath.Join(root, "bundles", "app")
+	writeFiles(t, root, map[string]string{
+		".git/HEAD": "ref: refs/heads/main\n",
+		"odin.toml": `compat = 1
+
+[[registries]]
+module-prefix = "shared.example.com"
+registry = "registry.root.com"
+
+[[registries]]
+module-prefix = "override.example.com"
+registry = "registry.root.com"
+
+[bundle]
+namespace = "root"
+values = ["shared.yaml"]
+`,
+		"bundles/app/odin.toml": `[[registries]]
+module-prefix = "override.example.com"
+registry = "registry.bundle.com"
+
+[bundle]
+namespace = "app"
+`,
+	})
+
+	cfg, err := LoadConfig(bundleDir)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	wantRegistries := map[string]string{
+		"shared.example.com":   "registry.root.com",
+		"override.example.com": "registry.bundle.com",
+	}
+	if !reflect.DeepEqual(cfg.Registries, wantRegistries) {
+		t.Errorf("Registries = %v, want %v", cfg.Registries, wantRegistries)
+	}
+	if cfg.Compat != 1 {
+		t.Errorf("Compat = %d, want 1 inherited from the root odin.toml", cfg.Compat)
+	}
+	if cfg.Bundle.Namespace != "app" {
+		t.Errorf("Bundle.Namespace = %q, want %q", cfg.Bundle.Namespace, "app")
+	}
+	wantValues := []string{filepath.Join(root, "shared.yaml")}
+	if !slices.Equal(cfg.Bundle.Values, wantValues) {
+		t.Errorf("Bundle.Values = %v, want %v", cfg.Bundle.Values, wantValues)
+	}
+}
+
+func TestLoadConfigStopsAtProjectRoot(t *testing.T) {
+	// The odin.toml above the project is malformed, so reading it would fail.
+	outside := map[string]string{"odin.toml": "registries = [\n"}
+
+	tests := []struct {
+		name           string
+		files          map[string]string
+		bundle         string
+		wantRegistries map[string]string
+	}{
+		{
+			name: "stops at the module root",
+			files: map[string]string{
+				"project/cue.mod/module.cue": `module: "example.com/app@v0"` + "\n",
+				"project/odin.toml": `[[registries]]
+module-prefix = "project.example.com"
+registry = "registry.project.com"
+`,
+			},
+			bundle:         "project",
+			wantRegistries: map[string]string{"project.example.com": "registry.project.com"},
+		},
+		{
+			name: "stops at the repository root",
+			files: map[string]string{
+				"repo/.git/HEAD": "ref: refs/heads/main\n",
+				"repo/odin.toml": `[[registries]]
+module-prefix = "repo.example.com"
+registry = "registry.repo.com"
+`,
+				"repo/bundles/app/cue.mod/module.cue": `module: "example.com/app@v0"` + "\n",
+			},
+			bundle:         "repo/bundles/app",
+			wantRegistries: map[string]string{"repo.example.com": "registry.repo.com"},
+		},
+		{
+			name:           "reads only the bundle directory outside a project",
+			files:          map[string]string{"bundle/app.cue": "package app\n"},
+			bundle:         "bundle",
+			wantRegistries: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, outside)
+			writeFiles(t, root, tt.files)
+
+			cfg, err := LoadConfig(filepath.Join(root, tt.bundle))
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg.Registries, tt.wantRegistries) {
+				t.Errorf("Registries = %v, want %v", cfg.Registries, tt.wantRegistries)
+			}
+		})
+	}
+}
+
+func TestLoadConfigLegacyRegistriesFile(t *testing.T) {
+	tests := []struct {
+		name           string
+		files          map[string]string
+		wantRegistries map[string]string
+		wantLegacy     bool
+	}{
+		{
+			name: "legacy file used when odin.toml is absent",
+			files: map[string]string{
+				"odin.registries.toml": `[[registries]]
+module-prefix = "legacy.example.com"
+registry = "registry.legacy.com"
+`,
+			},
+			wantRegistries: map[string]string{"legacy.example.com": "registry.legacy.com"},
+			wantLegacy:     true,
+		},
+		{
+			name: "odin.toml preferred over legacy file",
+			files: map[string]string{
+				"odin.toml": `[[registries]]
+module-prefix = "current.example.com"
+registry = "registry.current.com"
+`,
+				"odin.registries.toml": `[[registries]]
+module-prefix = "legacy.example.com"
+registry = "registry.legacy.com"
+`,
+			},
+			wantRegistries: map[string]string{"current.example.com": "registry.current.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			cfg, err := LoadConfig(dir)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg.Registries, tt.wantRegistries) {
+				t.Errorf("Registries = %v, want %v", cfg.Registries, tt.wantRegistries)
+			}
+			if got := len(cfg.LegacyFiles) > 0; got != tt.wantLegacy {
+				t.Errorf("LegacyFiles = %v, want legacy %v", cfg.LegacyFiles, tt.wantLegacy)
+			}
 		})
 	}
 }
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cuelang.org/go/mod/modconfig"
+	"cuelang.org/go/mod/modregistry"
+	"cuelang.org/go/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// DependencyStatus describes a bundle dependency alongside the newest version
+// of it available in its registry.
+type DependencyStatus struct {
+	Dependency
+	// Latest is the newest version available in the registry. It is empty
+	// when Err is set.
+	Latest string
+	// Err records why the available versions could not be determined.
+	Err error
+}
+
+// Outdated reports whether a newer version than the one required is available.
+func (s DependencyStatus) Outdated() bool {
+	return s.Err == nil && semver.Compare(s.Latest, s.Version) > 0
+}
+
+// CheckDependencies queries the module registry for the versions available for
+// each of the bundle's dependencies. Failures to query an individual module are
+// recorded on its DependencyStatus rather than returned, so a single
+// unreachable module does not prevent the rest from being checked.
+func (b *Bundle) CheckDependencies(ctx context.Context) ([]DependencyStatus, error) {
+	deps, err := b.Dependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := modconfig.NewRegistry(&modconfig.Config{
+		Env: b.env,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating module registry: %w", err)
+	}
+
+	statuses := make([]DependencyStatus, 0, len(deps))
+	for _, dep := range deps {
+		status := DependencyStatus{Dependency: dep}
+		versions, err := registry.ModuleVersions(ctx, dep.Path)
+		if err != nil {
+			b.logger.Debug("failed to list module versions", "dep", dep.Path, "err", err)
+			status.Err = err
+		} else if status.Latest = latestVersion(versions); status.Latest == "" {
+			status.Err = errors.New("no versions found in registry")
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// FetchResult records fetching one of a bundle's dependencies into the
+// module cache.
+type FetchResult struct {
+	Dependency
+	// Cached is set if the module was already in the cache, so nothing was
+	// fetched.
+	Cached bool
+	// Err records why the module could not be fetched.
+	Err error
+}
+
+// FetchDependencies fetches each module required by the bundle at bundlePath
+// into the module cache, so the bundle can later be loaded WithOffline. The
+// bundle's source is prepared but not loaded, so modules it imports are
+// reported as fetched rather than as already cached. Options are those of
+// LoadBundle. Failures to fetch an individual module are recorded on its
+// FetchResult rather than returned.
+func FetchDependencies(ctx context.Context, bundlePath string, options ...Option) ([]FetchResult, error) {
+	l, err := newBundleLoader(bundlePath, options)
+	if err != nil {
+		return nil, err
+	}
+	b, _, err := l.prepare()
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+	deps, err := b.Dependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := modconfig.NewRegistry(&modconfig.Config{
+		Env: b.env,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating module registry: %w", err)
+	}
+
+	results := make([]FetchResult, 0, len(deps))
+	for _, dep := range deps {
+		result := FetchResult{Dependency: dep}
+		modVer, err := module.NewVersion(dep.Path, dep.Version)
+		if err != nil {
+			result.Err = err
+			results = append(results, result)
+			continue
+		}
+		if _, err := registry.FetchFromCache(modVer); err == nil {
+			b.logger.Debug("module already cached", "module", modVer)
+			result.Cached = true
+		} else {
+			b.logger.Info("fetching module", "module", modVer)
+			done := timePhase(b.logger, b.timings, PhaseFetch, "dep", dep.Path)
+			_, result.Err = registry.Fetch(ctx, modVer)
+			done()
+			if result.Err != nil {
+				b.logger.Debug("failed to fetch module", "module", modVer, "err", result.Err)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// ErrNotCached is returned, wrapped in a NotCachedError, when a bundle is
+// loaded with WithOffline and one of its dependencies isn't in the cache.
+var ErrNotCached = errors.New("not in cache")
+
+// NotCachedError reports a dependency that an offline load couldn't find in
+// the module cache.
+type NotCachedError struct {
+	Module string
+}
+
+func (e *NotCachedError) Error() string {
+	return fmt.Sprintf("module %s not in cache", e.Module)
+}
+
+func (e *NotCachedError) Unwrap() error {
+	return ErrNotCached
+}
+
+// checkCached returns a NotCachedError for the first of the bundle's
+// dependencies, in path order, that isn't in the module cache. It is used
+// before loading offline, so a missing module fails fast with a clear error
+// rather than as an import failure deep in the bundle.
+func (b *Bundle) checkCached() error {
+	deps, err := b.Dependencies()
+	if err != nil {
+		// Bundles without a module file have no dependencies to check.
+		b.logger.Debug("not checking the module cache", "err", err)
+		return nil
+	}
+
+	registry, err := modconfig.NewRegistry(&modconfig.Config{
+		Env: b.env,
+	})
+	if err != nil {
+		return fmt.Errorf("creating module registry: %w", err)
+	}
+
+	for _, dep := range deps {
+		modVer, err := module.NewVersion(dep.Path, dep.Version)
+		if err != nil {
+			continue
+		}
+		if _, err := registry.FetchFromCache(modVer); err != nil {
+			if errors.Is(err, modregistry.ErrNotFound) {
+				return &NotCachedError{Module: modVer.String()}
+			}
+			return fmt.Errorf("looking up %s in the module cache: %w", modVer, err)
+		}
+	}
+	return nil
+}
+
+// latestVersion returns the highest valid semver in versions, preferring
+// releases over pre-releases.
+func latestVersion(versions []string) string {
+	var latest, latestPrerelease string
+	for _, v := range versions {
+		if !semver.IsValid(v) {
+			continue
+		}
+		if semver.Prerelease(v) != "" {
+			if semver.Compare(v, latestPrerelease) > 0 {
+				latestPrerelease = v
+			}
+			continue
+		}
+		if semver.Compare(v, latest) > 0 {
+			latest = v
+		}
+	}
+	if latest == "" {
+		return latestPrerelease
+	}
+	return latest
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/mod/modregistrytest"
+)
+
+func TestLatestVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		want     string
+	}{
+		{
+			name:     "highest release",
+			versions: []string{"v0.1.0", "v0.10.0", "v0.2.0"},
+			want:     "v0.10.0",
+		},
+		{
+			name:     "releases preferred over pre-releases",
+			versions: []string{"v0.1.0", "v0.2.0-rc.1"},
+			want:     "v0.1.0",
+		},
+		{
+			name:     "pre-release when no releases",
+			versions: []string{"v0.1.0-alpha", "v0.1.0-beta"},
+			want:     "v0.1.0-beta",
+		},
+		{
+			name:     "invalid versions ignored",
+			versions: []string{"latest", "v0.1.0"},
+			want:     "v0.1.0",
+		},
+		{
+			name: "no versions",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := latestVersion(tt.versions); got != tt.want {
+				t.Errorf("latestVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	registryDir := t.TempDir()
+	for _, version := range []string{"v0.1.0", "v0.2.0"} {
+		writeFiles(t, filepath.Join(registryDir, "example.com_platform_"+version), map[string]string{
+			"cue.mod/module.cue": `module: "example.com/platform@v0"
+language: version: "v0.14.0"
+`,
+			"platform.cue": "package platform\n",
+		})
+	}
+	writeFiles(t, filepath.Join(registryDir, "example.com_current_v0.3.0"), map[string]string{
+		"cue.mod/module.cue": `module: "example.com/current@v0"
+language: version: "v0.14.0"
+`,
+		"current.cue": "package current\n",
+	})
+
+	registry, err := modregistrytest.New(os.DirFS(registryDir), "")
+	if err != nil {
+		t.Fatalf("failed to start registry: %v", err)
+	}
+	t.Cleanup(registry.Close)
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/bundle@v0"
+language: version: "v0.14.0"
+deps: {
+	"example.com/platform@v0": v: "v0.1.0"
+	"example.com/current@v0": v: "v0.3.0"
+	"unreachable.example.com/missing@v0": v: "v0.1.0"
+}
+`,
+		"bundle.cue": "package bundle\n",
+	})
+
+	b, err := LoadBundle(dir,
+		WithRegistries(map[string]string{
+			"example.com":             registry.Host(),
+			"unreachable.example.com": "127.0.0.1:1",
+		}),
+		WithCacheDir(t.TempDir()),
+	)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	statuses, err := b.CheckDependencies(context.Background())
+	if err != nil {
+		t.Fatalf("CheckDependencies() error = %v", err)
+	}
+	if len(statuses) != 3 {
+		t.Fatalf("CheckDependencies() returned %d statuses, want 3", len(statuses))
+	}
+
+	tests := []struct {
+		path         string
+		wantLatest   string
+		wantOutdated bool
+		wantErr      bool
+	}{
+		{path: "example.com/current@v0", wantLatest: "v0.3.0"},
+		{path: "example.com/platform@v0", wantLatest: "v0.2.0", wantOutdated: true},
+		{path: "unreachable.example.com/missing@v0", wantErr: true},
+	}
+	for i, tt := range tests {
+		status := statuses[i]
+		if status.Path != tt.path {
+			t.Errorf("statuses[%d].Path = %q, want %q", i, status.Path, tt.path)
+			continue
+		}
+		if (status.Err != nil) != tt.wantErr {
+			t.Errorf("%s: Err = %v, wantErr %v", tt.path, status.Err, tt.wantErr)
+		}
+		if status.Latest != tt.wantLatest {
+			t.Errorf("%s: Latest = %q, want %q", tt.path, status.Latest, tt.wantLatest)
+		}
+		if status.Outdated() != tt.wantOutdated {
+			t.Errorf("%s: Outdated() = %v, want %v", tt.path, status.Outdated(), tt.wantOutdated)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+
+package source
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"go-valkyrie.com/odin/internal/git"
+)
+
+// gitSource is a bundle checked out from a git repository. Locations take the
+// form git+https://host/repo.git#ref:subdir, where the ref and subdirectory
+// are both optional; git:// and git+ssh:// (or any other git+ transport) are
+// accepted too.
+type gitSource struct {
+	raw     string
+	url     string
+	ref     string
+	subdir  string
+	tempDir string
+	logger  *slog.Logger
+}
+
+func isGitLocation(location string) bool {
+	return strings.HasPrefix(location, "git://") || strings.HasPrefix(location, "git+")
+}
+
+func newGit(location string, logger *slog.Logger) (Source, error) {
+	url, fragment, _ := strings.Cut(strings.TrimPrefix(location, "git+"), "#")
+	// Git ref names can't contain ':', so it's safe to split on it.
+	ref, subdir, _ := strings.Cut(fragment, ":")
+	if url == "" {
+		return nil, fmt.Errorf("invalid git source %q: missing repository URL", location)
+	}
+	if subdir != "" {
+		subdir = path.Clean(subdir)
+		if path.IsAbs(subdir) || subdir == ".." || strings.HasPrefix(subdir, "../") {
+			return nil, fmt.Errorf("invalid git source %q: subdirectory must be relative to the repository", location)
+		}
+	}
+	return &gitSource{
+		raw:    location,
+		url:    url,
+		ref:    ref,
+		subdir: subdir,
+		logger: logger,
+	}, nil
+}
+
+func (s *gitSource) Prepare() error {
+	tempDir, err := os.MkdirTemp("", "odin-git-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	s.tempDir = tempDir
+
+	var sparseDirs []string
+	if s.subdir != "" && s.subdir != "." {
+		sparseDirs = []string{s.subdir}
+	}
+
+	s.logger.Debug("cloning git source", "url", s.url, "ref", s.ref, "subdir", s.subdir)
+	if _, err := git.CloneAt(s.url, s.ref, tempDir, sparseDirs); err != nil {
+		os.RemoveAll(tempDir)
+		s.tempDir = ""
+		return fmt.Errorf("failed to check out git bundle: %w", err)
+	}
+	return nil
+}
+
+func (s *gitSource) String() string {
+	if s.tempDir != "" {
+		return filepath.Join(s.tempDir, filepath.FromSlash(s.subdir))
+	}
+	return s.raw
+}
+
+func (s *gitSource) Load(ctx *cue.Context, opts *LoadOptions) (cue.Value, error) {
+	if s.tempDir == "" {
+		return cue.Value{}, fmt.Errorf("git source not prepared (call Prepare first)")
+	}
+	return local(s.String()).Load(ctx, opts)
+}
+
+func (s *gitSource) Close() error {
+	if s.tempDir != "" {
+		return os.RemoveAll(s.tempDir)
+	}
+	return nil
+}
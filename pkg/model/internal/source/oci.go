@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 
 	"cuelang.org/go/cue"
 	"go-valkyrie.com/odin/pkg/oci"
@@ -39,10 +40,31 @@ func (s *ociSource) Prepare() error {
 	s.tempDir = tempDir
 
 	ctx := context.Background()
-	if err := oci.Pull(ctx, s.ref, tempDir, s.logger); err != nil {
+	if _, err := oci.Pull(ctx, s.ref, tempDir, false, s.logger); err != nil {
 		os.RemoveAll(tempDir)
 		return fmt.Errorf("failed to pull OCI bundle: %w", err)
 	}
+	if err := checkBundleLayout(tempDir); err != nil {
+		os.RemoveAll(tempDir)
+		return fmt.Errorf("pulled artifact is not a valid odin bundle: %w", err)
+	}
+	return nil
+}
+
+// checkBundleLayout reports whether dir holds a bundle: a CUE module with
+// CUE files at its root. The pulled directory is checked on its own rather
+// than by searching upwards, since its parent is the system temp directory.
+func checkBundleLayout(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, "cue.mod", "module.cue")); err != nil {
+		return fmt.Errorf("missing cue.mod/module.cue")
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.cue"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no CUE files at the bundle root")
+	}
 	return nil
 }
 
@@ -4,6 +4,7 @@ package source
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
@@ -29,14 +30,23 @@ type Source interface {
 }
 
 // New returns a Source for the given location. OCI URIs (oci://) return an
-// ociSource; everything else is treated as a local filesystem path.
+// ociSource, git URLs (git:// or git+<transport>://) return a gitSource, and
+// everything else is treated as a local filesystem path. The logger
+// is used by sources that perform I/O of their own (e.g. pulling from a
+// registry); a nil logger discards their output.
 func New(location string, logger *slog.Logger) (Source, error) {
 	if strings.HasPrefix(location, "oci://") {
 		if logger == nil {
-			logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+			logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 		}
 		return newOCI(location, logger)
 	}
+	if isGitLocation(location) {
+		if logger == nil {
+			logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+		}
+		return newGit(location, logger)
+	}
 	return local(location), nil
 }
 
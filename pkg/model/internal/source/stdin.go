@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+
+package source
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/mod/modfile"
+	"github.com/rogpeppe/go-internal/txtar"
+)
+
+// StdinLocation is the bundle location that reads the bundle from standard
+// input rather than from disk.
+const StdinLocation = "-"
+
+// stdinModule is the module path given to a bundle read from stdin without a
+// cue.mod/module.cue of its own.
+const stdinModule = "stdin.odin.go-valkyrie.com/bundle@v0"
+
+type stdinSource struct {
+	r       io.Reader
+	tempDir string
+}
+
+// NewStdin returns a Source that reads a bundle from r, either a txtar
+// archive of the bundle's files or a single CUE file. Like an OCI source it
+// must be prepared, which writes the bundle to a temporary directory it is
+// then loaded from. A bundle without a cue.mod/module.cue is given one with
+// no dependencies, so a bundle that imports other modules must be passed as
+// a txtar archive including its module file.
+func NewStdin(r io.Reader) Source {
+	return &stdinSource{r: r}
+}
+
+func (s *stdinSource) Prepare() error {
+	data, err := io.ReadAll(s.r)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle from stdin: %w", err)
+	}
+
+	files := txtar.Parse(data).Files
+	if len(files) == 0 {
+		if len(data) == 0 {
+			return fmt.Errorf("no bundle on stdin")
+		}
+		files = []txtar.File{{Name: "bundle.cue", Data: data}}
+	}
+
+	tempDir, err := os.MkdirTemp("", "odin-stdin-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	if err := writeArchive(tempDir, files); err != nil {
+		os.RemoveAll(tempDir)
+		return err
+	}
+	s.tempDir = tempDir
+	return nil
+}
+
+// writeArchive writes files to dir, adding a cue.mod/module.cue if there
+// isn't one.
+func writeArchive(dir string, files []txtar.File) error {
+	hasModule := false
+	for _, f := range files {
+		name := filepath.FromSlash(f.Name)
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid file name %q in bundle archive", f.Name)
+		}
+		if name == filepath.Join("cue.mod", "module.cue") {
+			hasModule = true
+		}
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, f.Data, 0644); err != nil {
+			return err
+		}
+	}
+	if hasModule {
+		return nil
+	}
+
+	data, err := modfile.Format(&modfile.File{
+		Module:   stdinModule,
+		Language: &modfile.Language{Version: cue.LanguageVersion()},
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "cue.mod"), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "cue.mod", "module.cue"), data, 0644)
+}
+
+func (s *stdinSource) String() string {
+	if s.tempDir != "" {
+		return s.tempDir
+	}
+	return StdinLocation
+}
+
+func (s *stdinSource) Load(ctx *cue.Context, opts *LoadOptions) (cue.Value, error) {
+	if s.tempDir == "" {
+		return cue.Value{}, fmt.Errorf("stdin source not prepared (call Prepare first)")
+	}
+	return local(s.tempDir).Load(ctx, opts)
+}
+
+func (s *stdinSource) Close() error {
+	if s.tempDir != "" {
+		return os.RemoveAll(s.tempDir)
+	}
+	return nil
+}
@@ -3,18 +3,29 @@
 package source
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/load"
+	"cuelang.org/go/encoding/yaml"
 	"go-valkyrie.com/odin/internal/utils"
 	"go-valkyrie.com/odin/internal/utils/regexpext"
 )
 
 var _valuesFilePattern = utils.Must(regexpext.NewMatcher(`^((?P<Format>[\w]*): )?(?P<Path>.*$)`))
 
+// valuesFormats are the encodings accepted as a "format: path" prefix.
+var valuesFormats = []string{"cue", "json", "toml", "yaml", k8sFormat}
+
+// k8sFormat reads values from the data of a Kubernetes ConfigMap or Secret
+// manifest; see loadK8sValues.
+const k8sFormat = "k8s"
+
 type valuesFile struct {
 	format string
 	path   string
@@ -27,6 +38,27 @@ func (f *valuesFile) String() string {
 	return fmt.Sprintf("%s: %s", f.format, f.path)
 }
 
+// ResolveValuesLocation resolves a relative values location against dir,
+// keeping any "format: " prefix intact.
+func ResolveValuesLocation(location, dir string) string {
+	match := _valuesFilePattern.Match(location)
+	path := match.Named("Path")
+	if path == "" || filepath.IsAbs(path) {
+		return location
+	}
+	path = filepath.Join(dir, path)
+	if format := match.Named("Format"); format != "" {
+		return fmt.Sprintf("%s: %s", format, path)
+	}
+	return path
+}
+
+// ValuesLocationPath returns the file path of a values location, without any
+// "format: " prefix.
+func ValuesLocationPath(location string) string {
+	return _valuesFilePattern.Match(location).Named("Path")
+}
+
 // Values is a source for values overlays loaded from one or more files.
 type Values struct {
 	locations []valuesFile
@@ -40,6 +72,10 @@ func NewValues(locations []string) (*Values, error) {
 				format: match.Named("Format"),
 				path:   match.Named("Path"),
 			}
+			if file.format != "" && !slices.Contains(valuesFormats, file.format) {
+				return nil, fmt.Errorf("unsupported values format %q for %s (supported: %s)",
+					file.format, file.path, strings.Join(valuesFormats, ", "))
+			}
 			if _, err := os.Stat(file.path); err != nil {
 				return nil, err
 			}
@@ -63,24 +99,106 @@ func (s *Values) String() string {
 
 func (s *Values) Load(ctx *cue.Context, opts *LoadOptions) (cue.Value, error) {
 	args := make([]string, 0, len(s.locations)*2)
+	var manifests []cue.Value
 	for _, file := range s.locations {
-		if file.format != "" {
+		if file.format == k8sFormat {
+			v, err := loadK8sValues(ctx, file.path)
+			if err != nil {
+				return cue.Value{}, err
+			}
+			manifests = append(manifests, v)
+		} else if file.format != "" {
 			args = append(args, fmt.Sprintf("%s:", file.format), file.path)
 		} else {
 			args = append(args, file.path)
 		}
 	}
 
-	inst := load.Instances(args, &load.Config{
-		DataFiles: true,
-		Env:       opts.Env,
-	})[0]
+	values := ctx.CompileString("{}")
+	if len(args) > 0 {
+		inst := load.Instances(args, &load.Config{
+			DataFiles: true,
+			Env:       opts.Env,
+		})[0]
 
-	if configure := opts.InstanceConfiguration; configure != nil {
-		if err := configure(inst); err != nil {
-			return cue.Value{}, err
+		if configure := opts.InstanceConfiguration; configure != nil {
+			if err := configure(inst); err != nil {
+				return cue.Value{}, err
+			}
 		}
+
+		values = ctx.BuildInstance(inst)
 	}
 
-	return ctx.BuildInstance(inst), nil
+	for _, v := range manifests {
+		values = values.Unify(v)
+	}
+	return values, nil
+}
+
+// loadK8sValues reads a Kubernetes ConfigMap or Secret manifest and returns
+// its data as values, each key becoming a top-level string field. The
+// base64-encoded data of a Secret and binaryData of a ConfigMap are decoded,
+// and stringData takes precedence over data, as when Kubernetes applies the
+// manifest. The rest of the manifest is ignored.
+func loadK8sValues(ctx *cue.Context, path string) (cue.Value, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return cue.Value{}, err
+	}
+	file, err := yaml.Extract(path, src)
+	if err != nil {
+		return cue.Value{}, err
+	}
+	manifest := ctx.BuildFile(file)
+	if err := manifest.Err(); err != nil {
+		return cue.Value{}, err
+	}
+
+	kind, _ := manifest.LookupPath(cue.ParsePath("kind")).String()
+	var encoded, plain []string
+	switch kind {
+	case "ConfigMap":
+		encoded, plain = []string{"binaryData"}, []string{"data"}
+	case "Secret":
+		encoded, plain = []string{"data"}, []string{"stringData"}
+	default:
+		return cue.Value{}, fmt.Errorf("%s: k8s values must be a ConfigMap or Secret, not %q", path, kind)
+	}
+
+	data := make(map[string]string)
+	read := func(field string, decode bool) error {
+		iter, err := manifest.LookupPath(cue.MakePath(cue.Str(field))).Fields()
+		if err != nil {
+			// The field is missing or isn't a map; there's nothing to read.
+			return nil
+		}
+		for iter.Next() {
+			key := iter.Selector().Unquoted()
+			value, err := iter.Value().String()
+			if err != nil {
+				return fmt.Errorf("%s: %s.%s: %w", path, field, key, err)
+			}
+			if decode {
+				decoded, err := base64.StdEncoding.DecodeString(value)
+				if err != nil {
+					return fmt.Errorf("%s: %s.%s: invalid base64: %w", path, field, key, err)
+				}
+				value = string(decoded)
+			}
+			data[key] = value
+		}
+		return nil
+	}
+	for _, field := range encoded {
+		if err := read(field, true); err != nil {
+			return cue.Value{}, err
+		}
+	}
+	for _, field := range plain {
+		if err := read(field, false); err != nil {
+			return cue.Value{}, err
+		}
+	}
+	return ctx.Encode(data), nil
 }
@@ -0,0 +1,336 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resolveOptions holds options for ResolveComponentTemplate.
+type resolveOptions struct {
+	caseSensitive bool
+}
+
+// ResolveOption is a functional option for ResolveComponentTemplate and the
+// bundle methods that resolve references.
+type ResolveOption func(*resolveOptions)
+
+// WithCaseSensitive makes definition and package names match only in the
+// same case, for bundles with definitions that differ only in case. By
+// default they match case-insensitively.
+func WithCaseSensitive(caseSensitive bool) ResolveOption {
+	return func(o *resolveOptions) {
+		o.caseSensitive = caseSensitive
+	}
+}
+
+// equal compares two names, ignoring case unless caseSensitive is set.
+func (o *resolveOptions) equal(a, b string) bool {
+	if o.caseSensitive {
+		return a == b
+	}
+	return strings.EqualFold(a, b)
+}
+
+// preferExact narrows templates matched ignoring case to those for which
+// exact reports a match in the same case, if there are any.
+func preferExact(templates []*ComponentTemplate, exact func(*ComponentTemplate) bool) []*ComponentTemplate {
+	if len(templates) < 2 {
+		return templates
+	}
+	var exactMatches []*ComponentTemplate
+	for _, tmpl := range templates {
+		if exact(tmpl) {
+			exactMatches = append(exactMatches, tmpl)
+		}
+	}
+	if len(exactMatches) > 0 {
+		return exactMatches
+	}
+	return templates
+}
+
+// ResolveComponentTemplate matches a reference string against a list of
+// component templates.
+// Resolution order:
+//  1. Fully qualified (contains ":#") — exact match, else by package path
+//     with an optional, possibly partial, version ("pkg@v1:#Def")
+//  2. Definition name — strip "#", case-insensitive; unique match wins
+//  3. Package name — last path segment, strip @vN, case-insensitive; unique def in package wins
+//  4. Dot syntax "package.Definition" — case-insensitive match on both parts;
+//     unique match wins
+//  5. Error with available templates
+//
+// When names in steps 2 to 4 match more than one template ignoring case, the
+// templates matching in the same case win; without a unique one the
+// reference is ambiguous. With WithCaseSensitive, steps 2 to 4 match names
+// in the same case only.
+func ResolveComponentTemplate(reference string, templates []*ComponentTemplate, opts ...ResolveOption) (*ComponentTemplate, error) {
+	o := &resolveOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no component templates available")
+	}
+
+	// 1. Fully qualified match: package:#Definition
+	if strings.Contains(reference, ":#") {
+		pkg, def, _ := strings.Cut(reference, ":#")
+		for _, tmpl := range templates {
+			if tmpl.Package == pkg && tmpl.Name == "#"+def {
+				return tmpl, nil
+			}
+		}
+		for _, tmpl := range templates {
+			fullPkg := tmpl.Package + "@" + tmpl.Version
+			if fullPkg == pkg && tmpl.Name == "#"+def {
+				return tmpl, nil
+			}
+		}
+		var versionMatches []*ComponentTemplate
+		for _, tmpl := range templates {
+			if tmpl.Name == "#"+def && tmpl.matchesVersioned(pkg) {
+				versionMatches = append(versionMatches, tmpl)
+			}
+		}
+		if len(versionMatches) == 1 {
+			return versionMatches[0], nil
+		} else if len(versionMatches) > 1 {
+			return nil, &AmbiguousReferenceError{Reference: reference, Candidates: versionMatches}
+		}
+		return nil, fmt.Errorf("no component template found matching %q", reference)
+	}
+
+	// 2. Definition name match (strip "#", case-insensitive)
+	var defMatches []*ComponentTemplate
+	for _, tmpl := range templates {
+		defName := strings.TrimPrefix(tmpl.Name, "#")
+		if o.equal(defName, reference) {
+			defMatches = append(defMatches, tmpl)
+		}
+	}
+	defMatches = preferExact(defMatches, func(tmpl *ComponentTemplate) bool {
+		return strings.TrimPrefix(tmpl.Name, "#") == reference
+	})
+	if len(defMatches) == 1 {
+		return defMatches[0], nil
+	}
+
+	// 3. Package name match (last path segment, strip @vN, case-insensitive)
+	var pkgMatches []*ComponentTemplate
+	for _, tmpl := range templates {
+		if o.equal(PackageShorthand(tmpl.Package), reference) {
+			pkgMatches = append(pkgMatches, tmpl)
+		}
+	}
+	pkgMatches = preferExact(pkgMatches, func(tmpl *ComponentTemplate) bool {
+		return PackageShorthand(tmpl.Package) == reference
+	})
+	if len(pkgMatches) == 1 {
+		return pkgMatches[0], nil
+	}
+
+	// 4. Dot syntax: "package.Definition" (case-insensitive)
+	if dotIdx := strings.LastIndex(reference, "."); dotIdx != -1 {
+		pkgPart := reference[:dotIdx]
+		defPart := reference[dotIdx+1:]
+		var dotMatches []*ComponentTemplate
+		for _, tmpl := range templates {
+			pkg := PackageShorthand(tmpl.Package)
+			def := strings.TrimPrefix(tmpl.Name, "#")
+			if o.equal(pkg, pkgPart) && o.equal(def, defPart) {
+				dotMatches = append(dotMatches, tmpl)
+			}
+		}
+		dotMatches = preferExact(dotMatches, func(tmpl *ComponentTemplate) bool {
+			return PackageShorthand(tmpl.Package) == pkgPart && strings.TrimPrefix(tmpl.Name, "#") == defPart
+		})
+		if len(dotMatches) == 1 {
+			return dotMatches[0], nil
+		} else if len(dotMatches) > 1 {
+			return nil, &AmbiguousReferenceError{Reference: reference, Candidates: dotMatches}
+		}
+	}
+
+	// 5. Error with available templates
+	// If we had ambiguous matches from step 2 (definition name), show those as candidates
+	if len(defMatches) > 1 {
+		return nil, &AmbiguousReferenceError{Reference: reference, Candidates: defMatches}
+	}
+	// If we had ambiguous matches from step 3 (package name), show those as candidates
+	if len(pkgMatches) > 1 {
+		return nil, &AmbiguousReferenceError{Reference: reference, Candidates: pkgMatches}
+	}
+
+	// No match at all
+	available := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		available = append(available, tmpl.ShortReference())
+	}
+	return nil, fmt.Errorf("no component template matching %q; available: %s", reference, strings.Join(available, ", "))
+}
+
+// ResolvePackagePath returns the templates whose package path starts with
+// reference, ignoring case unless WithCaseSensitive is set. Both paths have
+// any @vN suffix stripped before comparison. The templates are sorted by
+// package, then by name.
+func ResolvePackagePath(reference string, templates []*ComponentTemplate, opts ...ResolveOption) []*ComponentTemplate {
+	o := &resolveOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// Strip @vN from reference if present
+	refPath := reference
+	if idx := strings.LastIndex(refPath, "@"); idx != -1 {
+		refPath = refPath[:idx]
+	}
+	if !o.caseSensitive {
+		refPath = strings.ToLower(refPath)
+	}
+
+	var matches []*ComponentTemplate
+	for _, tmpl := range templates {
+		// Strip @vN from package path
+		pkgPath := tmpl.Package
+		if idx := strings.LastIndex(pkgPath, "@"); idx != -1 {
+			pkgPath = pkgPath[:idx]
+		}
+		if !o.caseSensitive {
+			pkgPath = strings.ToLower(pkgPath)
+		}
+
+		// Check if package path starts with reference
+		if strings.HasPrefix(pkgPath, refPath) {
+			matches = append(matches, tmpl)
+		}
+	}
+
+	// Sort by Package then Name
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Package != matches[j].Package {
+			return matches[i].Package < matches[j].Package
+		}
+		return matches[i].Name < matches[j].Name
+	})
+
+	return matches
+}
+
+// LookupComponentTemplate discovers all of the bundle's component templates
+// and returns the one reference resolves to, following the rules of
+// ResolveComponentTemplate.
+func (b *Bundle) LookupComponentTemplate(ctx context.Context, reference string, opts ...ResolveOption) (*ComponentTemplate, error) {
+	templates, err := b.collectComponentTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveComponentTemplate(reference, templates, opts...)
+}
+
+// FindComponentTemplate returns the template reference resolves to, like
+// LookupComponentTemplate, but discovers as little as it can. A fully
+// qualified reference ("package:#Definition") can only match templates in
+// that package, so only the module containing it is scanned, only that
+// package is built, and discovery stops at the first exact match. Other
+// references, such as a bare definition name, could be ambiguous and still
+// discover every template.
+func (b *Bundle) FindComponentTemplate(ctx context.Context, reference string, opts ...ResolveOption) (*ComponentTemplate, error) {
+	pkg, def, qualified := strings.Cut(reference, ":#")
+	if !qualified {
+		return b.LookupComponentTemplate(ctx, reference, opts...)
+	}
+
+	narrowed := *b
+	narrowed.onlyPackage = unversionedPath(pkg)
+	var templates []*ComponentTemplate
+	for tmpl, err := range narrowed.ComponentTemplates(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if tmpl.Package == pkg && tmpl.Name == "#"+def {
+			return tmpl, nil
+		}
+		templates = append(templates, tmpl)
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no component template found matching %q", reference)
+	}
+	return ResolveComponentTemplate(reference, templates, opts...)
+}
+
+// matchesVersioned reports whether the package part of a fully qualified
+// reference, "path" or "path@version", names t's package. The version may be
+// empty, matching any version, and otherwise matches t's module version or
+// the major version in its import path, either exactly or as a prefix ending
+// at a version component: "v1" and "v1.2" both match "v1.2.3".
+func (t *ComponentTemplate) matchesVersioned(pkg string) bool {
+	path, version, _ := strings.Cut(pkg, "@")
+	if path != unversionedPath(t.Package) {
+		return false
+	}
+	if version == "" {
+		return true
+	}
+	_, major, _ := strings.Cut(t.Package, "@")
+	major, _, _ = strings.Cut(major, ":")
+	for _, v := range []string{t.Version, major} {
+		if v != "" && (v == version || strings.HasPrefix(v, version+".") || strings.HasPrefix(v, version+"-")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bundle) collectComponentTemplates(ctx context.Context) ([]*ComponentTemplate, error) {
+	var templates []*ComponentTemplate
+	for tmpl, err := range b.ComponentTemplates(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+// ShortReference returns the template's short reference,
+// "package.Definition", which ResolveComponentTemplate resolves back to it
+// unless another template has the same one.
+func (t *ComponentTemplate) ShortReference() string {
+	return PackageShorthand(t.Package) + "." + strings.TrimPrefix(t.Name, "#")
+}
+
+// PackageShorthand extracts the last path segment from a package import path,
+// stripping any @vN version suffix.
+func PackageShorthand(pkg string) string {
+	// Strip @vN suffix if present
+	if idx := strings.LastIndex(pkg, "@"); idx != -1 {
+		pkg = pkg[:idx]
+	}
+	// Get last path segment
+	if idx := strings.LastIndex(pkg, "/"); idx != -1 {
+		return pkg[idx+1:]
+	}
+	return pkg
+}
+
+// AmbiguousReferenceError is returned when a reference matches more than one
+// component template. Tools can use errors.As to offer the candidates for
+// the user to pick from.
+type AmbiguousReferenceError struct {
+	Reference  string
+	Candidates []*ComponentTemplate
+}
+
+func (e *AmbiguousReferenceError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, m := range e.Candidates {
+		candidates = append(candidates, fmt.Sprintf("%s (%s)", m.ShortReference(), m.Package))
+	}
+	return fmt.Sprintf("ambiguous reference %q matches multiple templates:\n  %s", e.Reference, strings.Join(candidates, "\n  "))
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLookupComponentTemplate(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	b, err := LoadBundle(dir, opts...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	tests := []struct {
+		reference string
+		want      string
+		wantErr   string
+	}{
+		{reference: "example.com/platform/workload@v0:#WebApp", want: "#WebApp"},
+		{reference: "example.com/platform/workload@v0:#Missing", wantErr: "no component template found"},
+		{reference: "webapp", want: "#WebApp"},
+		{reference: "workload.Deployment", want: "#Deployment"},
+		{reference: "workload", wantErr: "ambiguous reference"},
+		{reference: "missing", wantErr: "available: workload.WebApp, workload.Deployment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			tmpl, err := b.LookupComponentTemplate(context.Background(), tt.reference)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LookupComponentTemplate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LookupComponentTemplate() error = %v", err)
+			}
+			if tmpl.Name != tt.want {
+				t.Errorf("LookupComponentTemplate() = %s, want %s", tmpl.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindComponentTemplate(t *testing.T) {
+	bundleCue := webAppBundle + `
+#Worker: odin.#Component & {
+	config: queue: string
+	resources: {}
+}
+`
+	dir, opts := setupTemplateBundle(t, bundleCue)
+
+	tests := []struct {
+		reference  string
+		want       string
+		wantBuilds int
+		wantErr    string
+	}{
+		{reference: "example.com/platform/workload@v0:#Deployment", want: "#Deployment", wantBuilds: 1},
+		{reference: "test.example.com/bundle@v0:#Worker", want: "#Worker", wantBuilds: 1},
+		{reference: "example.com/platform/workload@v0:#Missing", wantErr: "no component template found"},
+		{reference: "example.com/other@v0:#WebApp", wantErr: "no component template found"},
+		{reference: "worker", want: "#Worker", wantBuilds: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			timings := &Timings{}
+			b, err := LoadBundle(dir, append(opts, WithTimings(timings))...)
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+			tmpl, err := b.FindComponentTemplate(context.Background(), tt.reference)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FindComponentTemplate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindComponentTemplate() error = %v", err)
+			}
+			if tmpl.Name != tt.want {
+				t.Errorf("FindComponentTemplate() = %s, want %s", tmpl.Name, tt.want)
+			}
+			for _, p := range timings.Phases() {
+				if p.Phase == PhaseBuild && p.Count != tt.wantBuilds {
+					t.Errorf("built %d packages, want %d", p.Count, tt.wantBuilds)
+				}
+			}
+		})
+	}
+}
+
+func TestPackageShorthand(t *testing.T) {
+	tests := []struct {
+		pkg  string
+		want string
+	}{
+		{"platform.example.com/workload", "workload"},
+		{"platform.example.com/workload@v0", "workload"},
+		{"platform.example.com/workload@v0.1.0", "workload"},
+		{"workload", "workload"},
+		{"workload@v1", "workload"},
+		{"example.com/foo/bar", "bar"},
+		{"example.com/foo/bar@v2", "bar"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			got := PackageShorthand(tt.pkg)
+			if got != tt.want {
+				t.Errorf("PackageShorthand(%q) = %q, want %q", tt.pkg, got, tt.want)
+			}
+		})
+	}
+}
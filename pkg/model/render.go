@@ -0,0 +1,335 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"go-valkyrie.com/odin/pkg/model/internal/source"
+)
+
+// RevisionAnnotation is the annotation WithRevisionStamp sets to the bundle's
+// git revision.
+const RevisionAnnotation = "odin.go-valkyrie.com/revision"
+
+const (
+	// OrderName sorts resources by component and resource name.
+	OrderName = "name"
+	// OrderApply sorts resources by kind so they can be applied in one pass,
+	// with the name order as a tiebreak.
+	OrderApply = "apply"
+)
+
+// DefaultApplyOrder is the kind priority used by OrderApply. Kinds that aren't
+// listed sort after all listed kinds.
+var DefaultApplyOrder = []string{
+	"Namespace",
+	"CustomResourceDefinition",
+	"PriorityClass",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodSecurityPolicy",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicaSet",
+	"Deployment",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"Ingress",
+	"APIService",
+}
+
+// renderOptions holds options for Render.
+type renderOptions struct {
+	order          string
+	kinds          []string
+	stampRevision  bool
+	concreteConfig bool
+	selector       map[string]string
+}
+
+// RenderOption is a functional option for Render.
+type RenderOption func(*renderOptions)
+
+// WithResourceOrder sets the order Render returns resources in, OrderName
+// (the default) or OrderApply. kinds overrides DefaultApplyOrder for
+// OrderApply when non-empty.
+func WithResourceOrder(order string, kinds []string) RenderOption {
+	return func(o *renderOptions) {
+		o.order = order
+		o.kinds = kinds
+	}
+}
+
+// WithRevisionStamp annotates every resource with the git revision of the
+// bundle under RevisionAnnotation. A bundle that isn't in a git repository
+// is rendered without the annotation and a warning is logged.
+func WithRevisionStamp(stamp bool) RenderOption {
+	return func(o *renderOptions) {
+		o.stampRevision = stamp
+	}
+}
+
+// WithConcreteConfig checks that each component's config is concrete before
+// rendering its resources, failing with a *ConfigError that lists the config
+// fields left unset rather than an error about a resource using them.
+func WithConcreteConfig(concrete bool) RenderOption {
+	return func(o *renderOptions) {
+		o.concreteConfig = concrete
+	}
+}
+
+// WithLabelSelector keeps only the resources whose metadata.labels include
+// every key and value in selector. Resources without labels never match a
+// non-empty selector.
+func WithLabelSelector(selector map[string]string) RenderOption {
+	return func(o *renderOptions) {
+		o.selector = selector
+	}
+}
+
+// ParseLabelSelector parses selectors of the form key=value, as given to
+// --selector, into a selector for WithLabelSelector.
+func ParseLabelSelector(selectors []string) (map[string]string, error) {
+	selector := make(map[string]string, len(selectors))
+	for _, s := range selectors {
+		key, value, ok := strings.Cut(s, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label selector %q, must be key=value", s)
+		}
+		selector[key] = strings.TrimSpace(value)
+	}
+	return selector, nil
+}
+
+// Render returns the resources of all of the bundle's components, sorted by
+// name unless WithResourceOrder says otherwise, after passing each through
+// the bundle's transforms. It fails if the bundle has errors, a component's
+// config isn't valid, a transform fails or a resource isn't concrete, so the
+// resources returned are ready to be written out.
+func (b *Bundle) Render(ctx context.Context, opts ...RenderOption) ([]*Resource, error) {
+	o := &renderOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := b.Error(); err != nil {
+		return nil, err
+	}
+
+	resources := make([]*Resource, 0)
+	for component := range b.Components() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := component.ValidConfig(); err != nil {
+			return nil, err
+		}
+		if o.concreteConfig {
+			if errs := component.ConcreteConfigErrors(); len(errs) > 0 {
+				return nil, &ConfigError{Component: component.Selector().String(), Errors: errs}
+			}
+		}
+		resources = slices.AppendSeq(resources, component.Resources())
+	}
+
+	resources, err := b.applyTransforms(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(o.selector) > 0 {
+		resources = slices.DeleteFunc(resources, func(r *Resource) bool {
+			return !matchesLabels(r.Labels(), o.selector)
+		})
+	}
+
+	if o.stampRevision {
+		if revision, ok := b.SourceRevision(); ok {
+			annotations := map[string]string{RevisionAnnotation: revision.String()}
+			for i, resource := range resources {
+				resources[i] = resource.WithAnnotations(annotations)
+			}
+		} else {
+			b.logger.Warn("bundle is not in a git repository, not stamping revision", "bundle", b.sourcePath)
+		}
+	}
+
+	if err := sortResources(resources, o.order, o.kinds); err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		if err := validateResource(resource); err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+// applyTransforms passes each resource through the bundle's transforms, in
+// the order they're declared: each transform's in is filled with the
+// resource and its out replaces it.
+func (b *Bundle) applyTransforms(resources []*Resource) ([]*Resource, error) {
+	defer lock(b.mu)()
+	iter, err := b.value.LookupPath(cue.ParsePath("transforms")).Fields()
+	if err != nil {
+		// The bundle has no transforms.
+		return resources, nil
+	}
+	type transform struct {
+		name  string
+		value cue.Value
+	}
+	var transforms []transform
+	for iter.Next() {
+		transforms = append(transforms, transform{iter.Selector().String(), iter.Value()})
+	}
+
+	transformed := make([]*Resource, 0, len(resources))
+	for _, resource := range resources {
+		value := resource.value
+		for _, t := range transforms {
+			out := t.value.FillPath(cue.ParsePath("in"), value).LookupPath(cue.ParsePath("out"))
+			if err := out.Err(); err != nil {
+				return nil, fmt.Errorf("transform %s failed on resource %s.%s: %w",
+					t.name, resource.owner.Selector(), resource.selector, err)
+			}
+			value = out
+		}
+		transformed = append(transformed, newResource(resource.owner, resource.selector, value))
+	}
+	return transformed, nil
+}
+
+// matchesLabels reports whether labels has every key and value in selector.
+func matchesLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if got, ok := labels[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+func validateResource(r *Resource) error {
+	defer lock(r.owner.mu)()
+	return r.value.Validate(cue.Concrete(true))
+}
+
+// sortResources sorts resources in place according to order. kinds overrides
+// DefaultApplyOrder for OrderApply when non-empty.
+func sortResources(resources []*Resource, order string, kinds []string) error {
+	byName := func(left, right *Resource) int {
+		lname := fmt.Sprintf("%s.%s", left.Owner().Selector(), left.Selector())
+		rname := fmt.Sprintf("%s.%s", right.Owner().Selector(), right.Selector())
+		return strings.Compare(lname, rname)
+	}
+
+	switch order {
+	case "", OrderName:
+		slices.SortFunc(resources, byName)
+	case OrderApply:
+		if len(kinds) == 0 {
+			kinds = DefaultApplyOrder
+		}
+		priority := func(r *Resource) int {
+			if i := slices.Index(kinds, r.Kind()); i >= 0 {
+				return i
+			}
+			return len(kinds)
+		}
+		slices.SortFunc(resources, func(left, right *Resource) int {
+			if c := priority(left) - priority(right); c != 0 {
+				return c
+			}
+			return byName(left, right)
+		})
+	default:
+		return fmt.Errorf("unknown resource order %q, must be %q or %q", order, OrderName, OrderApply)
+	}
+	return nil
+}
+
+// RenderTemplate instantiates tmpl, one of the bundle's component templates,
+// as a component named name outside of the bundle, and returns its resources
+// sorted by name. The files at valuesLocations, given as to WithValues, are
+// unified with the component, so they set its config under a config field,
+// as odin example writes it. Config that conflicts with the template or is
+// left incomplete fails with a *ConfigError listing each field.
+func (b *Bundle) RenderTemplate(tmpl *ComponentTemplate, name string, valuesLocations []string) ([]*Resource, error) {
+	unlock := lock(b.mu)
+	value := tmpl.Value.FillPath(cue.ParsePath("metadata.name"), name)
+	if len(valuesLocations) > 0 {
+		valuesSource, err := source.NewValues(valuesLocations)
+		if err != nil {
+			unlock()
+			return nil, err
+		}
+		values, err := valuesSource.Load(b.ctx, &source.LoadOptions{
+			Env:                   b.env,
+			InstanceConfiguration: configureValuesInstance,
+		})
+		if err != nil {
+			unlock()
+			return nil, err
+		}
+		value = value.Unify(values)
+	}
+	// Errors are found at paths within the template's package, e.g.
+	// "#WebApp.config.image", but reported from the component.
+	prefix := len(tmpl.Value.Path().Selectors())
+	errs := concreteConfigErrors(value, func(path []string) string {
+		if len(path) >= prefix {
+			path = path[prefix:]
+		}
+		return strings.Join(path, ".")
+	})
+	// Incomplete fields may come without a position of their own, so use
+	// where the template declares them.
+	for i, valueErr := range errs {
+		if valueErr.Position != "" {
+			continue
+		}
+		if pos := value.LookupPath(cue.ParsePath(valueErr.Path)).Pos(); pos.IsValid() {
+			errs[i].Position = pos.String()
+		}
+	}
+	unlock()
+	if len(errs) > 0 {
+		return nil, &ConfigError{Component: name, Errors: errs}
+	}
+
+	component := newComponent(b.mu, cue.Str(name), value)
+
+	resources := slices.Collect(component.Resources())
+	if err := sortResources(resources, OrderName, nil); err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		if err := validateResource(resource); err != nil {
+			return nil, err
+		}
+	}
+	return resources, nil
+}
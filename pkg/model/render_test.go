@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeRenderBundle writes a bundle without dependencies with two components
+// whose resources sort differently by name and by kind.
+func writeRenderBundle(t *testing.T, extra string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"cue.mod/module.cue": `module: "test.example.com/render@v0"
+language: version: "v0.14.0"
+`,
+		"bundle.cue": `package bundle
+
+metadata: name: "render"
+
+components: app: {
+	config: {}
+	resources: {
+		deployment: {kind: "Deployment", metadata: name: "app"}
+		config: {kind: "ConfigMap", metadata: name: "app"}
+	}
+}
+
+components: base: {
+	config: {}
+	resources: namespace: {kind: "Namespace", metadata: name: "web"}
+}
+` + extra,
+	})
+	return dir
+}
+
+func renderedNames(resources []*Resource) []string {
+	var names []string
+	for _, r := range resources {
+		names = append(names, r.Owner().Selector().String()+"."+r.Selector().String())
+	}
+	return names
+}
+
+func TestBundleRender(t *testing.T) {
+	b, err := LoadBundle(writeRenderBundle(t, ""), WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	ctx := context.Background()
+
+	resources, err := b.Render(ctx)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got, want := renderedNames(resources), []string{"app.config", "app.deployment", "base.namespace"}; !slices.Equal(got, want) {
+		t.Errorf("Render() = %v, want %v", got, want)
+	}
+
+	resources, err = b.Render(ctx, WithResourceOrder(OrderApply, nil))
+	if err != nil {
+		t.Fatalf("Render(apply) error = %v", err)
+	}
+	if got, want := renderedNames(resources), []string{"base.namespace", "app.config", "app.deployment"}; !slices.Equal(got, want) {
+		t.Errorf("Render(apply) = %v, want %v", got, want)
+	}
+
+	resources, err = b.Render(ctx, WithResourceOrder(OrderApply, []string{"Deployment"}))
+	if err != nil {
+		t.Fatalf("Render(apply, kinds) error = %v", err)
+	}
+	if got, want := renderedNames(resources), []string{"app.deployment", "app.config", "base.namespace"}; !slices.Equal(got, want) {
+		t.Errorf("Render(apply, kinds) = %v, want %v", got, want)
+	}
+
+	if _, err := b.Render(ctx, WithResourceOrder("random", nil)); err == nil || !strings.Contains(err.Error(), "unknown resource order") {
+		t.Errorf("Render(random) error = %v, want unknown resource order", err)
+	}
+}
+
+func TestBundleRenderConcreteConfig(t *testing.T) {
+	b, err := LoadBundle(writeRenderBundle(t, `
+components: app: {
+	config: {
+		replicas: int
+		image:    string | *"nginx"
+		name:     "app"
+	}
+	resources: deployment: spec: replicas: config.replicas
+}
+`), WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	if _, err := b.Render(context.Background()); err == nil {
+		t.Fatal("Render() succeeded with an unset replicas")
+	}
+
+	_, err = b.Render(context.Background(), WithConcreteConfig(true))
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		t.Fatalf("Render(WithConcreteConfig) error = %v, want *ConfigError", err)
+	}
+	if configErr.Component != "app" || len(configErr.Errors) != 1 {
+		t.Fatalf("ConfigError = %+v, want one error for app", configErr)
+	}
+	valueErr := configErr.Errors[0]
+	if valueErr.Path != "config.replicas" || !strings.Contains(valueErr.Message, "incomplete") || !strings.Contains(valueErr.Position, "bundle.cue:") {
+		t.Errorf("error = %+v, want an incomplete config.replicas with a position", valueErr)
+	}
+	if !strings.Contains(err.Error(), "component app has incomplete config:\n  config.replicas: ") {
+		t.Errorf("Error() = %q", err)
+	}
+}
+
+func TestBundleRenderRejectsIncompleteResources(t *testing.T) {
+	b, err := LoadBundle(writeRenderBundle(t, `
+components: app: resources: deployment: spec: replicas: int
+`), WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	if _, err := b.Render(context.Background()); err == nil || !strings.Contains(err.Error(), "replicas") {
+		t.Errorf("Render() error = %v, want an incomplete replicas error", err)
+	}
+}
+
+func TestBundleRenderLabelSelector(t *testing.T) {
+	b, err := LoadBundle(writeRenderBundle(t, `
+components: app: resources: {
+	deployment: metadata: labels: {tier: "frontend", team: "web"}
+	config: metadata: labels: tier: "backend"
+}
+`), WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		selectors []string
+		want      []string
+	}{
+		{[]string{"tier=frontend"}, []string{"app.deployment"}},
+		{[]string{"tier=frontend", "team=web"}, []string{"app.deployment"}},
+		{[]string{"tier=frontend", "team=ops"}, nil},
+		{[]string{"tier=database"}, nil},
+		{nil, []string{"app.config", "app.deployment", "base.namespace"}},
+	}
+	for _, tt := range tests {
+		selector, err := ParseLabelSelector(tt.selectors)
+		if err != nil {
+			t.Fatalf("ParseLabelSelector(%v) error = %v", tt.selectors, err)
+		}
+		resources, err := b.Render(ctx, WithLabelSelector(selector))
+		if err != nil {
+			t.Fatalf("Render(%v) error = %v", tt.selectors, err)
+		}
+		if got := renderedNames(resources); !slices.Equal(got, tt.want) {
+			t.Errorf("Render(%v) = %v, want %v", tt.selectors, got, tt.want)
+		}
+	}
+
+	if _, err := ParseLabelSelector([]string{"tier"}); err == nil {
+		t.Error("ParseLabelSelector(tier) succeeded, want an error")
+	}
+}
+
+func TestBundleRenderTemplate(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	b, err := LoadBundle(dir, opts...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	tmpl, err := b.FindComponentTemplate(context.Background(), "workload.WebApp")
+	if err != nil {
+		t.Fatalf("FindComponentTemplate() error = %v", err)
+	}
+
+	configDir := t.TempDir()
+	writeFiles(t, configDir, map[string]string{
+		"complete.yaml":   "config:\n  image: nginx\n  replicas: 2\n",
+		"incomplete.yaml": "config:\n  image: nginx\n",
+	})
+
+	resources, err := b.RenderTemplate(tmpl, "web", []string{filepath.Join(configDir, "complete.yaml")})
+	if err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("RenderTemplate() = %d resources, want 1", len(resources))
+	}
+	out, err := resources[0].ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	for _, want := range []string{"kind: Deployment", "name: web", "replicas: 2"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("rendered resource missing %q:\n%s", want, out)
+		}
+	}
+
+	_, err = b.RenderTemplate(tmpl, "web", []string{filepath.Join(configDir, "incomplete.yaml")})
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		t.Fatalf("RenderTemplate() with incomplete config error = %v, want *ConfigError", err)
+	}
+	if len(configErr.Errors) != 1 || configErr.Errors[0].Path != "config.replicas" || configErr.Errors[0].Position == "" {
+		t.Errorf("ConfigError = %+v, want config.replicas with a position", configErr)
+	}
+}
+
+func TestBundleRenderTransforms(t *testing.T) {
+	b, err := LoadBundle(writeRenderBundle(t, `
+components: app: resources: deployment: status: replicas: 1
+
+transforms: {
+	managedBy: {
+		in:  _
+		out: in & {metadata: labels: "app.kubernetes.io/managed-by": "odin"}
+	}
+	stripStatus: {
+		in: _
+		out: {for k, v in in if k != "status" {(k): v}}
+	}
+}
+`), WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	resources, err := b.Render(context.Background(), WithLabelSelector(map[string]string{"app.kubernetes.io/managed-by": "odin"}))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got, want := renderedNames(resources), []string{"app.config", "app.deployment", "base.namespace"}; !slices.Equal(got, want) {
+		t.Errorf("Render() = %v, want every resource labelled: %v", got, want)
+	}
+	for _, r := range resources {
+		out, err := r.ToYAML()
+		if err != nil {
+			t.Fatalf("ToYAML() error = %v", err)
+		}
+		if strings.Contains(string(out), "status") {
+			t.Errorf("%s.%s kept its status:\n%s", r.Owner().Selector(), r.Selector(), out)
+		}
+	}
+}
+
+func TestBundleRenderTransformError(t *testing.T) {
+	b, err := LoadBundle(writeRenderBundle(t, `
+transforms: pinKind: {
+	in:  _
+	out: in & {kind: "ConfigMap"}
+}
+`), WithLogger(discardLogger()))
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+
+	_, err = b.Render(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "transform pinKind failed on resource app.deployment") {
+		t.Errorf("Render() error = %v, want the failing transform and resource", err)
+	}
+}
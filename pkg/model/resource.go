@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/format"
 	"gopkg.in/yaml.v3"
 )
 
@@ -19,14 +20,18 @@ type Resource struct {
 func (r *Resource) Format(f fmt.State, c rune) {
 	switch c {
 	case 'v':
+		defer lock(r.owner.mu)()
 		f.Write([]byte(
 			fmt.Sprintf("#Resource %v: %v", r.selector, r.value)))
 	}
 }
 
 func (r *Resource) ToYAML() ([]byte, error) {
+	unlock := lock(r.owner.mu)
 	var resourceMap map[string]interface{}
-	if err := r.value.Decode(&resourceMap); err != nil {
+	err := r.value.Decode(&resourceMap)
+	unlock()
+	if err != nil {
 		return nil, err
 	}
 
@@ -44,11 +49,51 @@ func (r *Resource) ToYAML() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// ToCUE formats the resource as CUE source, keeping the doc comments of its
+// fields.
+func (r *Resource) ToCUE() ([]byte, error) {
+	unlock := lock(r.owner.mu)
+	syn := r.value.Syntax(cue.Docs(true), cue.Final())
+	unlock()
+
+	return format.Node(syn)
+}
+
 func (r *Resource) Name() string {
+	defer lock(r.owner.mu)()
 	name, _ := r.value.LookupPath(cue.ParsePath("metadata.name")).String()
 	return name
 }
 
+// Kind returns the resource's kind, or an empty string if it isn't set.
+func (r *Resource) Kind() string {
+	defer lock(r.owner.mu)()
+	kind, _ := r.value.LookupPath(cue.ParsePath("kind")).String()
+	return kind
+}
+
+// Labels returns the resource's metadata.labels, or nil if it has none.
+// Labels whose value isn't a string are left out.
+func (r *Resource) Labels() map[string]string {
+	defer lock(r.owner.mu)()
+	iter, err := r.value.LookupPath(cue.ParsePath("metadata.labels")).Fields()
+	if err != nil {
+		return nil
+	}
+	var labels map[string]string
+	for iter.Next() {
+		value, err := iter.Value().String()
+		if err != nil {
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[iter.Selector().Unquoted()] = value
+	}
+	return labels
+}
+
 func (r *Resource) Owner() *Component {
 	return r.owner
 }
@@ -61,6 +106,18 @@ func (r *Resource) Value() cue.Value {
 	return r.value
 }
 
+// WithAnnotations returns a copy of the resource with annotations added to its
+// metadata.
+func (r *Resource) WithAnnotations(annotations map[string]string) *Resource {
+	defer lock(r.owner.mu)()
+	value := r.value
+	for key, v := range annotations {
+		path := cue.MakePath(cue.Str("metadata"), cue.Str("annotations"), cue.Str(key))
+		value = value.FillPath(path, v)
+	}
+	return newResource(r.owner, r.selector, value)
+}
+
 func newResource(owner *Component, selector cue.Selector, value cue.Value) *Resource {
 	return &Resource{
 		owner:    owner,
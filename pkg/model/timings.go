@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Phases of loading a bundle and discovering its component templates, as
+// recorded in Timings.
+const (
+	// PhaseLoad is loading and evaluating the bundle itself.
+	PhaseLoad = "load"
+	// PhaseValues is loading and applying values files.
+	PhaseValues = "values"
+	// PhaseFetch is fetching a dependency's module source.
+	PhaseFetch = "fetch"
+	// PhaseInstances is loading the package instances of a module.
+	PhaseInstances = "instances"
+	// PhaseBuild is building a package instance.
+	PhaseBuild = "build"
+	// PhaseUnify is unifying a definition with the component bases.
+	PhaseUnify = "unify"
+)
+
+// PhaseTiming is the time spent in one phase.
+type PhaseTiming struct {
+	Phase string
+	// Count is how many times the phase ran, e.g. the number of
+	// dependencies fetched or packages built.
+	Count    int
+	Duration time.Duration
+}
+
+// Timings accumulates the time spent in each phase of loading bundles and
+// discovering templates. Pass it to LoadBundle with WithTimings. It is safe for
+// concurrent use, and a nil *Timings records nothing.
+type Timings struct {
+	mu     sync.Mutex
+	phases []*PhaseTiming
+}
+
+// Record adds a run of phase taking d.
+func (t *Timings) Record(phase string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, p := range t.phases {
+		if p.Phase == phase {
+			p.Count++
+			p.Duration += d
+			return
+		}
+	}
+	t.phases = append(t.phases, &PhaseTiming{Phase: phase, Count: 1, Duration: d})
+}
+
+// Phases returns the recorded phases in the order they first ran.
+func (t *Timings) Phases() []PhaseTiming {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	phases := make([]PhaseTiming, len(t.phases))
+	for i, p := range t.phases {
+		phases[i] = *p
+	}
+	return phases
+}
+
+// Format writes the recorded phases to w as a table.
+func (t *Timings) Format(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "PHASE\tCOUNT\tDURATION")
+	for _, p := range t.Phases() {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", p.Phase, p.Count, p.Duration.Round(time.Millisecond))
+	}
+	return tw.Flush()
+}
+
+// timePhase starts timing a run of phase. The returned function records it in
+// timings, which may be nil, and logs its duration at debug level.
+func timePhase(logger *slog.Logger, timings *Timings, phase string, attrs ...any) (done func()) {
+	start := time.Now()
+	return func() {
+		d := time.Since(start)
+		timings.Record(phase, d)
+		logger.Debug("phase complete", append([]any{"phase", phase, "duration", d}, attrs...)...)
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimingsRecord(t *testing.T) {
+	timings := &Timings{}
+	timings.Record(PhaseBuild, 2*time.Millisecond)
+	timings.Record(PhaseLoad, time.Millisecond)
+	timings.Record(PhaseBuild, 3*time.Millisecond)
+
+	got := timings.Phases()
+	want := []PhaseTiming{
+		{Phase: PhaseBuild, Count: 2, Duration: 5 * time.Millisecond},
+		{Phase: PhaseLoad, Count: 1, Duration: time.Millisecond},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Phases() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Phases()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	var out strings.Builder
+	if err := timings.Format(&out); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	for _, s := range []string{"PHASE", "build", "5ms", "load"} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("Format() output missing %q:\n%s", s, out.String())
+		}
+	}
+
+	var nilTimings *Timings
+	nilTimings.Record(PhaseLoad, time.Second)
+	if phases := nilTimings.Phases(); phases != nil {
+		t.Errorf("nil Timings Phases() = %v, want nil", phases)
+	}
+}
+
+func TestLoadBundleWithTimings(t *testing.T) {
+	dir, opts := setupTemplateBundle(t, webAppBundle)
+	timings := &Timings{}
+	b, err := LoadBundle(dir, append(opts, WithTimings(timings))...)
+	if err != nil {
+		t.Fatalf("LoadBundle() error = %v", err)
+	}
+	for _, err := range b.ComponentTemplates(context.Background()) {
+		if err != nil {
+			t.Fatalf("ComponentTemplates() error = %v", err)
+		}
+	}
+
+	counts := map[string]int{}
+	for _, p := range timings.Phases() {
+		counts[p.Phase] = p.Count
+	}
+	for _, phase := range []string{PhaseLoad, PhaseInstances, PhaseBuild, PhaseUnify} {
+		if counts[phase] == 0 {
+			t.Errorf("phase %q not recorded (got %v)", phase, counts)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	cueerrors "cuelang.org/go/cue/errors"
+)
+
+// ValueError is a problem with a single field of a bundle's values.
+type ValueError struct {
+	// Path is the field's path within values, e.g. "components.app.replicas".
+	Path string `json:"path"`
+	// Message describes the problem, e.g. "invalid value 0 (out of bound >=1)".
+	Message string `json:"message"`
+	// Position is the file, line and column the problem was found at, if
+	// known.
+	Position string `json:"position,omitempty"`
+}
+
+func (e ValueError) String() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidateValues checks the bundle's values, including any loaded with
+// WithValues, against the values schema and the config schema of each
+// component, without evaluating any resources. Values that are missing or
+// not concrete are reported along with conflicts. The errors are sorted by
+// path.
+func (b *Bundle) ValidateValues() []ValueError {
+	defer lock(b.mu)()
+
+	var errs []ValueError
+	add := func(err error) {
+		for _, valueErr := range valueErrors(err, valuesPath) {
+			if !slices.Contains(errs, valueErr) {
+				errs = append(errs, valueErr)
+			}
+		}
+	}
+
+	validate := []cue.Option{cue.Concrete(true), cue.Final()}
+	if values := b.value.LookupPath(cue.ParsePath("values")); values.Exists() {
+		add(values.Validate(validate...))
+	}
+	if iter, err := b.value.LookupPath(cue.ParsePath("components")).Fields(); err == nil {
+		for iter.Next() {
+			config := iter.Value().LookupPath(cue.ParsePath("config"))
+			if config.Exists() {
+				add(config.Validate(validate...))
+			}
+		}
+	}
+
+	slices.SortStableFunc(errs, func(a, b ValueError) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return errs
+}
+
+// valueErrors converts the errors in err to ValueErrors, with paths given
+// by toPath.
+func valueErrors(err error, toPath func(path []string) string) []ValueError {
+	var errs []ValueError
+	for _, e := range cueerrors.Errors(err) {
+		format, args := e.Msg()
+		// An empty disjunction is reported as a header followed by an
+		// error per disjunct; the header adds nothing on its own.
+		if strings.HasSuffix(format, ":") {
+			continue
+		}
+		valueErr := ValueError{
+			Path:    toPath(e.Path()),
+			Message: fmt.Sprintf(format, args...),
+		}
+		if pos := e.Position(); pos.IsValid() {
+			valueErr.Position = pos.String()
+		} else if pos := e.InputPositions(); len(pos) > 0 {
+			valueErr.Position = pos[0].String()
+		}
+		errs = append(errs, valueErr)
+	}
+	return errs
+}
+
+// valuesPath converts the path of an error within the bundle to the path of
+// the value that causes it: "values.x" becomes "x", and the config of a
+// component, "components.<name>.config.x", becomes "components.<name>.x",
+// where its values are set.
+func valuesPath(path []string) string {
+	switch {
+	case len(path) > 0 && path[0] == "values":
+		path = path[1:]
+	case len(path) >= 3 && path[0] == "components" && path[2] == "config":
+		path = append([]string{path[0], path[1]}, path[3:]...)
+	}
+	return strings.Join(path, ".")
+}
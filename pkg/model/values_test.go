@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateValues(t *testing.T) {
+	dir := writePlainBundle(t)
+	writeFiles(t, dir, map[string]string{
+		"values.cue": "package bundle\n\nvalues: level: int & >=1\n",
+	})
+	valuesDir := t.TempDir()
+	writeFiles(t, valuesDir, map[string]string{
+		"valid.yaml":   "level: 2\ncomponents:\n  app:\n    image: nginx\n",
+		"invalid.yaml": "level: 0\ncomponents:\n  app:\n    replicas: three\n",
+		"missing.yaml": "level: 1\n",
+	})
+
+	tests := []struct {
+		name   string
+		values string
+		want   []string
+	}{
+		{
+			name:   "valid",
+			values: "valid.yaml",
+		},
+		{
+			name:   "conflicts",
+			values: "invalid.yaml",
+			want: []string{
+				`components.app.replicas: conflicting values "three" and 1 (mismatched types string and int)`,
+				`components.app.replicas: conflicting values "three" and int (mismatched types string and int)`,
+				"level: invalid value 0 (out of bound >=1)",
+			},
+		},
+		{
+			name:   "missing",
+			values: "missing.yaml",
+			want:   []string{"components.app.image: incomplete value string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := LoadBundle(dir, WithValues(filepath.Join(valuesDir, tt.values)))
+			if err != nil {
+				t.Fatalf("LoadBundle() error = %v", err)
+			}
+			var got []string
+			for _, e := range b.ValidateValues() {
+				got = append(got, e.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("ValidateValues() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
@@ -4,15 +4,21 @@ package oci
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
@@ -23,7 +29,32 @@ import (
 type Reference struct {
 	Registry   string
 	Repository string
-	Reference  string // tag or digest
+	Reference  string // tag or digest to resolve; the digest when both are given
+	Tag        string // tag, if the reference has one
+	Digest     string // digest, if the reference has one
+}
+
+var (
+	// registryPattern matches a host name or bracketed IPv6 address with an
+	// optional port.
+	registryPattern = regexp.MustCompile(`^(\[[0-9a-fA-F:]+\]|[a-zA-Z0-9]([a-zA-Z0-9.-]*[a-zA-Z0-9])?)(:[0-9]+)?$`)
+	tagPattern      = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+	digestPattern   = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+	sha256Pattern   = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+)
+
+// ParseReferenceWithDefault parses an OCI reference like ParseReference, but
+// a reference without a registry, such as "mybundle:v1", is taken to be in
+// defaultRegistry, which may include a repository prefix (e.g.
+// "ghcr.io/org"). With an empty defaultRegistry it's the same as
+// ParseReference.
+func ParseReferenceWithDefault(raw, defaultRegistry string) (*Reference, error) {
+	raw = strings.TrimPrefix(raw, "oci://")
+	defaultRegistry = strings.TrimSuffix(strings.TrimPrefix(defaultRegistry, "oci://"), "/")
+	if defaultRegistry != "" && raw != "" && !strings.Contains(raw, "/") {
+		raw = defaultRegistry + "/" + raw
+	}
+	return ParseReference(raw)
 }
 
 // ParseReference parses an OCI reference string, optionally stripping the oci:// scheme
@@ -40,31 +71,81 @@ func ParseReference(raw string) (*Reference, error) {
 	registry := parts[0]
 	repoAndRef := parts[1]
 
-	// Split repository and reference (tag or digest)
-	var repository, reference string
+	if registry == "" {
+		return nil, fmt.Errorf("invalid reference %q: registry is empty", raw)
+	}
+	if !registryPattern.MatchString(registry) {
+		return nil, fmt.Errorf("invalid reference %q: registry %q is not a valid host", raw, registry)
+	}
+
+	// Split repository, tag and digest. A reference may carry both, as in
+	// repo:tag@digest, in which case the digest is authoritative.
+	var repository, tag, digest string
 	if idx := strings.LastIndex(repoAndRef, "@"); idx != -1 {
-		// Digest reference
-		repository = repoAndRef[:idx]
-		reference = repoAndRef[idx+1:]
-	} else if idx := strings.LastIndex(repoAndRef, ":"); idx != -1 {
-		// Tag reference
+		digest = repoAndRef[idx+1:]
+		repoAndRef = repoAndRef[:idx]
+		if err := validateDigest(digest); err != nil {
+			return nil, fmt.Errorf("invalid reference %q: %w", raw, err)
+		}
+	}
+	repository = repoAndRef
+	if idx := strings.LastIndex(repoAndRef, ":"); idx != -1 {
 		repository = repoAndRef[:idx]
-		reference = repoAndRef[idx+1:]
-	} else {
-		// No reference, default to latest
-		repository = repoAndRef
-		reference = "latest"
+		tag = repoAndRef[idx+1:]
+		if tag == "" {
+			return nil, fmt.Errorf("invalid reference %q: tag is empty", raw)
+		}
+		if !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("invalid reference %q: tag %q is not valid", raw, tag)
+		}
+	}
+
+	reference := digest
+	if reference == "" {
+		reference = tag
+	}
+	if reference == "" {
+		// No tag or digest, default to latest
+		tag = "latest"
+		reference = tag
+	}
+
+	if repository == "" {
+		return nil, fmt.Errorf("invalid reference %q: repository is empty", raw)
+	}
+	if slices.Contains(strings.Split(repository, "/"), "") {
+		return nil, fmt.Errorf("invalid reference %q: repository %q has an empty path segment", raw, repository)
 	}
 
 	return &Reference{
 		Registry:   registry,
 		Repository: repository,
 		Reference:  reference,
+		Tag:        tag,
+		Digest:     digest,
 	}, nil
 }
 
+// validateDigest checks that digest has the form algorithm:encoded, with a
+// full-length hex encoding for sha256.
+func validateDigest(digest string) error {
+	if digest == "" {
+		return fmt.Errorf("digest is empty")
+	}
+	if !digestPattern.MatchString(digest) {
+		return fmt.Errorf("digest %q is not of the form algorithm:hex", digest)
+	}
+	if strings.HasPrefix(digest, "sha256:") && !sha256Pattern.MatchString(digest) {
+		return fmt.Errorf("digest %q is not a valid sha256 digest (expected 64 lowercase hex characters)", digest)
+	}
+	return nil
+}
+
 // String returns the full reference string
 func (r *Reference) String() string {
+	if r.Tag != "" && r.Digest != "" {
+		return fmt.Sprintf("%s/%s:%s@%s", r.Registry, r.Repository, r.Tag, r.Digest)
+	}
 	sep := ":"
 	if strings.HasPrefix(r.Reference, "sha256:") {
 		sep = "@"
@@ -72,6 +153,36 @@ func (r *Reference) String() string {
 	return fmt.Sprintf("%s/%s%s%s", r.Registry, r.Repository, sep, r.Reference)
 }
 
+// Pinned returns the reference to the content with the given digest in the
+// same repository, e.g. ghcr.io/org/app@sha256:..., which unlike a tag
+// always refers to the same content.
+func (r *Reference) Pinned(digest string) *Reference {
+	return &Reference{
+		Registry:   r.Registry,
+		Repository: r.Repository,
+		Reference:  digest,
+		Digest:     digest,
+	}
+}
+
+// Tagged returns the reference to ref's tag without any digest, or nil if
+// it has no tag.
+func (r *Reference) Tagged() *Reference {
+	tag := r.Tag
+	if tag == "" && !strings.Contains(r.Reference, ":") {
+		tag = r.Reference
+	}
+	if tag == "" {
+		return nil
+	}
+	return &Reference{
+		Registry:   r.Registry,
+		Repository: r.Repository,
+		Reference:  tag,
+		Tag:        tag,
+	}
+}
+
 // LastComponent returns the last path segment of the repository
 func (r *Reference) LastComponent() string {
 	parts := strings.Split(r.Repository, "/")
@@ -121,14 +232,123 @@ func newCredentialStore() (*auth.Client, error) {
 	}, nil
 }
 
-// Push pushes a bundle to an OCI registry
-func Push(ctx context.Context, ref *Reference, bundlePath string, annotations map[string]string, logger *slog.Logger) error {
+// Artifact types set on manifests pushed by odin.
+const (
+	// BundleArtifactType marks an artifact containing bundle source.
+	BundleArtifactType = "application/vnd.odin.bundle.v1"
+	// ManifestsArtifactType marks an artifact containing rendered manifests.
+	ManifestsArtifactType = "application/vnd.odin.manifests.v1"
+)
+
+// newRepository creates a remote repository for ref, using plain HTTP for
+// localhost registries and the user's registry credentials.
+func newRepository(ref *Reference) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(fmt.Sprintf("%s/%s", ref.Registry, ref.Repository))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	// Use plain HTTP for localhost
+	if strings.HasPrefix(ref.Registry, "localhost") {
+		repo.PlainHTTP = true
+	}
+
+	// Set up auth
+	authClient, err := newCredentialStore()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth client: %w", err)
+	}
+	repo.Client = authClient
+
+	return repo, nil
+}
+
+// DefaultConcurrency is the number of blobs copied to or from a registry at
+// once unless WithConcurrency says otherwise.
+const DefaultConcurrency = 3
+
+// copyOptions holds options for Push, PushManifests and Pull.
+type copyOptions struct {
+	mirrors     map[string]string
+	concurrency int
+}
+
+// CopyOption is a functional option for Push, PushManifests and Pull.
+type CopyOption func(*copyOptions)
+
+func newCopyOptions(opts []CopyOption) (*copyOptions, error) {
+	o := &copyOptions{concurrency: DefaultConcurrency}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1, got %d", o.concurrency)
+	}
+	return o, nil
+}
+
+// WithMirrors pulls from mirrors instead of the registries they stand in
+// for; see MirrorReference. Pushes always go to the registry named in the
+// reference, so Push and PushManifests return an error if any are given.
+func WithMirrors(mirrors map[string]string) CopyOption {
+	return func(o *copyOptions) {
+		o.mirrors = mirrors
+	}
+}
+
+// WithConcurrency sets how many blobs are copied at once, which must be at
+// least 1. More speeds up bundles with many layers over high-latency links
+// at the cost of more connections to the registry and more memory.
+func WithConcurrency(n int) CopyOption {
+	return func(o *copyOptions) {
+		o.concurrency = n
+	}
+}
+
+// Push pushes a bundle to an OCI registry, returning the descriptor of the
+// manifest pushed, whose digest pins the bundle; see Reference.Pinned.
+func Push(ctx context.Context, ref *Reference, bundlePath string, annotations map[string]string, logger *slog.Logger, opts ...CopyOption) (ocispec.Descriptor, error) {
 	logger.Info("pushing bundle", "reference", ref.String(), "path", bundlePath)
 
-	// Create file store from bundle directory
-	fileStore, err := file.New(bundlePath)
+	desc, err := pushDirectory(ctx, ref, bundlePath, BundleArtifactType, annotations, logger, opts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	logger.Info("bundle pushed successfully", "digest", desc.Digest.String())
+	return desc, nil
+}
+
+// PushManifests pushes a directory of rendered manifests to an OCI registry
+// with ManifestsArtifactType, so it can't be mistaken for bundle source. It
+// returns the descriptor of the manifest pushed, like Push.
+func PushManifests(ctx context.Context, ref *Reference, manifestsPath string, annotations map[string]string, logger *slog.Logger, opts ...CopyOption) (ocispec.Descriptor, error) {
+	logger.Info("pushing rendered manifests", "reference", ref.String(), "path", manifestsPath)
+
+	desc, err := pushDirectory(ctx, ref, manifestsPath, ManifestsArtifactType, annotations, logger, opts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	logger.Info("manifests pushed successfully", "digest", desc.Digest.String())
+	return desc, nil
+}
+
+// pushDirectory packs dir as a single tar layer in a manifest with the given
+// artifact type and pushes it to ref.
+func pushDirectory(ctx context.Context, ref *Reference, dir string, artifactType string, annotations map[string]string, logger *slog.Logger, opts []CopyOption) (ocispec.Descriptor, error) {
+	o, err := newCopyOptions(opts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if len(o.mirrors) > 0 {
+		return ocispec.Descriptor{}, fmt.Errorf("mirrors only apply to pulls, not pushes")
+	}
+
+	// Create file store from the directory
+	fileStore, err := file.New(dir)
 	if err != nil {
-		return fmt.Errorf("failed to create file store: %w", err)
+		return ocispec.Descriptor{}, fmt.Errorf("failed to create file store: %w", err)
 	}
 	defer func() {
 		if cerr := fileStore.Close(); cerr != nil {
@@ -140,82 +360,123 @@ func Push(ctx context.Context, ref *Reference, bundlePath string, annotations ma
 	// For now, we'll skip this and users should clean their bundle before pushing
 
 	// Add the directory - this creates a tar layer with proper annotations
-	layerDesc, err := fileStore.Add(ctx, ".", "", bundlePath)
+	layerDesc, err := fileStore.Add(ctx, ".", "", dir)
 	if err != nil {
-		return fmt.Errorf("failed to add bundle directory: %w", err)
+		return ocispec.Descriptor{}, fmt.Errorf("failed to add directory: %w", err)
 	}
 
-	// Pack into a manifest with the layer
-	packOpts := oras.PackManifestOptions{
-		Layers: []ocispec.Descriptor{layerDesc},
-	}
-	if len(annotations) > 0 {
-		packOpts.ManifestAnnotations = annotations
-	}
-	manifestDesc, err := oras.PackManifest(ctx, fileStore, oras.PackManifestVersion1_1, "application/vnd.odin.bundle.v1", packOpts)
-	if err != nil {
-		return fmt.Errorf("failed to pack manifest: %w", err)
+	// The digest of a tag@digest reference only identifies content to pull,
+	// so pushes go to the tag.
+	tag := ref.Reference
+	if ref.Tag != "" {
+		tag = ref.Tag
 	}
 
-	// Tag the manifest
-	if err := fileStore.Tag(ctx, manifestDesc, ref.Reference); err != nil {
-		return fmt.Errorf("failed to tag manifest: %w", err)
+	// Pack into a manifest with the layer. The tag is recorded as the ref
+	// name unless the caller set one.
+	manifestAnnotations := maps.Clone(annotations)
+	if manifestAnnotations == nil {
+		manifestAnnotations = map[string]string{}
 	}
-
-	// Set up remote repository
-	repo, err := remote.NewRepository(fmt.Sprintf("%s/%s", ref.Registry, ref.Repository))
+	if _, ok := manifestAnnotations[ocispec.AnnotationRefName]; !ok {
+		manifestAnnotations[ocispec.AnnotationRefName] = tag
+	}
+	manifestDesc, err := oras.PackManifest(ctx, fileStore, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{
+		Layers:              []ocispec.Descriptor{layerDesc},
+		ManifestAnnotations: manifestAnnotations,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create repository: %w", err)
+		return ocispec.Descriptor{}, fmt.Errorf("failed to pack manifest: %w", err)
 	}
 
-	// Use plain HTTP for localhost
-	if strings.HasPrefix(ref.Registry, "localhost") {
-		repo.PlainHTTP = true
+	// Tag the manifest.
+	if err := fileStore.Tag(ctx, manifestDesc, tag); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to tag manifest: %w", err)
 	}
 
-	// Set up auth
-	authClient, err := newCredentialStore()
+	repo, err := newRepository(ref)
 	if err != nil {
-		return fmt.Errorf("failed to create auth client: %w", err)
+		return ocispec.Descriptor{}, err
 	}
-	repo.Client = authClient
 
 	// Copy from file store to remote
-	desc, err := oras.Copy(ctx, fileStore, ref.Reference, repo, ref.Reference, oras.CopyOptions{})
+	copyOpts := oras.CopyOptions{}
+	copyOpts.Concurrency = o.concurrency
+	desc, err := oras.Copy(ctx, fileStore, tag, repo, tag, copyOpts)
 	if err != nil {
-		return fmt.Errorf("failed to push to registry: %w", err)
+		return ocispec.Descriptor{}, fmt.Errorf("failed to push to registry: %w", err)
 	}
 
-	logger.Info("bundle pushed successfully", "digest", desc.Digest.String())
-	return nil
+	return desc, nil
 }
 
-// Pull pulls a bundle from an OCI registry
-func Pull(ctx context.Context, ref *Reference, outputDir string, logger *slog.Logger) error {
+// MirrorReference returns the reference ref is pulled from given mirrors,
+// which map a registry host to the mirror serving it, optionally with a
+// repository prefix: with "ghcr.io" mapped to "mirror.internal/ghcr.io",
+// ghcr.io/org/app:v1 is pulled from mirror.internal/ghcr.io/org/app:v1. The
+// tag and digest are kept, so the content pulled is verified against the
+// digest asked for. A ref whose registry has no mirror is returned as is.
+func MirrorReference(ref *Reference, mirrors map[string]string) (*Reference, error) {
+	mirror, ok := mirrors[ref.Registry]
+	if !ok {
+		return ref, nil
+	}
+	mirror = strings.TrimSuffix(strings.TrimPrefix(mirror, "oci://"), "/")
+	registry, prefix, _ := strings.Cut(mirror, "/")
+	if registry == "" || !registryPattern.MatchString(registry) {
+		return nil, fmt.Errorf("invalid mirror %q for %s: %q is not a valid host", mirror, ref.Registry, registry)
+	}
+	mirrored := *ref
+	mirrored.Registry = registry
+	mirrored.Repository = path.Join(prefix, ref.Repository)
+	return &mirrored, nil
+}
+
+// Pull pulls a bundle from an OCI registry, returning the descriptor of the
+// manifest pulled, whose digest pins the bundle; see Reference.Pinned. If the
+// artifact at ref isn't an odin bundle a warning is logged, or with strict an
+// error is returned before anything is written to outputDir.
+func Pull(ctx context.Context, ref *Reference, outputDir string, strict bool, logger *slog.Logger, opts ...CopyOption) (ocispec.Descriptor, error) {
+	o, err := newCopyOptions(opts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
 	logger.Info("pulling bundle", "reference", ref.String(), "output", outputDir)
 
-	// Set up remote repository
-	repo, err := remote.NewRepository(fmt.Sprintf("%s/%s", ref.Registry, ref.Repository))
+	source, err := MirrorReference(ref, o.mirrors)
 	if err != nil {
-		return fmt.Errorf("failed to create repository: %w", err)
+		return ocispec.Descriptor{}, err
+	}
+	if source != ref {
+		logger.Info("pulling through mirror", "mirror", source.String())
 	}
 
-	// Use plain HTTP for localhost
-	if strings.HasPrefix(ref.Registry, "localhost") {
-		repo.PlainHTTP = true
+	repo, err := newRepository(source)
+	if err != nil {
+		return ocispec.Descriptor{}, err
 	}
 
-	// Set up auth
-	authClient, err := newCredentialStore()
+	// Check the artifact type before copying anything
+	manifestDesc, err := repo.Resolve(ctx, ref.Reference)
 	if err != nil {
-		return fmt.Errorf("failed to create auth client: %w", err)
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve reference: %w", err)
+	}
+	manifest, err := content.FetchAll(ctx, repo, manifestDesc)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	if artifactType := manifestArtifactType(manifest); artifactType != BundleArtifactType {
+		if strict {
+			return ocispec.Descriptor{}, fmt.Errorf("%s is not an odin bundle: artifact type is %q, want %q", ref, artifactType, BundleArtifactType)
+		}
+		logger.Warn("artifact is not an odin bundle", "reference", ref.String(), "artifactType", artifactType, "want", BundleArtifactType)
 	}
-	repo.Client = authClient
 
 	// Create file store for output directory
 	fileStore, err := file.New(outputDir)
 	if err != nil {
-		return fmt.Errorf("failed to create file store: %w", err)
+		return ocispec.Descriptor{}, fmt.Errorf("failed to create file store: %w", err)
 	}
 	defer func() {
 		if cerr := fileStore.Close(); cerr != nil {
@@ -223,12 +484,112 @@ func Pull(ctx context.Context, ref *Reference, outputDir string, logger *slog.Lo
 		}
 	}()
 
-	// Copy from remote to file store - this automatically unpacks
-	_, err = oras.Copy(ctx, repo, ref.Reference, fileStore, ref.Reference, oras.CopyOptions{})
+	// Copy from remote to file store - this automatically unpacks. The
+	// manifest is copied by the digest checked above rather than by ref, so
+	// a tag that moves in the meantime can't swap in another artifact.
+	copyOpts := oras.CopyOptions{}
+	copyOpts.Concurrency = o.concurrency
+	_, err = oras.Copy(ctx, repo, manifestDesc.Digest.String(), fileStore, ref.Reference, copyOpts)
 	if err != nil {
-		return fmt.Errorf("failed to pull from registry: %w", err)
+		return ocispec.Descriptor{}, fmt.Errorf("failed to pull from registry: %w", err)
 	}
 
-	logger.Info("bundle pulled successfully")
-	return nil
+	logger.Info("bundle pulled successfully", "digest", manifestDesc.Digest.String())
+	return manifestDesc, nil
+}
+
+// AttachArtifact pushes the file or directory at artifactPath as an artifact
+// referring to the manifest at subjectRef, such as generated docs or an SBOM
+// for a bundle. Registries without the OCI 1.1 referrers API are handled by
+// falling back to the referrers tag schema.
+func AttachArtifact(ctx context.Context, subjectRef *Reference, artifactPath string, artifactType string, logger *slog.Logger) (ocispec.Descriptor, error) {
+	logger.Info("attaching artifact", "subject", subjectRef.String(), "path", artifactPath, "artifactType", artifactType)
+
+	repo, err := newRepository(subjectRef)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	subject, err := repo.Resolve(ctx, subjectRef.Reference)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve subject: %w", err)
+	}
+
+	fileStore, err := file.New(filepath.Dir(artifactPath))
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to create file store: %w", err)
+	}
+	defer func() {
+		if cerr := fileStore.Close(); cerr != nil {
+			logger.Debug("failed to close file store", "error", cerr)
+		}
+	}()
+
+	layerDesc, err := fileStore.Add(ctx, filepath.Base(artifactPath), "", artifactPath)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to add artifact: %w", err)
+	}
+
+	manifestDesc, err := oras.PackManifest(ctx, fileStore, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{
+		Layers:  []ocispec.Descriptor{layerDesc},
+		Subject: &subject,
+	})
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to pack manifest: %w", err)
+	}
+
+	// The artifact is found through its subject, so push it by digest only.
+	if err := oras.CopyGraph(ctx, fileStore, repo, manifestDesc, oras.DefaultCopyGraphOptions); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to push artifact: %w", err)
+	}
+
+	logger.Info("artifact attached successfully", "digest", manifestDesc.Digest.String())
+	return manifestDesc, nil
+}
+
+// ListReferrers returns the descriptors of all artifacts referring to the
+// manifest at ref. Registries without the OCI 1.1 referrers API are handled by
+// falling back to the referrers tag schema.
+func ListReferrers(ctx context.Context, ref *Reference) ([]ocispec.Descriptor, error) {
+	repo, err := newRepository(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := repo.Resolve(ctx, ref.Reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve reference: %w", err)
+	}
+
+	var referrers []ocispec.Descriptor
+	if err := repo.Referrers(ctx, subject, "", func(page []ocispec.Descriptor) error {
+		referrers = append(referrers, page...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list referrers: %w", err)
+	}
+
+	return referrers, nil
+}
+
+// manifestArtifactType returns the artifact type of a manifest: its
+// artifactType field, or the config media type for manifests predating it, or
+// the manifest's own media type (e.g. for an image index).
+func manifestArtifactType(data []byte) string {
+	var manifest struct {
+		MediaType    string             `json:"mediaType"`
+		ArtifactType string             `json:"artifactType"`
+		Config       ocispec.Descriptor `json:"config"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return ""
+	}
+	switch {
+	case manifest.ArtifactType != "":
+		return manifest.ArtifactType
+	case manifest.Config.MediaType != "" && manifest.Config.MediaType != ocispec.MediaTypeEmptyJSON:
+		return manifest.Config.MediaType
+	default:
+		return manifest.MediaType
+	}
 }
@@ -3,9 +3,28 @@
 package oci
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+
+	"cuelabs.dev/go/oci/ociregistry/ocimem"
+	"cuelabs.dev/go/oci/ociregistry/ociserver"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
 )
 
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
 func TestParseReference(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -13,6 +32,8 @@ func TestParseReference(t *testing.T) {
 		wantReg    string
 		wantRepo   string
 		wantRef    string
+		wantTag    string
+		wantDigest string
 		wantErr    bool
 		wantString string
 	}{
@@ -22,6 +43,7 @@ func TestParseReference(t *testing.T) {
 			wantReg:    "ghcr.io",
 			wantRepo:   "org/app",
 			wantRef:    "v1",
+			wantTag:    "v1",
 			wantString: "ghcr.io/org/app:v1",
 		},
 		{
@@ -30,6 +52,7 @@ func TestParseReference(t *testing.T) {
 			wantReg:    "ghcr.io",
 			wantRepo:   "org/app",
 			wantRef:    "v1",
+			wantTag:    "v1",
 			wantString: "ghcr.io/org/app:v1",
 		},
 		{
@@ -38,15 +61,46 @@ func TestParseReference(t *testing.T) {
 			wantReg:    "ghcr.io",
 			wantRepo:   "org/app",
 			wantRef:    "latest",
+			wantTag:    "latest",
 			wantString: "ghcr.io/org/app:latest",
 		},
 		{
 			name:       "digest reference",
-			input:      "ghcr.io/org/app@sha256:abcdef",
+			input:      "ghcr.io/org/app@" + testDigest,
 			wantReg:    "ghcr.io",
 			wantRepo:   "org/app",
-			wantRef:    "sha256:abcdef",
-			wantString: "ghcr.io/org/app@sha256:abcdef",
+			wantRef:    testDigest,
+			wantDigest: testDigest,
+			wantString: "ghcr.io/org/app@" + testDigest,
+		},
+		{
+			name:       "tag and digest",
+			input:      "ghcr.io/org/app:v1@" + testDigest,
+			wantReg:    "ghcr.io",
+			wantRepo:   "org/app",
+			wantRef:    testDigest,
+			wantTag:    "v1",
+			wantDigest: testDigest,
+			wantString: "ghcr.io/org/app:v1@" + testDigest,
+		},
+		{
+			name:       "tag and digest with registry port",
+			input:      "oci://localhost:5000/org/project/app:v1@" + testDigest,
+			wantReg:    "localhost:5000",
+			wantRepo:   "org/project/app",
+			wantRef:    testDigest,
+			wantTag:    "v1",
+			wantDigest: testDigest,
+			wantString: "localhost:5000/org/project/app:v1@" + testDigest,
+		},
+		{
+			name:       "registry with port",
+			input:      "localhost:5000/org/app:v1",
+			wantReg:    "localhost:5000",
+			wantRepo:   "org/app",
+			wantRef:    "v1",
+			wantTag:    "v1",
+			wantString: "localhost:5000/org/app:v1",
 		},
 		{
 			name:       "nested repository",
@@ -54,6 +108,7 @@ func TestParseReference(t *testing.T) {
 			wantReg:    "registry.example.com",
 			wantRepo:   "org/project/bundle",
 			wantRef:    "tag",
+			wantTag:    "tag",
 			wantString: "registry.example.com/org/project/bundle:tag",
 		},
 		{
@@ -61,6 +116,56 @@ func TestParseReference(t *testing.T) {
 			input:   "ghcr.io",
 			wantErr: true,
 		},
+		{
+			name:    "invalid - empty registry",
+			input:   "/org/app:v1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - registry is not a host",
+			input:   "ghcr io/org/app:v1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - empty repository segment",
+			input:   "ghcr.io//app",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - trailing slash in repository",
+			input:   "ghcr.io/org/:v1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - empty tag",
+			input:   "ghcr.io/app:",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - empty digest",
+			input:   "ghcr.io/org/app@",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - short sha256 digest",
+			input:   "ghcr.io/org/app@sha256:abcdef",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - digest without algorithm",
+			input:   "ghcr.io/org/app@abcdef",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - empty tag before digest",
+			input:   "ghcr.io/org/app:@" + testDigest,
+			wantErr: true,
+		},
+		{
+			name:    "invalid - empty tag and registry",
+			input:   ":tag",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -83,6 +188,12 @@ func TestParseReference(t *testing.T) {
 			if ref.Reference != tt.wantRef {
 				t.Errorf("Reference = %v, want %v", ref.Reference, tt.wantRef)
 			}
+			if ref.Tag != tt.wantTag {
+				t.Errorf("Tag = %v, want %v", ref.Tag, tt.wantTag)
+			}
+			if ref.Digest != tt.wantDigest {
+				t.Errorf("Digest = %v, want %v", ref.Digest, tt.wantDigest)
+			}
 			if ref.String() != tt.wantString {
 				t.Errorf("String() = %v, want %v", ref.String(), tt.wantString)
 			}
@@ -90,6 +201,33 @@ func TestParseReference(t *testing.T) {
 	}
 }
 
+func TestParseReferenceWithDefault(t *testing.T) {
+	tests := []struct {
+		input           string
+		defaultRegistry string
+		want            string
+		wantErr         bool
+	}{
+		{input: "mybundle:v1", defaultRegistry: "registry.local", want: "registry.local/mybundle:v1"},
+		{input: "mybundle", defaultRegistry: "ghcr.io/org/", want: "ghcr.io/org/mybundle:latest"},
+		{input: "oci://mybundle@" + testDigest, defaultRegistry: "oci://localhost:5000", want: "localhost:5000/mybundle@" + testDigest},
+		{input: "ghcr.io/org/app:v1", defaultRegistry: "registry.local", want: "ghcr.io/org/app:v1"},
+		{input: "mybundle:v1", wantErr: true},
+		{input: "", defaultRegistry: "registry.local", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		ref, err := ParseReferenceWithDefault(tt.input, tt.defaultRegistry)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseReferenceWithDefault(%q, %q) error = %v, wantErr %v", tt.input, tt.defaultRegistry, err, tt.wantErr)
+			continue
+		}
+		if err == nil && ref.String() != tt.want {
+			t.Errorf("ParseReferenceWithDefault(%q, %q) = %s, want %s", tt.input, tt.defaultRegistry, ref, tt.want)
+		}
+	}
+}
+
 func TestReferenceLastComponent(t *testing.T) {
 	tests := []struct {
 		name string
@@ -121,3 +259,439 @@ func TestReferenceLastComponent(t *testing.T) {
 		})
 	}
 }
+
+func TestManifestArtifactType(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     string
+	}{
+		{
+			name:     "artifact type",
+			manifest: `{"mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/vnd.odin.bundle.v1","config":{"mediaType":"application/vnd.oci.empty.v1+json"}}`,
+			want:     BundleArtifactType,
+		},
+		{
+			name:     "config media type",
+			manifest: `{"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json"}}`,
+			want:     "application/vnd.oci.image.config.v1+json",
+		},
+		{
+			name:     "image index",
+			manifest: `{"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`,
+			want:     "application/vnd.oci.image.index.v1+json",
+		},
+		{
+			name:     "invalid json",
+			manifest: `not json`,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := manifestArtifactType([]byte(tt.manifest)); got != tt.want {
+				t.Errorf("manifestArtifactType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// startRegistry serves an in-memory OCI registry and returns its host,
+// addressed as localhost so it's used over plain HTTP.
+func startRegistry(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(ociserver.New(ocimem.New(), nil))
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	return net.JoinHostPort("localhost", serverURL.Port())
+}
+
+func TestPullChecksArtifactType(t *testing.T) {
+	host := startRegistry(t)
+
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "bundle.cue"), []byte("package bundle\n"), 0644); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	bundleRef := &Reference{Registry: host, Repository: "org/bundle", Reference: "v1"}
+	if _, err := Push(ctx, bundleRef, src, nil, logger); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	manifestsRef := &Reference{Registry: host, Repository: "org/manifests", Reference: "v1"}
+	if _, err := PushManifests(ctx, manifestsRef, src, nil, logger); err != nil {
+		t.Fatalf("PushManifests() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		ref     *Reference
+		strict  bool
+		wantErr bool
+	}{
+		{name: "bundle", ref: bundleRef, strict: true},
+		{name: "non-bundle warns by default", ref: manifestsRef},
+		{name: "non-bundle fails when strict", ref: manifestsRef, strict: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := t.TempDir()
+			_, err := Pull(ctx, tt.ref, out, tt.strict, logger)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Pull() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			_, statErr := os.Stat(filepath.Join(out, "bundle.cue"))
+			if tt.wantErr && statErr == nil {
+				t.Error("Pull() wrote files despite rejecting the artifact")
+			}
+			if !tt.wantErr && statErr != nil {
+				t.Errorf("Pull() did not extract bundle.cue: %v", statErr)
+			}
+		})
+	}
+}
+
+func TestPullCopiesCheckedManifest(t *testing.T) {
+	reg := ocimem.New()
+	var moved atomic.Bool
+	var moveErr error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ociserver.New(reg, nil).ServeHTTP(w, r)
+		// Move the tag to another bundle once Pull has resolved it, before
+		// the response is sent.
+		if strings.HasSuffix(r.URL.Path, "/manifests/v1") && r.Method != http.MethodPut && !moved.Swap(true) {
+			ctx := context.Background()
+			other, err := reg.GetTag(ctx, "org/bundle", "other")
+			if err != nil {
+				moveErr = err
+				return
+			}
+			defer other.Close()
+			data, err := io.ReadAll(other)
+			if err != nil {
+				moveErr = err
+				return
+			}
+			_, moveErr = reg.PushManifest(ctx, "org/bundle", "v1", data, other.Descriptor().MediaType)
+		}
+	}))
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse server URL: %v", err)
+	}
+	host := net.JoinHostPort("localhost", serverURL.Port())
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	for tag, pkg := range map[string]string{"v1": "checked", "other": "other"} {
+		src := t.TempDir()
+		if err := os.WriteFile(filepath.Join(src, "bundle.cue"), []byte("package "+pkg+"\n"), 0644); err != nil {
+			t.Fatalf("failed to write bundle: %v", err)
+		}
+		// Pushing v1 resolves it, so the tag is only moved by the pull.
+		moved.Store(true)
+		if _, err := Push(ctx, &Reference{Registry: host, Repository: "org/bundle", Reference: tag}, src, nil, logger); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	moved.Store(false)
+
+	out := t.TempDir()
+	if _, err := Pull(ctx, &Reference{Registry: host, Repository: "org/bundle", Reference: "v1"}, out, true, logger); err != nil {
+		t.Fatalf("Pull() error = %v", err)
+	}
+	if !moved.Load() || moveErr != nil {
+		t.Fatalf("tag not moved during Pull(): %v", moveErr)
+	}
+	data, err := os.ReadFile(filepath.Join(out, "bundle.cue"))
+	if err != nil {
+		t.Fatalf("Pull() did not extract bundle.cue: %v", err)
+	}
+	if got := string(data); got != "package checked\n" {
+		t.Errorf("Pull() extracted %q, want the bundle whose manifest was checked", got)
+	}
+}
+
+func TestMirrorReference(t *testing.T) {
+	mirrors := map[string]string{
+		"ghcr.io":   "mirror.internal/ghcr.io",
+		"quay.io":   "oci://localhost:5000/",
+		"docker.io": "/library",
+	}
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{ref: "ghcr.io/org/app:v1", want: "mirror.internal/ghcr.io/org/app:v1"},
+		{ref: "ghcr.io/org/app@" + testDigest, want: "mirror.internal/ghcr.io/org/app@" + testDigest},
+		{ref: "quay.io/org/app:v1", want: "localhost:5000/org/app:v1"},
+		{ref: "registry.example.com/org/app:v1", want: "registry.example.com/org/app:v1"},
+		{ref: "docker.io/app:v1", wantErr: true},
+	}
+	for _, tt := range tests {
+		ref, err := ParseReference(tt.ref)
+		if err != nil {
+			t.Fatalf("ParseReference(%q) error = %v", tt.ref, err)
+		}
+		got, err := MirrorReference(ref, mirrors)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("MirrorReference(%s) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("MirrorReference(%s) = %s, want %s", tt.ref, got, tt.want)
+		}
+		if ref.String() != tt.ref {
+			t.Errorf("MirrorReference(%s) changed the original reference to %s", tt.ref, ref)
+		}
+	}
+}
+
+func TestPullWithMirrors(t *testing.T) {
+	host := startRegistry(t)
+
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "bundle.cue"), []byte("package bundle\n"), 0644); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	// The mirror serves ghcr.io's repositories under a ghcr.io prefix.
+	mirrored := &Reference{Registry: host, Repository: "ghcr.io/org/bundle", Reference: "v1"}
+	if _, err := Push(ctx, mirrored, src, nil, logger); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	ref := &Reference{Registry: "ghcr.io", Repository: "org/bundle", Reference: "v1"}
+	out := t.TempDir()
+	if _, err := Pull(ctx, ref, out, true, logger, WithMirrors(map[string]string{"ghcr.io": host + "/ghcr.io"}), WithConcurrency(1)); err != nil {
+		t.Fatalf("Pull() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "bundle.cue")); err != nil {
+		t.Errorf("Pull() did not extract bundle.cue: %v", err)
+	}
+}
+
+func TestWithConcurrency(t *testing.T) {
+	tests := []struct {
+		opts    []CopyOption
+		want    int
+		wantErr bool
+	}{
+		{nil, DefaultConcurrency, false},
+		{[]CopyOption{WithConcurrency(8)}, 8, false},
+		{[]CopyOption{WithConcurrency(0)}, 0, true},
+		{[]CopyOption{WithConcurrency(8), WithConcurrency(-1)}, 0, true},
+	}
+	for _, tt := range tests {
+		o, err := newCopyOptions(tt.opts)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("newCopyOptions() concurrency = %d, want error", o.concurrency)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("newCopyOptions() error = %v", err)
+			continue
+		}
+		if o.concurrency != tt.want {
+			t.Errorf("concurrency = %d, want %d", o.concurrency, tt.want)
+		}
+	}
+
+	// Push and Pull reject it before touching the registry, like the push
+	// and pull commands.
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ref, err := ParseReference("registry.invalid/org/bundle:v1")
+	if err != nil {
+		t.Fatalf("ParseReference() error = %v", err)
+	}
+	if _, err := Push(context.Background(), ref, t.TempDir(), nil, logger, WithConcurrency(0)); err == nil || !strings.Contains(err.Error(), "concurrency") {
+		t.Errorf("Push() error = %v, want concurrency error", err)
+	}
+	if _, err := Pull(context.Background(), ref, t.TempDir(), false, logger, WithConcurrency(0)); err == nil || !strings.Contains(err.Error(), "concurrency") {
+		t.Errorf("Pull() error = %v, want concurrency error", err)
+	}
+}
+
+func TestPushRejectsMirrors(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ref, err := ParseReference("registry.invalid/org/bundle:v1")
+	if err != nil {
+		t.Fatalf("ParseReference() error = %v", err)
+	}
+	mirrors := WithMirrors(map[string]string{"registry.invalid": "mirror.invalid"})
+	if _, err := Push(context.Background(), ref, t.TempDir(), nil, logger, mirrors); err == nil || !strings.Contains(err.Error(), "mirrors") {
+		t.Errorf("Push() error = %v, want mirrors error", err)
+	}
+	if _, err := PushManifests(context.Background(), ref, t.TempDir(), nil, logger, mirrors); err == nil || !strings.Contains(err.Error(), "mirrors") {
+		t.Errorf("PushManifests() error = %v, want mirrors error", err)
+	}
+}
+
+func TestPushReturnsPinnedReference(t *testing.T) {
+	host := startRegistry(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "bundle.cue"), []byte("package bundle\n"), 0644); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+	ref, err := ParseReference(host + "/org/bundle:v1")
+	if err != nil {
+		t.Fatalf("ParseReference() error = %v", err)
+	}
+	desc, err := Push(ctx, ref, src, nil, logger)
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	pinned := ref.Pinned(desc.Digest.String())
+	if want := host + "/org/bundle@" + desc.Digest.String(); pinned.String() != want {
+		t.Errorf("Pinned() = %s, want %s", pinned, want)
+	}
+	if tagged := pinned.Tagged(); tagged != nil {
+		t.Errorf("Pinned().Tagged() = %s, want nil", tagged)
+	}
+	if tagged := ref.Tagged(); tagged == nil || tagged.String() != host+"/org/bundle:v1" {
+		t.Errorf("Tagged() = %v, want %s/org/bundle:v1", tagged, host)
+	}
+
+	out := t.TempDir()
+	pulled, err := Pull(ctx, pinned, out, true, logger)
+	if err != nil {
+		t.Fatalf("Pull(pinned) error = %v", err)
+	}
+	if pulled.Digest != desc.Digest {
+		t.Errorf("Pull(pinned) digest = %s, want %s", pulled.Digest, desc.Digest)
+	}
+	if _, err := os.Stat(filepath.Join(out, "bundle.cue")); err != nil {
+		t.Errorf("Pull(pinned) did not extract bundle.cue: %v", err)
+	}
+}
+
+func TestPushManifestIsArtifact(t *testing.T) {
+	host := startRegistry(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "bundle.cue"), []byte("package bundle\n"), 0644); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantRefName string
+	}{
+		{name: "tag as ref name", wantRefName: "v1"},
+		{name: "caller's ref name", annotations: map[string]string{ocispec.AnnotationRefName: "stable"}, wantRefName: "stable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref := &Reference{Registry: host, Repository: "org/bundle", Reference: "v1", Tag: "v1"}
+			desc, err := Push(ctx, ref, src, tt.annotations, logger)
+			if err != nil {
+				t.Fatalf("Push() error = %v", err)
+			}
+
+			repo, err := newRepository(ref)
+			if err != nil {
+				t.Fatalf("newRepository() error = %v", err)
+			}
+			data, err := content.FetchAll(ctx, repo, desc)
+			if err != nil {
+				t.Fatalf("FetchAll() error = %v", err)
+			}
+			var manifest ocispec.Manifest
+			if err := json.Unmarshal(data, &manifest); err != nil {
+				t.Fatalf("failed to decode manifest: %v", err)
+			}
+			if manifest.Config.MediaType != ocispec.MediaTypeEmptyJSON || manifest.Config.Digest != ocispec.DescriptorEmptyJSON.Digest {
+				t.Errorf("config = %+v, want the empty descriptor", manifest.Config)
+			}
+			if manifest.ArtifactType != BundleArtifactType {
+				t.Errorf("artifactType = %q, want %q", manifest.ArtifactType, BundleArtifactType)
+			}
+			if got := manifest.Annotations[ocispec.AnnotationRefName]; got != tt.wantRefName {
+				t.Errorf("ref name annotation = %q, want %q", got, tt.wantRefName)
+			}
+
+			out := t.TempDir()
+			if _, err := Pull(ctx, ref, out, true, logger); err != nil {
+				t.Fatalf("Pull() error = %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(out, "bundle.cue")); err != nil {
+				t.Errorf("Pull() did not extract bundle.cue: %v", err)
+			}
+		})
+	}
+}
+
+func TestAttachArtifact(t *testing.T) {
+	host := startRegistry(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "bundle.cue"), []byte("package bundle\n"), 0644); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+	ref := &Reference{Registry: host, Repository: "org/bundle", Reference: "v1"}
+	if _, err := Push(ctx, ref, src, nil, logger); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	referrers, err := ListReferrers(ctx, ref)
+	if err != nil {
+		t.Fatalf("ListReferrers() error = %v", err)
+	}
+	if len(referrers) != 0 {
+		t.Fatalf("ListReferrers() = %v, want none before attaching", referrers)
+	}
+
+	docsDir := filepath.Join(t.TempDir(), "docs")
+	if err := os.MkdirAll(docsDir, 0755); err != nil {
+		t.Fatalf("failed to create docs dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(docsDir, "SUMMARY.md"), []byte("# Summary\n"), 0644); err != nil {
+		t.Fatalf("failed to write docs: %v", err)
+	}
+
+	const docsType = "application/vnd.odin.docs.v1"
+	attached, err := AttachArtifact(ctx, ref, docsDir, docsType, logger)
+	if err != nil {
+		t.Fatalf("AttachArtifact() error = %v", err)
+	}
+
+	referrers, err = ListReferrers(ctx, ref)
+	if err != nil {
+		t.Fatalf("ListReferrers() error = %v", err)
+	}
+	if len(referrers) != 1 {
+		t.Fatalf("ListReferrers() returned %d referrers, want 1", len(referrers))
+	}
+	if referrers[0].Digest != attached.Digest {
+		t.Errorf("referrer digest = %s, want %s", referrers[0].Digest, attached.Digest)
+	}
+	if referrers[0].ArtifactType != docsType {
+		t.Errorf("referrer artifact type = %q, want %q", referrers[0].ArtifactType, docsType)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/pelletier/go-toml/v2"
 	"github.com/rogpeppe/go-internal/testscript"
 	"go-valkyrie.com/odin/pkg/cmd/template"
+	"go-valkyrie.com/odin/pkg/cueerr"
 	"go-valkyrie.com/odin/pkg/model"
 )
 
@@ -84,7 +85,7 @@ func TemplateCmd(ctx context.Context, globalRegistries map[string]string, cacheD
 		bundleConfig, err := model.LoadConfig(".")
 		if err != nil {
 			if !neg {
-				ts.Fatalf("failed to load config: %v", err)
+				ts.Fatalf("failed to load config: %s", describeError(err))
 			}
 			return
 		}
@@ -113,10 +114,22 @@ func TemplateCmd(ctx context.Context, globalRegistries map[string]string, cacheD
 			return
 		}
 		if err != nil {
-			ts.Fatalf("template failed: %v", err)
+			ts.Fatalf("template failed: %s", describeError(err))
 		}
 
 		// Write output to stdout
 		ts.Stdout().Write([]byte(output.String()))
 	}
 }
+
+// describeError formats err for a test failure. CUE errors are expanded to
+// one per line with the positions they refer to, as the odin CLI prints them,
+// so failing scripts show where in the CUE the problem is.
+func describeError(err error) string {
+	if !cueerr.Is(err) {
+		return err.Error()
+	}
+	var b strings.Builder
+	cueerr.Format(&b, err)
+	return "\n" + strings.TrimSuffix(b.String(), "\n")
+}
@@ -46,6 +46,14 @@ func WithUpdateScripts(update bool) ParamsOption {
 	}
 }
 
+// WithTestWork keeps each script's work directory after the run instead of
+// removing it (equivalent to the -testwork flag).
+func WithTestWork(keep bool) ParamsOption {
+	return func(p *testscript.Params) {
+		p.TestWork = keep
+	}
+}
+
 // WithDir sets the directory containing test scripts.
 func WithDir(dir string) ParamsOption {
 	return func(p *testscript.Params) {
@@ -71,3 +79,22 @@ func WithCmds(cmds map[string]func(*testscript.TestScript, bool, []string)) Para
 		}
 	}
 }
+
+// WithEnv sets additional environment variables in every test script, after
+// the variables forwarded by DefaultParams.
+func WithEnv(vars map[string]string) ParamsOption {
+	return func(p *testscript.Params) {
+		setup := p.Setup
+		p.Setup = func(env *testscript.Env) error {
+			if setup != nil {
+				if err := setup(env); err != nil {
+					return err
+				}
+			}
+			for k, v := range vars {
+				env.Setenv(k, v)
+			}
+			return nil
+		}
+	}
+}
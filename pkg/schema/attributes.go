@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/token"
+)
+
+// odinKeywords are the arguments without a value that @odin accepts.
+var odinKeywords = []string{"ref", "ext", "other", "hidden", "expand", "component"}
+
+// odinKeys are the key=value arguments that @odin accepts.
+var odinKeys = []string{"example"}
+
+// AttributeError is a malformed @odin attribute found by
+// ValidateOdinAttributes.
+type AttributeError struct {
+	// Path is the path of the field or definition the attribute is on.
+	Path string
+	// Pos is the position of the field or definition, if known.
+	Pos     token.Pos
+	Message string
+}
+
+func (e *AttributeError) Error() string {
+	msg := fmt.Sprintf("@odin attribute on %s: %s", e.Path, e.Message)
+	if e.Pos.IsValid() {
+		return e.Pos.String() + ": " + msg
+	}
+	return msg
+}
+
+// ValidateOdinAttributes checks the @odin attributes of value and all of the
+// definitions and fields within it, returning an error for each one that
+// can't be parsed, uses an unknown keyword, such as a misspelled
+// @odin(reff) that would otherwise be read as "other", or is missing or has
+// an unexpected value. Errors are returned in the order they're found.
+func ValidateOdinAttributes(value cue.Value) []error {
+	var errs []error
+	seen := make(map[string]bool)
+	var walk func(v cue.Value)
+	walk = func(v cue.Value) {
+		for _, a := range v.Attributes(cue.ValueAttr) {
+			if a.Name() != "odin" {
+				continue
+			}
+			for _, e := range validateOdinAttribute(v, a) {
+				// A definition unified into another field repeats its
+				// attributes; report each once, where it's declared.
+				key := e.Pos.String() + ": " + e.Message
+				if !seen[key] {
+					seen[key] = true
+					errs = append(errs, e)
+				}
+			}
+		}
+		// A reference is checked where it's declared, and following it
+		// could recurse forever.
+		if _, path := v.ReferencePath(); len(path.Selectors()) > 0 {
+			return
+		}
+		if v.IncompleteKind() != cue.StructKind {
+			return
+		}
+		iter, err := v.Fields(cue.Definitions(true), cue.Optional(true), cue.Patterns(true))
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			walk(iter.Value())
+		}
+	}
+	walk(value)
+	return errs
+}
+
+func validateOdinAttribute(v cue.Value, a cue.Attribute) []*AttributeError {
+	newErr := func(format string, args ...any) *AttributeError {
+		return &AttributeError{
+			Path:    v.Path().String(),
+			Pos:     v.Pos(),
+			Message: fmt.Sprintf(format, args...),
+		}
+	}
+	if err := a.Err(); err != nil {
+		return []*AttributeError{newErr("%v", err)}
+	}
+
+	var errs []*AttributeError
+	for arg := range a.Args(0) {
+		if arg.Key == "" {
+			switch {
+			case slices.Contains(odinKeywords, arg.Value):
+			case slices.Contains(odinKeys, arg.Value):
+				errs = append(errs, newErr("%s needs a value, as %s=<value>", arg.Value, arg.Value))
+			default:
+				errs = append(errs, newErr("unknown keyword %q (want one of %s)", arg.Value, strings.Join(odinKeywords, ", ")))
+			}
+			continue
+		}
+		switch {
+		case slices.Contains(odinKeys, arg.Key):
+			if arg.Value == "" {
+				errs = append(errs, newErr("%s needs a value, as %s=<value>", arg.Key, arg.Key))
+			}
+		case slices.Contains(odinKeywords, arg.Key):
+			errs = append(errs, newErr("%s doesn't take a value", arg.Key))
+		default:
+			errs = append(errs, newErr("unknown argument %q (want one of %s)", arg.Key, strings.Join(odinKeys, ", ")))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MIT
+
+package schema_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"go-valkyrie.com/odin/pkg/schema"
+)
+
+func TestValidateOdinAttributes(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "valid",
+			src: `
+				#Ref: {name: string} @odin(ref)
+				#Ext: {name: string} @odin(ext)
+				#Plain: {name: string}
+				#Template: {kind: string} @odin(component)
+				#Config: {
+					port: int @odin(example=8080) @odin(example=443)
+					host: string @odin(example="db.internal", expand)
+					secret: string @odin(hidden)
+					labels: [string]: string @odin(hidden)
+				} @odin(other)
+			`,
+		},
+		{
+			name: "typo in category",
+			src:  `#Ref: {name: string} @odin(reff)`,
+			want: []string{`@odin attribute on #Ref: unknown keyword "reff"`},
+		},
+		{
+			name: "typo in nested field",
+			src:  `#Config: {db: {password: string @odin(hiden)}}`,
+			want: []string{`@odin attribute on #Config.db.password: unknown keyword "hiden"`},
+		},
+		{
+			name: "unknown key",
+			src:  `#Config: {port: int @odin(exmaple=8080)}`,
+			want: []string{`@odin attribute on #Config.port: unknown argument "exmaple"`},
+		},
+		{
+			name: "missing value",
+			src:  `#Config: {port: int @odin(example)}`,
+			want: []string{"@odin attribute on #Config.port: example needs a value"},
+		},
+		{
+			name: "unexpected value",
+			src:  `#Config: {port: int @odin(hidden=true)}`,
+			want: []string{"@odin attribute on #Config.port: hidden doesn't take a value"},
+		},
+		{
+			name: "one error per bad argument",
+			src:  `#Config: {port: int @odin(hidden, exapnd, example)}`,
+			want: []string{`unknown keyword "exapnd"`, "example needs a value"},
+		},
+		{
+			name: "referenced definition reported once",
+			src: `
+				#Inner: {value: string @odin(reff)}
+				#Outer: {a: #Inner, b: #Inner}
+			`,
+			want: []string{`@odin attribute on #Inner.value: unknown keyword "reff"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := cuecontext.New().CompileString(tt.src, cue.Filename("schema.cue"))
+			if err := v.Err(); err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			errs := schema.ValidateOdinAttributes(v)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("ValidateOdinAttributes() = %v, want %d errors", errs, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+				var attrErr *schema.AttributeError
+				if !errors.As(errs[i], &attrErr) || !attrErr.Pos.IsValid() {
+					t.Errorf("error %d = %#v, want an *AttributeError with a position", i, errs[i])
+				}
+				if !strings.HasPrefix(errs[i].Error(), "schema.cue:") {
+					t.Errorf("error %d = %q, want it to start with its position", i, errs[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+)
+
+// exampleOptions holds options for FormatExampleCUE and FormatExampleYAML.
+type exampleOptions struct {
+	onlyRequired bool
+}
+
+// ExampleOption is a functional option for FormatExampleCUE and
+// FormatExampleYAML.
+type ExampleOption func(*exampleOptions)
+
+// WithOnlyRequired limits the example to fields that must be provided:
+// those that are neither optional nor have a default. Structs are kept only
+// when they contain such a field.
+func WithOnlyRequired(onlyRequired bool) ExampleOption {
+	return func(o *exampleOptions) {
+		o.onlyRequired = onlyRequired
+	}
+}
+
+// FormatExampleCUE writes a CUE skeleton for fields to w. Fields with
+// defaults are filled with them, fields without defaults get a placeholder
+// of their type, followed by their constraint as a comment when it is more
+// than a type, and optional fields without defaults are left out.
+func FormatExampleCUE(w io.Writer, fields []*SchemaField, depth int, opts ...ExampleOption) {
+	o := &exampleOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	formatExampleCUE(w, exampleFields(fields, o), depth, o)
+}
+
+func formatExampleCUE(w io.Writer, fields []*SchemaField, depth int, o *exampleOptions) {
+	indent := strings.Repeat("\t", depth)
+	for _, f := range fields {
+		writeExampleDoc(w, indent, "//", f.Doc)
+		if len(f.Children) > 0 {
+			if children := exampleFields(f.Children, o); len(children) > 0 {
+				fmt.Fprintf(w, "%s%s: {\n", indent, exampleLabel(f.Name))
+				formatExampleCUE(w, children, depth+1, o)
+				fmt.Fprintf(w, "%s}\n", indent)
+				continue
+			}
+		}
+		value, constraint := exampleValue(f)
+		fmt.Fprintf(w, "%s%s: %s%s\n", indent, exampleLabel(f.Name), value, exampleComment(" //", constraint))
+	}
+}
+
+// FormatExampleYAML writes a YAML skeleton for fields to w, following the
+// same rules as FormatExampleCUE.
+func FormatExampleYAML(w io.Writer, fields []*SchemaField, depth int, opts ...ExampleOption) {
+	o := &exampleOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	formatExampleYAML(w, exampleFields(fields, o), depth, o)
+}
+
+func formatExampleYAML(w io.Writer, fields []*SchemaField, depth int, o *exampleOptions) {
+	indent := strings.Repeat("  ", depth)
+	for _, f := range fields {
+		writeExampleDoc(w, indent, "#", f.Doc)
+		if len(f.Children) > 0 {
+			if children := exampleFields(f.Children, o); len(children) > 0 {
+				fmt.Fprintf(w, "%s%s:\n", indent, f.Name)
+				formatExampleYAML(w, children, depth+1, o)
+				continue
+			}
+		}
+		value, constraint := exampleValue(f)
+		fmt.Fprintf(w, "%s%s: %s%s\n", indent, f.Name, value, exampleComment(" #", constraint))
+	}
+}
+
+// exampleFields returns the fields that belong in an example: everything
+// except pattern constraints and optional fields without a default. With
+// onlyRequired, fields with defaults and structs without a required
+// descendant are dropped as well.
+func exampleFields(fields []*SchemaField, o *exampleOptions) []*SchemaField {
+	var result []*SchemaField
+	for _, f := range fields {
+		if f.IsPattern {
+			continue
+		}
+		if f.Optional && f.Default == "" {
+			continue
+		}
+		if o.onlyRequired && !isRequiredField(f) {
+			continue
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// isRequiredField reports whether a value must be provided for f.
+func isRequiredField(f *SchemaField) bool {
+	if f.IsPattern || f.Optional || f.Default != "" {
+		return false
+	}
+	if len(f.Children) == 0 {
+		return true
+	}
+	for _, child := range f.Children {
+		if isRequiredField(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeExampleDoc(w io.Writer, indent, mark, doc string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		fmt.Fprintf(w, "%s%s %s\n", indent, mark, line)
+	}
+}
+
+// exampleLabel quotes name when it isn't a valid CUE identifier.
+func exampleLabel(name string) string {
+	if strings.HasPrefix(name, `"`) {
+		return name
+	}
+	for i, r := range name {
+		if r == '_' || r == '$' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9' {
+			continue
+		}
+		return fmt.Sprintf("%q", name)
+	}
+	return name
+}
+
+// exampleComment returns constraint as a trailing comment, or nothing if
+// there is none.
+func exampleComment(mark, constraint string) string {
+	if constraint == "" {
+		return ""
+	}
+	return mark + " " + constraint
+}
+
+// exampleValue returns the default for f, or a placeholder of its type. In
+// the latter case it also returns f's type when that is a constraint rather
+// than a plain type, such as int & >0 or "a" | "b", since the placeholder
+// alone doesn't show it.
+func exampleValue(f *SchemaField) (value, constraint string) {
+	if f.Default != "" {
+		return f.Default, ""
+	}
+	if len(f.Children) > 0 {
+		return "{}", ""
+	}
+	switch f.Type {
+	case "", "_", "{...}", "[...]", "string", "int", "number", "float", "bool", "bytes", "null":
+		return examplePlaceholder(f.Type), ""
+	}
+	if strings.HasPrefix(f.Type, "#") {
+		// Unexpanded definition reference.
+		return "{}", ""
+	}
+	return examplePlaceholder(f.Type), f.Type
+}
+
+// examplePlaceholder returns a value of the kind typ, a CUE expression,
+// allows: the first option of a disjunction if that is a concrete scalar,
+// or else the zero value of its kind.
+func examplePlaceholder(typ string) string {
+	v := cuecontext.New().CompileString(typ)
+	if v.Err() != nil {
+		return "{}"
+	}
+	if op, args := v.Expr(); op == cue.OrOp && len(args) > 0 {
+		v = args[0]
+	}
+	kind := v.IncompleteKind()
+	if v.IsConcrete() && kind&(cue.StringKind|cue.NumberKind|cue.BoolKind|cue.NullKind) != 0 {
+		return formatValue(v)
+	}
+	switch {
+	case kind&cue.StringKind != 0:
+		return `"TODO"`
+	case kind&cue.IntKind != 0:
+		return "0"
+	case kind&cue.FloatKind != 0:
+		return "0.0"
+	case kind&cue.BoolKind != 0:
+		return "false"
+	case kind&cue.BytesKind != 0:
+		return `''`
+	case kind&cue.NullKind != 0:
+		return "null"
+	case kind&cue.ListKind != 0:
+		return "[]"
+	default:
+		return "{}"
+	}
+}
@@ -16,8 +16,27 @@ var (
 	fieldName    = color.New(color.Bold).SprintFunc()
 	typeName     = color.New(color.FgGreen).SprintFunc()
 	defaultValue = color.New(color.FgYellow).SprintFunc()
+	exampleText  = color.New(color.FgMagenta).SprintFunc()
+	originMark   = color.New(color.FgCyan).SprintFunc()
 )
 
+// truncatedHint follows the type of fields whose children were cut off by
+// WithMaxDepth.
+const truncatedHint = " (more fields not shown)"
+
+// examplesHint describes a field's example values, e.g. " (example: 8080)",
+// or is empty if it has none.
+func examplesHint(examples []string) string {
+	switch len(examples) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf(" (example: %s)", examples[0])
+	default:
+		return fmt.Sprintf(" (examples: %s)", strings.Join(examples, ", "))
+	}
+}
+
 // FormatSchema writes a human-readable schema tree to w.
 func FormatSchema(w io.Writer, fields []*SchemaField, indent int) {
 	for _, f := range fields {
@@ -50,6 +69,15 @@ func FormatSchema(w io.Writer, fields []*SchemaField, indent int) {
 			} else {
 				typeStr = typeName(typeStr)
 			}
+			if len(f.Examples) > 0 {
+				typeStr += exampleText(examplesHint(f.Examples))
+			}
+			if f.Truncated {
+				typeStr += commentMark(truncatedHint)
+			}
+			if f.Origin != "" {
+				typeStr += originMark(fmt.Sprintf(" (%s)", f.Origin))
+			}
 
 			// Pad the name to at least 20 chars for alignment
 			padding := 20 - len(name)
@@ -96,6 +124,13 @@ func FormatSchemaMarkdown(w io.Writer, fields []*SchemaField, depth int) {
 			if f.Default != "" {
 				typeInfo = fmt.Sprintf("`%s` (default: %s)", f.Type, f.Default)
 			}
+			typeInfo += examplesHint(f.Examples)
+			if f.Truncated {
+				typeInfo += truncatedHint
+			}
+			if f.Origin != "" {
+				typeInfo += fmt.Sprintf(" (%s)", f.Origin)
+			}
 			fmt.Fprintf(w, "%s- **%s**%s: %s\n", indent, name, optMarker, typeInfo)
 		}
 	}
@@ -159,7 +194,11 @@ func formatDeclarationGroup(w io.Writer, declarations []*Declaration, indent int
 			if padding < 1 {
 				padding = 1
 			}
-			fmt.Fprintf(w, "%s%s%s%s\n", prefix, fieldName(d.Name), strings.Repeat(" ", padding), typeName(d.Type))
+			typeStr := typeName(d.Type)
+			if d.Truncated {
+				typeStr += commentMark(truncatedHint)
+			}
+			fmt.Fprintf(w, "%s%s%s%s\n", prefix, fieldName(d.Name), strings.Repeat(" ", padding), typeStr)
 		}
 	}
 }
@@ -218,7 +257,11 @@ func formatDeclarationGroupMarkdown(w io.Writer, declarations []*Declaration, de
 			FormatSchemaMarkdown(w, d.Children, depth+1)
 		} else {
 			// Leaf declaration: name with type
-			fmt.Fprintf(w, "- **%s**: `%s`\n", d.Name, d.Type)
+			typeInfo := fmt.Sprintf("`%s`", d.Type)
+			if d.Truncated {
+				typeInfo += truncatedHint
+			}
+			fmt.Fprintf(w, "- **%s**: %s\n", d.Name, typeInfo)
 		}
 	}
 }
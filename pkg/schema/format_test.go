@@ -4,8 +4,14 @@ package schema
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"strings"
 	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"gopkg.in/yaml.v3"
 )
 
 func TestFormatSchemaMarkdown(t *testing.T) {
@@ -45,6 +51,26 @@ func TestFormatSchemaMarkdown(t *testing.T) {
 				"- **enabled**: `bool` (default: true)",
 			},
 		},
+		{
+			name: "field with example",
+			fields: []*SchemaField{
+				{Name: "port", Type: "int", Default: "80", Examples: []string{"8080"}},
+			},
+			depth: 0,
+			wantContains: []string{
+				"- **port**: `int` (default: 80) (example: 8080)",
+			},
+		},
+		{
+			name: "field with examples",
+			fields: []*SchemaField{
+				{Name: "port", Type: "int", Examples: []string{"80", "443"}},
+			},
+			depth: 0,
+			wantContains: []string{
+				"- **port**: `int` (examples: 80, 443)",
+			},
+		},
 		{
 			name: "field with doc comment",
 			fields: []*SchemaField{
@@ -210,3 +236,147 @@ func TestFormatDeclarationsMarkdown(t *testing.T) {
 		})
 	}
 }
+
+const exampleSchemaSource = `
+#Probe: path: string
+
+config: {
+	// Container image to run.
+	image: string
+	replicas: int | *1
+	env: "dev" | "prod"
+	port: int & >0 | "auto"
+	probe: #Probe
+	labels: [string]: string
+	debug?: bool
+	pullPolicy?: "Always" | *"IfNotPresent"
+	"app.kubernetes.io/part-of": string
+}
+`
+
+func TestFormatExample(t *testing.T) {
+	v := cuecontext.New().CompileString(exampleSchemaSource)
+	if err := v.Err(); err != nil {
+		t.Fatalf("failed to compile schema: %v", err)
+	}
+	fields := WalkSchema(v.LookupPath(cue.ParsePath("config")), WithExpand(true))
+
+	tests := []struct {
+		name   string
+		format func(w io.Writer, fields []*SchemaField, depth int, opts ...ExampleOption)
+		opts   []ExampleOption
+		want   string
+	}{
+		{
+			name:   "cue",
+			format: FormatExampleCUE,
+			want: `// Container image to run.
+image: "TODO"
+replicas: 1
+env: "dev" // "dev" | "prod"
+port: 0 // int & >0 | "auto"
+probe: {
+	path: "TODO"
+}
+labels: {}
+pullPolicy: "IfNotPresent"
+"app.kubernetes.io/part-of": "TODO"
+`,
+		},
+		{
+			name:   "yaml",
+			format: FormatExampleYAML,
+			want: `# Container image to run.
+image: "TODO"
+replicas: 1
+env: "dev" # "dev" | "prod"
+port: 0 # int & >0 | "auto"
+probe:
+  path: "TODO"
+labels: {}
+pullPolicy: "IfNotPresent"
+"app.kubernetes.io/part-of": "TODO"
+`,
+		},
+		{
+			name:   "cue only required",
+			format: FormatExampleCUE,
+			opts:   []ExampleOption{WithOnlyRequired(true)},
+			want: `// Container image to run.
+image: "TODO"
+env: "dev" // "dev" | "prod"
+port: 0 // int & >0 | "auto"
+probe: {
+	path: "TODO"
+}
+"app.kubernetes.io/part-of": "TODO"
+`,
+		},
+		{
+			name:   "yaml only required",
+			format: FormatExampleYAML,
+			opts:   []ExampleOption{WithOnlyRequired(true)},
+			want: `# Container image to run.
+image: "TODO"
+env: "dev" # "dev" | "prod"
+port: 0 # int & >0 | "auto"
+probe:
+  path: "TODO"
+"app.kubernetes.io/part-of": "TODO"
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.format(&buf, fields, 0, tt.opts...)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output mismatch\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormatExampleYAMLConstraints checks that fields constrained beyond a
+// plain type get placeholders that decode as values of that type.
+func TestFormatExampleYAMLConstraints(t *testing.T) {
+	v := cuecontext.New().CompileString(`
+		positive: int & >0 | "auto"
+		ratio: float & <1
+		size: number
+		name: =~"^[a-z]+$"
+		env: "dev" | "prod"
+		level: 1 | 2
+		flag: bool | string
+		tags: [...string]
+	`)
+	if err := v.Err(); err != nil {
+		t.Fatalf("failed to compile schema: %v", err)
+	}
+	var buf bytes.Buffer
+	FormatExampleYAML(&buf, WalkSchema(v), 0)
+
+	var got map[string]any
+	if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("example is not YAML: %v\n%s", err, buf.String())
+	}
+	want := map[string]any{
+		"positive": 0,
+		"ratio":    0.0,
+		"size":     0,
+		"name":     "TODO",
+		"env":      "dev",
+		"level":    1,
+		"flag":     false,
+		"tags":     []any{},
+	}
+	for name, wantValue := range want {
+		if fmt.Sprintf("%#v", got[name]) != fmt.Sprintf("%#v", wantValue) {
+			t.Errorf("%s = %#v, want %#v\n%s", name, got[name], wantValue, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), `positive: 0 # int & >0 | "auto"`) {
+		t.Errorf("constraint of positive is not kept as a comment:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: MIT
+
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalSchema serializes a schema tree as indented JSON for golden-file
+// tests and other tools that compare schemas. Unlike FormatSchema, the output
+// has no colors and doesn't change with the terminal: the fields of each
+// SchemaField are always written in the same order, fields are kept in the
+// order given, empty attributes are left out and characters such as < and >
+// aren't escaped. The result ends with a newline and unmarshals back into
+// the same tree.
+func MarshalSchema(fields []*SchemaField) ([]byte, error) {
+	if fields == nil {
+		fields = []*SchemaField{}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(fields); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"go-valkyrie.com/odin/pkg/schema"
+)
+
+func TestMarshalSchema(t *testing.T) {
+	v := cuecontext.New().CompileString(`
+		#Config: {
+			// The name of the app.
+			name!: string
+			replicas?: int & >=1 | *1
+			db: {
+				host: string | *"<none>"
+			}
+			labels: [string]: string
+		}
+	`)
+	fields := schema.WalkSchema(v.LookupPath(cue.ParsePath("#Config")))
+
+	got, err := schema.MarshalSchema(fields)
+	if err != nil {
+		t.Fatalf("MarshalSchema() error = %v", err)
+	}
+	want := `[
+  {
+    "name": "name",
+    "doc": "The name of the app.",
+    "type": "string",
+    "required": true
+  },
+  {
+    "name": "replicas",
+    "type": "int",
+    "optional": true,
+    "default": "1"
+  },
+  {
+    "name": "db",
+    "children": [
+      {
+        "name": "host",
+        "type": "string",
+        "default": "\"<none>\""
+      }
+    ]
+  },
+  {
+    "name": "labels",
+    "children": [
+      {
+        "name": "[string]",
+        "type": "string",
+        "isPattern": true
+      }
+    ]
+  }
+]
+`
+	if string(got) != want {
+		t.Errorf("MarshalSchema() =\n%s\nwant:\n%s", got, want)
+	}
+
+	var roundTrip []*schema.SchemaField
+	if err := json.Unmarshal(got, &roundTrip); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(roundTrip, fields) {
+		t.Errorf("round trip changed the schema: got %+v, want %+v", roundTrip, fields)
+	}
+
+	empty, err := schema.MarshalSchema(nil)
+	if err != nil || string(empty) != "[]\n" {
+		t.Errorf("MarshalSchema(nil) = %q, %v, want %q", empty, err, "[]\n")
+	}
+}
@@ -3,6 +3,7 @@
 package schema
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -11,16 +12,32 @@ import (
 
 // SchemaField represents a single field in a CUE schema tree.
 type SchemaField struct {
-	Name      string
-	Doc       string
-	Type      string
-	Optional  bool
-	Required  bool
-	IsPattern bool
-	Default   string
-	Children  []*SchemaField
+	Name      string `json:"name"`
+	Doc       string `json:"doc,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Optional  bool   `json:"optional,omitempty"`
+	Required  bool   `json:"required,omitempty"`
+	IsPattern bool   `json:"isPattern,omitempty"`
+	Default   string `json:"default,omitempty"`
+	// Examples are example values given by the author with
+	// @odin(example=...), in the order they're declared.
+	Examples []string `json:"examples,omitempty"`
+	Origin   Origin   `json:"origin,omitempty"`
+	// Truncated reports that the field has children cut off by WithMaxDepth.
+	Truncated bool           `json:"truncated,omitempty"`
+	Children  []*SchemaField `json:"children,omitempty"`
 }
 
+// Origin records where the effective value of a leaf field comes from, as
+// determined by AnnotateOrigins.
+type Origin string
+
+const (
+	OriginDefault    Origin = "default"    // the schema default applies
+	OriginSet        Origin = "set"        // set by values, matching or lacking a default
+	OriginOverridden Origin = "overridden" // set by values, replacing the default
+)
+
 // DeclarationCategory represents the category of a declaration based on @odin attribute.
 type DeclarationCategory string
 
@@ -36,12 +53,29 @@ type Declaration struct {
 	Doc      string
 	Category DeclarationCategory
 	Type     string
-	Children []*SchemaField
+	// Truncated reports that the declaration has fields cut off by
+	// WithMaxDepth.
+	Truncated bool
+	Children  []*SchemaField
 }
 
 // walkOptions holds options for WalkSchema.
 type walkOptions struct {
-	expand bool
+	expand   bool
+	maxDepth int
+	// filters decide which fields are walked; a field must pass all of them.
+	filters []func(attrs []cue.Attribute) bool
+}
+
+func newWalkOptions(opts []WalkOption) walkOptions {
+	o := walkOptions{
+		maxDepth: -1,
+		filters:  []func([]cue.Attribute) bool{notOdinHidden},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
 }
 
 // WalkOption is a functional option for WalkSchema.
@@ -55,17 +89,64 @@ func WithExpand(expand bool) WalkOption {
 	}
 }
 
-// hasOdinHidden checks if a value has @odin(hidden) attribute.
-func hasOdinHidden(v cue.Value) bool {
+// WithMaxDepth limits how many levels of nested fields are walked. At depth 0
+// only top-level fields are returned; structs below the limit are reported as
+// "{...}" and marked Truncated. A negative depth, the default, is unlimited.
+func WithMaxDepth(depth int) WalkOption {
+	return func(o *walkOptions) {
+		o.maxDepth = depth
+	}
+}
+
+// odinExamples returns the values of the example arguments of a value's
+// @odin attributes, e.g. "8080" for @odin(example=8080).
+func odinExamples(v cue.Value) []string {
+	var examples []string
+	for _, a := range v.Attributes(cue.ValueAttr) {
+		if a.Name() != "odin" {
+			continue
+		}
+		for i := range a.NumArgs() {
+			if key, value := a.Arg(i); key == "example" {
+				examples = append(examples, value)
+			}
+		}
+	}
+	return examples
+}
+
+// WithAttributeFilter only walks fields for which keep returns true, given
+// the field's attributes, e.g. only those tagged @odin(public). Fields that
+// are filtered out are skipped along with their children. Fields with
+// @odin(hidden) are always skipped; filters add to that and to each other.
+func WithAttributeFilter(keep func(attrs []cue.Attribute) bool) WalkOption {
+	return func(o *walkOptions) {
+		o.filters = append(o.filters, keep)
+	}
+}
+
+// includes reports whether v's field passes the attribute filters.
+func (o walkOptions) includes(v cue.Value) bool {
 	attrs := v.Attributes(cue.ValueAttr)
+	for _, keep := range o.filters {
+		if !keep(attrs) {
+			return false
+		}
+	}
+	return true
+}
+
+// notOdinHidden is the default attribute filter, skipping fields with the
+// @odin(hidden) attribute.
+func notOdinHidden(attrs []cue.Attribute) bool {
 	for _, a := range attrs {
 		if a.Name() == "odin" {
 			if arg, err := a.String(0); err == nil && arg == "hidden" {
-				return true
+				return false
 			}
 		}
 	}
-	return false
+	return true
 }
 
 // hasOdinExpand checks if a value has @odin(expand) attribute.
@@ -84,27 +165,99 @@ func hasOdinExpand(v cue.Value) bool {
 // WalkSchema traverses a cue.Value's schema tree and returns a tree of SchemaField.
 // Options can be provided to control behavior (e.g., WithExpand).
 func WalkSchema(value cue.Value, opts ...WalkOption) []*SchemaField {
-	o := &walkOptions{}
-	for _, opt := range opts {
-		opt(o)
+	return walkFields(value, newWalkOptions(opts), 0)
+}
+
+// SkipChildren can be returned by the visit function of WalkSchemaFunc to
+// skip the children of the field it was called for.
+var SkipChildren = errors.New("skip children")
+
+// SkipAll can be returned by the visit function of WalkSchemaFunc to stop the
+// walk; WalkSchemaFunc then returns nil.
+var SkipAll = errors.New("skip all")
+
+// WalkSchemaFunc traverses value's schema tree like WalkSchema, but calls
+// visit for each field as it's found instead of building a tree. Fields are
+// visited depth first, each before its children, in the order WalkSchema
+// returns them. path is the field's dot-separated path from value, e.g.
+// "database.pool.size". The field's Children are not populated; a struct with
+// children has an empty Type, like in WalkSchema.
+//
+// If visit returns an error other than SkipChildren or SkipAll, the walk
+// stops and WalkSchemaFunc returns it.
+func WalkSchemaFunc(value cue.Value, visit func(path string, f *SchemaField) error, opts ...WalkOption) error {
+	err := visitFields(value, "", nil, newWalkOptions(opts), 0, func(path string, _, f *SchemaField) error {
+		return visit(path, f)
+	})
+	if err == SkipAll {
+		return nil
+	}
+	return err
+}
+
+// AnnotateOrigins sets the Origin of each leaf field by comparing its schema
+// default with the value, if any, that values sets for it. Fields under
+// pattern constraints aren't annotated, since they have no single path.
+func AnnotateOrigins(fields []*SchemaField, values cue.Value) {
+	for _, f := range fields {
+		if f.IsPattern {
+			continue
+		}
+		// f.Name is a CUE label, quoted when it isn't an identifier.
+		v := values.LookupPath(cue.ParsePath(f.Name))
+		if len(f.Children) > 0 {
+			AnnotateOrigins(f.Children, v)
+			continue
+		}
+		switch {
+		case !v.Exists() && f.Default != "":
+			f.Origin = OriginDefault
+		case !v.Exists():
+			// Neither set nor defaulted.
+		case f.Default != "" && formatValue(v) != f.Default:
+			f.Origin = OriginOverridden
+		default:
+			f.Origin = OriginSet
+		}
 	}
-	return walkFields(value, o.expand)
 }
 
-func walkFields(value cue.Value, expand bool) []*SchemaField {
+// walkFields walks the fields of value, which are at the given depth, and
+// returns them as a tree.
+func walkFields(value cue.Value, o walkOptions, depth int) []*SchemaField {
+	var fields []*SchemaField
+	visitFields(value, "", nil, o, depth, func(_ string, parent, f *SchemaField) error {
+		if parent == nil {
+			fields = append(fields, f)
+		} else {
+			parent.Children = append(parent.Children, f)
+		}
+		return nil
+	})
+	return fields
+}
+
+// visitFunc is called for each field visited, with its parent field, nil at
+// the top level.
+type visitFunc func(path string, parent, f *SchemaField) error
+
+// visitFields calls visit for each field of value, which are at the given
+// depth under prefix, and then for its children.
+func visitFields(value cue.Value, prefix string, parent *SchemaField, o walkOptions, depth int, visit visitFunc) error {
 	iter, err := value.Fields(cue.Optional(true))
 	if err != nil {
 		return nil
 	}
 
-	var fields []*SchemaField
 	for iter.Next() {
-		// Skip fields with @odin(hidden) attribute
-		if hasOdinHidden(iter.Value()) {
+		// Skip fields with @odin(hidden) or excluded by other filters
+		if !o.includes(iter.Value()) {
 			continue
 		}
-		f := fieldFromIter(iter, expand)
-		fields = append(fields, f)
+		f := fieldFromIter(iter)
+		if err := visitField(f, iter.Value(), prefix, parent, o, depth, visit); err != nil {
+			return err
+		}
 	}
 
 	// Also walk pattern constraints
@@ -113,24 +266,44 @@ func walkFields(value cue.Value, expand bool) []*SchemaField {
 		for iter.Next() {
 			sel := iter.Selector()
 			if sel.ConstraintType() == cue.PatternConstraint {
-				// Skip pattern constraints with @odin(hidden) attribute
-				if hasOdinHidden(iter.Value()) {
+				// Skip pattern constraints excluded by the filters
+				if !o.includes(iter.Value()) {
 					continue
 				}
 				f := &SchemaField{
 					Name:      sel.String(),
 					IsPattern: true,
 				}
-				populateFieldValue(f, iter.Value(), expand)
-				fields = append(fields, f)
+				if err := visitField(f, iter.Value(), prefix, parent, o, depth, visit); err != nil {
+					return err
+				}
 			}
 		}
 	}
 
-	return fields
+	return nil
+}
+
+// visitField populates f from its value v, visits it and then, unless visit
+// returns SkipChildren, its children.
+func visitField(f *SchemaField, v cue.Value, prefix string, parent *SchemaField, o walkOptions, depth int, visit visitFunc) error {
+	path := f.Name
+	if prefix != "" {
+		path = prefix + "." + f.Name
+	}
+	descend := populateFieldValue(f, v, o, depth)
+	if err := visit(path, parent, f); err == SkipChildren {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !descend {
+		return nil
+	}
+	return visitFields(v, path, f, o, depth+1, visit)
 }
 
-func fieldFromIter(iter *cue.Iterator, expand bool) *SchemaField {
+func fieldFromIter(iter *cue.Iterator) *SchemaField {
 	sel := iter.Selector()
 	name := sel.String()
 	// Selector.String() includes optionality markers (? and !), strip them
@@ -152,11 +325,13 @@ func fieldFromIter(iter *cue.Iterator, expand bool) *SchemaField {
 		f.Doc = strings.TrimSpace(strings.Join(docParts, "\n"))
 	}
 
-	populateFieldValue(f, iter.Value(), expand)
+	f.Examples = odinExamples(iter.Value())
 	return f
 }
 
-func populateFieldValue(f *SchemaField, v cue.Value, expand bool) {
+// populateFieldValue sets f's type and default from v, and reports whether f
+// has children to walk.
+func populateFieldValue(f *SchemaField, v cue.Value, o walkOptions, depth int) bool {
 	// Check for default value
 	defVal, hasDefault := v.Default()
 	if hasDefault {
@@ -169,36 +344,62 @@ func populateFieldValue(f *SchemaField, v cue.Value, expand bool) {
 	op, args := v.Expr()
 	if op == cue.OrOp && len(args) > 0 {
 		f.Type = formatDisjunction(args)
-		return
+		return false
 	}
 
 	// Check for @odin(expand) attribute to force expansion
 	forceExpand := hasOdinExpand(v)
 
 	// Check if this is a definition reference (unexpanded)
-	if !expand && !forceExpand && kind == cue.StructKind {
+	if !o.expand && !forceExpand && kind == cue.StructKind {
 		if defName, ok := definitionRefName(v); ok {
 			f.Type = defName
-			return
+			return false
 		}
 	}
 
 	if kind == cue.StructKind {
-		children := walkFields(v, expand)
-		if len(children) > 0 {
-			f.Children = children
-			return
+		if o.maxDepth >= 0 && depth >= o.maxDepth {
+			f.Type = "{...}"
+			f.Truncated = hasFields(v)
+			return false
+		}
+		if hasVisibleFields(v, o) {
+			return true
 		}
 		f.Type = "{...}"
-		return
+		return false
 	}
 
 	if kind == cue.ListKind {
 		f.Type = formatListType(v)
-		return
+		return false
 	}
 
 	f.Type = formatKind(kind)
+	return false
+}
+
+// hasVisibleFields reports whether v has any regular, optional or pattern
+// fields that pass the attribute filters, that is, whether walking it finds
+// any.
+func hasVisibleFields(v cue.Value, o walkOptions) bool {
+	iter, err := v.Fields(cue.Optional(true), cue.Patterns(true))
+	if err != nil {
+		return false
+	}
+	for iter.Next() {
+		if o.includes(iter.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFields reports whether v has any regular, optional or pattern fields.
+func hasFields(v cue.Value) bool {
+	iter, err := v.Fields(cue.Optional(true), cue.Patterns(true))
+	return err == nil && iter.Next()
 }
 
 func formatDisjunction(args []cue.Value) string {
@@ -290,10 +491,7 @@ func definitionRefName(v cue.Value) (string, bool) {
 // Returns declarations grouped by category. Only definitions with @odin attribute are included.
 // Private definitions (prefixed with _#) are skipped.
 func WalkDeclarations(value cue.Value, opts ...WalkOption) []*Declaration {
-	o := &walkOptions{}
-	for _, opt := range opts {
-		opt(o)
-	}
+	o := newWalkOptions(opts)
 
 	iter, err := value.Fields(cue.Definitions(true))
 	if err != nil {
@@ -378,7 +576,15 @@ func WalkDeclarations(value cue.Value, opts ...WalkOption) []*Declaration {
 				}
 			}
 
-			children := walkFields(v, o.expand || forceExpand)
+			childOpts := o
+			childOpts.expand = o.expand || forceExpand
+			if o.maxDepth == 0 {
+				decl.Type = "{...}"
+				decl.Truncated = hasFields(v)
+				declarations = append(declarations, decl)
+				continue
+			}
+			children := walkFields(v, childOpts, 1)
 			if len(children) > 0 {
 				decl.Children = children
 				decl.Type = "{...}"
@@ -3,6 +3,9 @@
 package schema_test
 
 import (
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 
 	"cuelang.org/go/cue"
@@ -68,3 +71,314 @@ func TestWalkSchemaWithExpand(t *testing.T) {
 		t.Errorf("with expand: expected 1 child, got %d", len(fieldsExpanded[0].Children))
 	}
 }
+
+// TestAnnotateOrigins verifies leaves are marked by where their value comes from.
+func TestAnnotateOrigins(t *testing.T) {
+	ctx := cuecontext.New()
+	v := ctx.CompileString(`
+		#Config: {
+			image: string
+			replicas: int | *1
+			port: int | *8080
+			debug: bool | *false
+			db: {
+				host: string | *"localhost"
+			}
+			labels: [string]: string
+			"app.kubernetes.io/name": string | *"web"
+			"log-level": string | *"info"
+			"pod-labels": tier: string | *"frontend"
+		}
+	`)
+	values := ctx.CompileString(`
+		"app.kubernetes.io/name": "shop"
+		"pod-labels": tier: "backend"
+		image: "nginx"
+		replicas: 3
+		port: 8080
+		db: host: "db.internal"
+		labels: team: "web"
+	`)
+
+	fields := schema.WalkSchema(v.LookupPath(cue.ParsePath("#Config")))
+	schema.AnnotateOrigins(fields, values)
+
+	got := make(map[string]schema.Origin)
+	var collect func(prefix string, fields []*schema.SchemaField)
+	collect = func(prefix string, fields []*schema.SchemaField) {
+		for _, f := range fields {
+			if len(f.Children) > 0 {
+				collect(prefix+f.Name+".", f.Children)
+				continue
+			}
+			got[prefix+f.Name] = f.Origin
+		}
+	}
+	collect("", fields)
+
+	tests := []struct {
+		path string
+		want schema.Origin
+	}{
+		{path: "image", want: schema.OriginSet},
+		{path: "replicas", want: schema.OriginOverridden},
+		{path: "port", want: schema.OriginSet},
+		{path: "debug", want: schema.OriginDefault},
+		{path: "db.host", want: schema.OriginOverridden},
+		{path: "labels.[string]", want: ""},
+		{path: `"app.kubernetes.io/name"`, want: schema.OriginOverridden},
+		{path: `"log-level"`, want: schema.OriginDefault},
+		{path: `"pod-labels".tier`, want: schema.OriginOverridden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got[tt.path] != tt.want {
+				t.Errorf("Origin = %q, want %q", got[tt.path], tt.want)
+			}
+		})
+	}
+}
+
+// TestWalkSchemaWithMaxDepth verifies nesting is cut off at the requested depth.
+func TestWalkSchemaWithMaxDepth(t *testing.T) {
+	ctx := cuecontext.New()
+	v := ctx.CompileString(`
+		#Config: {
+			name: string
+			db: {
+				host: string
+				pool: {
+					size: int
+				}
+			}
+			empty: {...}
+		}
+	`)
+	config := v.LookupPath(cue.ParsePath("#Config"))
+
+	tests := []struct {
+		name          string
+		depth         int
+		wantDB        string
+		wantTruncated bool
+		wantPool      string
+	}{
+		{name: "unlimited", depth: -1, wantPool: "size"},
+		{name: "top level", depth: 0, wantDB: "{...}", wantTruncated: true},
+		{name: "two levels", depth: 1, wantPool: "{...}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := schema.WalkSchema(config, schema.WithMaxDepth(tt.depth))
+			if len(fields) != 3 {
+				t.Fatalf("expected 3 fields, got %d", len(fields))
+			}
+			db, empty := fields[1], fields[2]
+			if db.Type != tt.wantDB || db.Truncated != tt.wantTruncated {
+				t.Errorf("db: Type = %q, Truncated = %v, want %q, %v", db.Type, db.Truncated, tt.wantDB, tt.wantTruncated)
+			}
+			if empty.Truncated {
+				t.Errorf("empty struct should not be marked truncated")
+			}
+			if tt.wantPool == "" {
+				return
+			}
+			if len(db.Children) != 2 {
+				t.Fatalf("expected 2 db children, got %d", len(db.Children))
+			}
+			pool := db.Children[1]
+			got := pool.Type
+			if len(pool.Children) > 0 {
+				got = pool.Children[0].Name
+			}
+			if got != tt.wantPool {
+				t.Errorf("pool = %q, want %q", got, tt.wantPool)
+			}
+		})
+	}
+}
+
+// TestWalkSchemaFunc verifies fields are visited depth first, each before its
+// children, with their paths.
+func TestWalkSchemaFunc(t *testing.T) {
+	ctx := cuecontext.New()
+	v := ctx.CompileString(`
+		#Config: {
+			name: string
+			db: {
+				host: string | *"localhost"
+				pool: {
+					size: int
+				}
+			}
+			secret: string @odin(hidden)
+			labels: [string]: string
+		}
+	`)
+	config := v.LookupPath(cue.ParsePath("#Config"))
+
+	var got []string
+	err := schema.WalkSchemaFunc(config, func(path string, f *schema.SchemaField) error {
+		got = append(got, path+" "+f.Type)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkSchemaFunc() error = %v", err)
+	}
+	want := []string{
+		"name string",
+		"db ",
+		"db.host string",
+		"db.pool ",
+		"db.pool.size int",
+		"labels ",
+		"labels.[string] string",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("visited:\n  %s\nwant:\n  %s", strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+
+	// WalkSchema builds the same fields into a tree.
+	var flat []string
+	var collect func(prefix string, fields []*schema.SchemaField)
+	collect = func(prefix string, fields []*schema.SchemaField) {
+		for _, f := range fields {
+			flat = append(flat, prefix+f.Name+" "+f.Type)
+			collect(prefix+f.Name+".", f.Children)
+		}
+	}
+	collect("", schema.WalkSchema(config))
+	if !slices.Equal(flat, want) {
+		t.Errorf("WalkSchema() fields:\n  %s\nwant:\n  %s", strings.Join(flat, "\n  "), strings.Join(want, "\n  "))
+	}
+}
+
+// TestWalkSchemaFuncEarlyExit verifies the visit function can skip children
+// or stop the walk.
+func TestWalkSchemaFuncEarlyExit(t *testing.T) {
+	ctx := cuecontext.New()
+	v := ctx.CompileString(`
+		#Config: {
+			name: string
+			db: {
+				host: string
+				port: int
+			}
+			replicas: int
+		}
+	`)
+	config := v.LookupPath(cue.ParsePath("#Config"))
+
+	walk := func(stopAt string, stop error) ([]string, error) {
+		var visited []string
+		err := schema.WalkSchemaFunc(config, func(path string, f *schema.SchemaField) error {
+			visited = append(visited, path)
+			if path == stopAt {
+				return stop
+			}
+			return nil
+		})
+		return visited, err
+	}
+
+	visited, err := walk("db", schema.SkipChildren)
+	if err != nil {
+		t.Fatalf("SkipChildren: error = %v", err)
+	}
+	if want := []string{"name", "db", "replicas"}; !slices.Equal(visited, want) {
+		t.Errorf("SkipChildren: visited %v, want %v", visited, want)
+	}
+
+	visited, err = walk("db.host", schema.SkipAll)
+	if err != nil {
+		t.Fatalf("SkipAll: error = %v", err)
+	}
+	if want := []string{"name", "db", "db.host"}; !slices.Equal(visited, want) {
+		t.Errorf("SkipAll: visited %v, want %v", visited, want)
+	}
+
+	errFound := errors.New("found")
+	visited, err = walk("db.host", errFound)
+	if err != errFound {
+		t.Fatalf("error = %v, want %v", err, errFound)
+	}
+	if want := []string{"name", "db", "db.host"}; !slices.Equal(visited, want) {
+		t.Errorf("visited %v, want %v", visited, want)
+	}
+}
+
+// TestWalkSchemaWithAttributeFilter verifies fields can be filtered by a
+// custom attribute, on top of @odin(hidden).
+func TestWalkSchemaWithAttributeFilter(t *testing.T) {
+	ctx := cuecontext.New()
+	v := ctx.CompileString(`
+		#Config: {
+			name: string @odin(public)
+			db: {
+				host: string @odin(public)
+				password: string
+			} @odin(public)
+			internal: {
+				debug: bool @odin(public)
+			}
+			secret: string @odin(public) @odin(hidden)
+		}
+	`)
+	config := v.LookupPath(cue.ParsePath("#Config"))
+
+	public := func(attrs []cue.Attribute) bool {
+		for _, a := range attrs {
+			if arg, err := a.String(0); err == nil && a.Name() == "odin" && arg == "public" {
+				return true
+			}
+		}
+		return false
+	}
+
+	var got []string
+	err := schema.WalkSchemaFunc(config, func(path string, f *schema.SchemaField) error {
+		got = append(got, path)
+		return nil
+	}, schema.WithAttributeFilter(public))
+	if err != nil {
+		t.Fatalf("WalkSchemaFunc() error = %v", err)
+	}
+	// internal isn't public, so its public child isn't reached; secret is
+	// still hidden.
+	if want := []string{"name", "db", "db.host"}; !slices.Equal(got, want) {
+		t.Errorf("visited %v, want %v", got, want)
+	}
+
+	fields := schema.WalkSchema(config, schema.WithAttributeFilter(public))
+	if len(fields) != 2 || fields[1].Name != "db" || len(fields[1].Children) != 1 {
+		t.Fatalf("WalkSchema() = %v, want name and db with one child", fields)
+	}
+}
+
+// TestWalkSchemaExamples verifies example values are read from @odin
+// attributes.
+func TestWalkSchemaExamples(t *testing.T) {
+	ctx := cuecontext.New()
+	v := ctx.CompileString(`
+		#Config: {
+			port: int @odin(example=8080)
+			ports: [...int] @odin(example=80) @odin(example=443)
+			host: string @odin(example="db.internal", expand)
+			name: string
+		}
+	`)
+	fields := schema.WalkSchema(v.LookupPath(cue.ParsePath("#Config")))
+
+	want := map[string][]string{
+		"port":  {"8080"},
+		"ports": {"80", "443"},
+		"host":  {"db.internal"},
+		"name":  nil,
+	}
+	for _, f := range fields {
+		if !slices.Equal(f.Examples, want[f.Name]) {
+			t.Errorf("%s: Examples = %q, want %q", f.Name, f.Examples, want[f.Name])
+		}
+	}
+}